@@ -0,0 +1,323 @@
+// internal/catalog/catalog.go
+// Package catalog describes the AI models LocalCloud knows about. The
+// catalog ships embedded in the binary and can be extended or corrected with
+// ~/.localcloud/catalog.yaml.
+package catalog
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed catalog.yaml
+var embedded []byte
+
+// Capabilities a model can have
+const (
+	CapabilityChat      = "chat"
+	CapabilityCode      = "code"
+	CapabilityTools     = "tools"
+	CapabilityVision    = "vision"
+	CapabilityEmbedding = "embedding"
+)
+
+const (
+	MB = 1024 * 1024
+	GB = 1024 * MB
+)
+
+// Catalog is a versioned list of models
+type Catalog struct {
+	Version int     `yaml:"version"`
+	Updated string  `yaml:"updated"`
+	Models  []Model `yaml:"models"`
+
+	// Sources lists the files the catalog was read from
+	Sources []string `yaml:"-"`
+}
+
+// Model describes a model and its default variant
+type Model struct {
+	Name         string    `yaml:"name"`
+	Family       string    `yaml:"family"`
+	Description  string    `yaml:"description"`
+	Parameters   string    `yaml:"parameters"`
+	Quantization string    `yaml:"quantization"`
+	Size         ByteSize  `yaml:"size"` // Download size
+	RAM          ByteSize  `yaml:"ram"`  // Memory needed to run it
+	Context      int       `yaml:"context"`
+	Dimensions   int       `yaml:"dimensions,omitempty"` // Embedding models only
+	Capabilities []string  `yaml:"capabilities"`
+	License      string    `yaml:"license"`
+	Recommended  bool      `yaml:"recommended,omitempty"`
+	Default      bool      `yaml:"default,omitempty"`
+	Variants     []Variant `yaml:"variants,omitempty"`
+}
+
+// Variant is another quantization of a model, pulled by its tag
+type Variant struct {
+	Tag          string   `yaml:"tag"`
+	Quantization string   `yaml:"quantization"`
+	Size         ByteSize `yaml:"size"`
+	RAM          ByteSize `yaml:"ram"`
+}
+
+// Has reports whether the model has a capability
+func (m Model) Has(capability string) bool {
+	for _, c := range m.Capabilities {
+		if strings.EqualFold(c, capability) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmbedding reports whether the model produces embeddings
+func (m Model) IsEmbedding() bool {
+	return m.Has(CapabilityEmbedding)
+}
+
+// ByteSize is a size written as "274MB" or "2.3GB" in the catalog
+type ByteSize int64
+
+// UnmarshalYAML parses sizes with a KB, MB, GB or TB suffix, or plain bytes
+func (b *ByteSize) UnmarshalYAML(value *yaml.Node) error {
+	size, err := ParseSize(value.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+	*b = ByteSize(size)
+	return nil
+}
+
+// MarshalYAML writes the size in the catalog's notation
+func (b ByteSize) MarshalYAML() (interface{}, error) {
+	return b.String(), nil
+}
+
+// String formats the size as the catalog writes it
+func (b ByteSize) String() string {
+	switch {
+	case b >= GB:
+		return strconv.FormatFloat(float64(b)/GB, 'f', 1, 64) + "GB"
+	case b >= MB:
+		return fmt.Sprintf("%dMB", int64(b)/MB)
+	default:
+		return fmt.Sprintf("%dB", int64(b))
+	}
+}
+
+// ParseSize parses a size such as "4GB", "512 MB" or "1073741824"
+func ParseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	multipliers := []struct {
+		suffix string
+		factor float64
+	}{
+		{"TB", 1024 * GB}, {"GB", GB}, {"MB", MB}, {"KB", 1024}, {"G", GB}, {"M", MB}, {"B", 1},
+	}
+
+	factor := 1.0
+	for _, m := range multipliers {
+		if strings.HasSuffix(s, m.suffix) {
+			factor = m.factor
+			s = strings.TrimSpace(strings.TrimSuffix(s, m.suffix))
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(n * factor), nil
+}
+
+// Parse reads a catalog document
+func Parse(data []byte) (*Catalog, error) {
+	var c Catalog
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	for i, m := range c.Models {
+		if m.Name == "" {
+			return nil, fmt.Errorf("model %d has no name", i+1)
+		}
+	}
+	return &c, nil
+}
+
+// UserPath returns the path of the user's catalog override
+func UserPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".localcloud", "catalog.yaml")
+}
+
+// Load reads the embedded catalog and merges the user override over it.
+// Override entries replace embedded entries with the same name and new
+// entries are added; the catalog takes the higher of the two versions.
+func Load() (*Catalog, error) {
+	c, err := Parse(embedded)
+	if err != nil {
+		return nil, fmt.Errorf("embedded catalog: %w", err)
+	}
+	c.Sources = []string{"built-in"}
+
+	path := UserPath()
+	if path == "" {
+		return c, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+
+	override, err := Parse(data)
+	if err != nil {
+		return c, fmt.Errorf("%s: %w", path, err)
+	}
+	c.merge(override)
+	c.Sources = append(c.Sources, path)
+	return c, nil
+}
+
+func (c *Catalog) merge(override *Catalog) {
+	if override.Version > c.Version {
+		c.Version = override.Version
+		c.Updated = override.Updated
+	}
+
+	for _, m := range override.Models {
+		replaced := false
+		for i := range c.Models {
+			if c.Models[i].Name == m.Name {
+				c.Models[i] = m
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			c.Models = append(c.Models, m)
+		}
+	}
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultCatalog *Catalog
+	defaultErr     error
+)
+
+// Default returns the loaded catalog, read once per process. If the user
+// override is invalid the built-in catalog is used and the problem is
+// reported by Err.
+func Default() *Catalog {
+	defaultOnce.Do(func() {
+		defaultCatalog, defaultErr = Load()
+		if defaultCatalog == nil {
+			defaultCatalog = &Catalog{}
+		}
+	})
+	return defaultCatalog
+}
+
+// Err returns the error from loading the default catalog, if any
+func Err() error {
+	Default()
+	return defaultErr
+}
+
+// Lookup finds a model by name or variant tag. A missing tag matches
+// ":latest" and names are compared without case.
+func (c *Catalog) Lookup(name string) *Model {
+	name = strings.ToLower(strings.TrimSuffix(name, ":latest"))
+	for i := range c.Models {
+		m := &c.Models[i]
+		if strings.ToLower(strings.TrimSuffix(m.Name, ":latest")) == name {
+			return m
+		}
+		for _, v := range m.Variants {
+			if strings.ToLower(v.Tag) == name {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+// Filter selects models in Search
+type Filter struct {
+	Query       string // Substring of the name, family or description
+	Capability  string
+	Family      string
+	MaxRAM      int64 // Models whose default variant fits; 0 for no limit
+	Recommended bool
+}
+
+// Search returns the models matching a filter, smallest RAM first
+func (c *Catalog) Search(f Filter) []Model {
+	query := strings.ToLower(f.Query)
+
+	var results []Model
+	for _, m := range c.Models {
+		if f.Capability != "" && !m.Has(f.Capability) {
+			continue
+		}
+		if f.Family != "" && !strings.EqualFold(m.Family, f.Family) {
+			continue
+		}
+		if f.MaxRAM > 0 && int64(m.RAM) > f.MaxRAM {
+			continue
+		}
+		if f.Recommended && !m.Recommended {
+			continue
+		}
+		if query != "" &&
+			!strings.Contains(strings.ToLower(m.Name), query) &&
+			!strings.Contains(strings.ToLower(m.Family), query) &&
+			!strings.Contains(strings.ToLower(m.Description), query) {
+			continue
+		}
+		results = append(results, m)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].RAM < results[j].RAM })
+	return results
+}
+
+// Recommended returns the recommended embedding models when embedding is
+// true, or the recommended language models otherwise, in catalog order
+func (c *Catalog) Recommended(embedding bool) []Model {
+	var results []Model
+	for _, m := range c.Models {
+		if m.Recommended && m.IsEmbedding() == embedding {
+			results = append(results, m)
+		}
+	}
+	return results
+}
+
+// Embedding returns all embedding models in catalog order
+func (c *Catalog) Embedding() []Model {
+	var results []Model
+	for _, m := range c.Models {
+		if m.IsEmbedding() {
+			results = append(results, m)
+		}
+	}
+	return results
+}
@@ -0,0 +1,81 @@
+// internal/chat/transcript.go
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/localcloud-sh/localcloud/internal/rag"
+)
+
+// Message is a turn of a chat session
+type Message struct {
+	Role    string       `json:"role"` // "user" or "assistant"
+	Content string       `json:"content"`
+	Model   string       `json:"model,omitempty"`   // Model that wrote an assistant turn
+	Sources []rag.Source `json:"sources,omitempty"` // Sources an answer was grounded in
+	Elapsed float64      `json:"elapsed_seconds,omitempty"`
+}
+
+// Transcript is a chat session as saved to and loaded from JSON
+type Transcript struct {
+	Model    string                 `json:"model"`
+	System   string                 `json:"system,omitempty"`
+	Options  map[string]interface{} `json:"options,omitempty"`
+	RAG      bool                   `json:"rag,omitempty"`
+	Messages []Message              `json:"messages"`
+	SavedAt  time.Time              `json:"saved_at"`
+}
+
+// ChatMessages returns the conversation to send to a model, starting with
+// the system prompt
+func (t *Transcript) ChatMessages() []models.ChatMessage {
+	messages := make([]models.ChatMessage, 0, len(t.Messages)+1)
+	if t.System != "" {
+		messages = append(messages, models.ChatMessage{Role: "system", Content: t.System})
+	}
+	for _, m := range t.Messages {
+		messages = append(messages, models.ChatMessage{Role: m.Role, Content: m.Content})
+	}
+	return messages
+}
+
+// Save writes the transcript to path as indented JSON
+func (t *Transcript) Save(path string) error {
+	t.SavedAt = time.Now()
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to save transcript: %w", err)
+	}
+	return nil
+}
+
+// LoadTranscript reads a transcript saved with Save
+func LoadTranscript(path string) (*Transcript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+
+	var t Transcript
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("invalid transcript %s: %w", path, err)
+	}
+	for i, m := range t.Messages {
+		if m.Role != "user" && m.Role != "assistant" {
+			return nil, fmt.Errorf("invalid transcript %s: message %d has role %q", path, i+1, m.Role)
+		}
+	}
+	return &t, nil
+}
+
+// DefaultTranscriptPath returns a file name for a transcript saved now
+func DefaultTranscriptPath() string {
+	return fmt.Sprintf("chat-%s.json", time.Now().Format("20060102-150405"))
+}
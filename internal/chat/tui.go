@@ -0,0 +1,664 @@
+// internal/chat/tui.go
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/localcloud-sh/localcloud/internal/rag"
+)
+
+// Retriever finds the sources that ground the answer to question. used is
+// the number of tokens the rest of the conversation already takes.
+type Retriever func(ctx context.Context, question string, used int) ([]rag.Source, error)
+
+// Session configures an interactive chat
+type Session struct {
+	Backend    models.Backend
+	Transcript *Transcript
+	Retriever  Retriever // Set to allow grounding answers with /rag
+}
+
+// Run starts the chat UI and returns the transcript when the user quits
+func Run(s Session) (*Transcript, error) {
+	if s.Transcript == nil {
+		s.Transcript = &Transcript{}
+	}
+	if s.Transcript.Options == nil {
+		s.Transcript.Options = map[string]interface{}{}
+	}
+	if s.Retriever == nil {
+		s.Transcript.RAG = false
+	}
+
+	final, err := tea.NewProgram(newModel(s), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, err
+	}
+	return final.(model).transcript, nil
+}
+
+// Options that /set accepts, with whether they take integers
+var chatOptions = map[string]bool{
+	"temperature":       false,
+	"top_p":             false,
+	"top_k":             true,
+	"num_predict":       true,
+	"num_ctx":           true,
+	"seed":              true,
+	"repeat_penalty":    false,
+	"presence_penalty":  false,
+	"frequency_penalty": false,
+	"stop":              false,
+}
+
+const helpText = `Enter sends, Alt+Enter or Ctrl+J adds a line, Esc stops a reply, Ctrl+C quits.
+
+/model [name]        show or switch the model
+/models              list installed models
+/system [prompt]     show or set the system prompt (/system - clears it)
+/set [key value]     show or set a parameter: ` + "temperature, top_p, top_k, num_predict, num_ctx, seed, repeat_penalty, presence_penalty, frequency_penalty, stop" + `
+/unset key           reset a parameter to the model's default
+/rag [on|off]        ground answers in the vector store (needs lc chat --rag)
+/save [file]         save the transcript as JSON
+/load file           load a transcript
+/clear               start a new conversation
+/quit                leave`
+
+var (
+	userStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	modelStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
+	noticeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	headerStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+)
+
+// entry is a block of the conversation view. Notices are shown but not
+// saved in the transcript.
+type entry struct {
+	message *Message
+	notice  string
+	isError bool
+}
+
+// Messages from a streaming reply
+type (
+	tokenMsg string
+	replyMsg struct {
+		reply   string
+		sources []rag.Source
+		elapsed time.Duration
+		err     error
+	}
+)
+
+type model struct {
+	session    Session
+	transcript *Transcript
+
+	entries  []entry
+	viewport viewport.Model
+	input    textarea.Model
+	spinner  spinner.Model
+	width    int
+	ready    bool
+
+	streaming bool
+	partial   string
+	cancel    context.CancelFunc
+	stream    chan tea.Msg
+}
+
+func newModel(s Session) model {
+	input := textarea.New()
+	input.Placeholder = "Send a message, or /help"
+	input.ShowLineNumbers = false
+	input.Prompt = "│ "
+	input.SetHeight(3)
+	input.KeyMap.InsertNewline.SetKeys("alt+enter", "ctrl+j")
+	input.Focus()
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+
+	m := model{
+		session:    s,
+		transcript: s.Transcript,
+		input:      input,
+		spinner:    sp,
+	}
+	for i := range s.Transcript.Messages {
+		m.entries = append(m.entries, entry{message: &s.Transcript.Messages[i]})
+	}
+	m.notify("Type /help for commands")
+	return m
+}
+
+func (m model) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.input.SetWidth(msg.Width)
+		height := msg.Height - m.input.Height() - 3 // Header, status and spacing
+		if height < 3 {
+			height = 3
+		}
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, height)
+			m.ready = true
+		} else {
+			m.viewport.Width, m.viewport.Height = msg.Width, height
+		}
+		m.refresh()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			if m.cancel != nil {
+				m.cancel()
+			}
+			return m, tea.Quit
+		case "esc":
+			if m.streaming && m.cancel != nil {
+				m.cancel()
+			}
+			return m, nil
+		case "enter":
+			if m.streaming {
+				return m, nil
+			}
+			text := strings.TrimSpace(m.input.Value())
+			m.input.Reset()
+			if text == "" {
+				return m, nil
+			}
+			if strings.HasPrefix(text, "/") {
+				cmd := m.command(text)
+				m.refresh()
+				return m, cmd
+			}
+			cmd := m.send(text)
+			m.refresh()
+			return m, cmd
+		case "pgup", "pgdown":
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+
+	case tokenMsg:
+		m.partial += string(msg)
+		m.refresh()
+		return m, m.wait()
+
+	case replyMsg:
+		m.finish(msg)
+		m.refresh()
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.streaming {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m model) View() string {
+	if !m.ready {
+		return "Starting chat...\n"
+	}
+
+	header := headerStyle.Render("lc chat") + noticeStyle.Render(" · "+m.describe())
+
+	status := noticeStyle.Render("Enter to send · Alt+Enter for a new line · /help · Ctrl+C to quit")
+	if m.streaming {
+		status = m.spinner.View() + noticeStyle.Render(" "+m.transcript.Model+" is replying · Esc to stop")
+	}
+
+	return header + "\n" + m.viewport.View() + "\n" + status + "\n" + m.input.View()
+}
+
+// describe summarizes the model and settings for the header
+func (m model) describe() string {
+	parts := []string{m.transcript.Model}
+	if len(m.transcript.Options) > 0 {
+		parts = append(parts, formatOptions(m.transcript.Options))
+	}
+	if m.transcript.System != "" {
+		parts = append(parts, "system prompt set")
+	}
+	if m.transcript.RAG {
+		parts = append(parts, "rag")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// send starts a streaming reply to a user message
+func (m *model) send(text string) tea.Cmd {
+	m.transcript.Messages = append(m.transcript.Messages, Message{Role: "user", Content: text})
+	m.rebuildEntries()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.streaming = true
+	m.partial = ""
+	m.stream = make(chan tea.Msg, 64)
+
+	backend := m.session.Backend
+	retriever := m.session.Retriever
+	modelName := m.transcript.Model
+	messages := m.transcript.ChatMessages()
+	options := copyOptions(m.transcript.Options)
+	grounded := m.transcript.RAG && retriever != nil
+	stream := m.stream
+
+	go func() {
+		defer close(stream)
+		start := time.Now()
+
+		var sources []rag.Source
+		if grounded {
+			used := 0
+			for _, message := range messages[:len(messages)-1] {
+				used += rag.EstimateTokens(message.Content)
+			}
+			var err error
+			sources, err = retriever(ctx, text, used)
+			if err != nil {
+				stream <- replyMsg{err: err}
+				return
+			}
+			if len(sources) > 0 {
+				messages = rag.GroundConversation(messages, sources)
+			}
+		}
+
+		reply, err := backend.Generate(ctx, modelName, messages, options, func(token string) {
+			select {
+			case stream <- tokenMsg(token):
+			case <-ctx.Done():
+			}
+		})
+		stream <- replyMsg{reply: reply, sources: sources, elapsed: time.Since(start), err: err}
+	}()
+
+	return tea.Batch(m.wait(), m.spinner.Tick)
+}
+
+// wait returns the next message of the streaming reply
+func (m model) wait() tea.Cmd {
+	stream := m.stream
+	return func() tea.Msg {
+		msg, ok := <-stream
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// finish records a completed, stopped or failed reply
+func (m *model) finish(msg replyMsg) {
+	stopped := errors.Is(msg.err, context.Canceled)
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.cancel = nil
+	m.streaming = false
+
+	reply := msg.reply
+	if reply == "" {
+		reply = m.partial
+	}
+	m.partial = ""
+
+	if reply != "" {
+		m.transcript.Messages = append(m.transcript.Messages, Message{
+			Role:    "assistant",
+			Content: reply,
+			Model:   m.transcript.Model,
+			Sources: msg.sources,
+			Elapsed: msg.elapsed.Round(time.Millisecond).Seconds(),
+		})
+	} else if n := len(m.transcript.Messages); n > 0 && msg.err != nil {
+		// Drop the unanswered question so it can be sent again
+		m.transcript.Messages = m.transcript.Messages[:n-1]
+	}
+	m.rebuildEntries()
+
+	switch {
+	case stopped:
+		m.notify("Stopped")
+	case msg.err != nil:
+		m.fail(msg.err)
+	case m.transcript.RAG && len(msg.sources) == 0:
+		m.notify("No matching documents found; answered without sources")
+	}
+}
+
+// command runs a slash command
+func (m *model) command(text string) tea.Cmd {
+	name, arg, _ := strings.Cut(text, " ")
+	arg = strings.TrimSpace(arg)
+
+	switch name {
+	case "/help":
+		m.notify(helpText)
+
+	case "/quit", "/exit":
+		return tea.Quit
+
+	case "/model":
+		if arg == "" {
+			m.notify("Model: " + m.transcript.Model)
+			return nil
+		}
+		installed, err := m.session.Backend.List(context.Background())
+		if err != nil {
+			m.fail(err)
+			return nil
+		}
+		for _, im := range installed {
+			if im.Name == arg || strings.TrimSuffix(im.Name, ":latest") == arg {
+				m.transcript.Model = arg
+				m.notify("Switched to " + arg)
+				return nil
+			}
+		}
+		m.fail(fmt.Errorf("model %s is not installed (see /models)", arg))
+
+	case "/models":
+		installed, err := m.session.Backend.List(context.Background())
+		if err != nil {
+			m.fail(err)
+			return nil
+		}
+		if len(installed) == 0 {
+			m.notify("No models installed")
+			return nil
+		}
+		var lines []string
+		for _, im := range installed {
+			marker := "  "
+			if im.Name == m.transcript.Model || strings.TrimSuffix(im.Name, ":latest") == m.transcript.Model {
+				marker = "* "
+			}
+			lines = append(lines, marker+im.Name)
+		}
+		m.notify(strings.Join(lines, "\n"))
+
+	case "/system":
+		switch arg {
+		case "":
+			if m.transcript.System == "" {
+				m.notify("No system prompt")
+			} else {
+				m.notify("System prompt:\n" + m.transcript.System)
+			}
+		case "-":
+			m.transcript.System = ""
+			m.notify("System prompt cleared")
+		default:
+			m.transcript.System = arg
+			m.notify("System prompt set")
+		}
+
+	case "/set":
+		if arg == "" {
+			if len(m.transcript.Options) == 0 {
+				m.notify("No parameters set; the model's defaults apply")
+			} else {
+				m.notify("Parameters: " + formatOptions(m.transcript.Options))
+			}
+			return nil
+		}
+		key, value, _ := strings.Cut(arg, " ")
+		if err := setOption(m.transcript.Options, key, strings.TrimSpace(value)); err != nil {
+			m.fail(err)
+			return nil
+		}
+		m.notify("Parameters: " + formatOptions(m.transcript.Options))
+
+	case "/unset":
+		if _, ok := m.transcript.Options[arg]; !ok {
+			m.fail(fmt.Errorf("%s is not set", arg))
+			return nil
+		}
+		delete(m.transcript.Options, arg)
+		m.notify(arg + " reset to the model's default")
+
+	case "/rag":
+		if m.session.Retriever == nil {
+			m.fail(fmt.Errorf("start the chat with lc chat --rag to ground answers in the vector store"))
+			return nil
+		}
+		switch arg {
+		case "on":
+			m.transcript.RAG = true
+		case "off":
+			m.transcript.RAG = false
+		case "":
+			m.transcript.RAG = !m.transcript.RAG
+		default:
+			m.fail(fmt.Errorf("use /rag on or /rag off"))
+			return nil
+		}
+		if m.transcript.RAG {
+			m.notify("Answers are grounded in the vector store")
+		} else {
+			m.notify("Answers are no longer grounded in the vector store")
+		}
+
+	case "/save":
+		path := arg
+		if path == "" {
+			path = DefaultTranscriptPath()
+		}
+		if err := m.transcript.Save(path); err != nil {
+			m.fail(err)
+			return nil
+		}
+		m.notify("Saved to " + path)
+
+	case "/load":
+		if arg == "" {
+			m.fail(fmt.Errorf("usage: /load <file>"))
+			return nil
+		}
+		loaded, err := LoadTranscript(arg)
+		if err != nil {
+			m.fail(err)
+			return nil
+		}
+		if loaded.Model == "" {
+			loaded.Model = m.transcript.Model
+		}
+		if loaded.Options == nil {
+			loaded.Options = map[string]interface{}{}
+		}
+		if m.session.Retriever == nil {
+			loaded.RAG = false
+		}
+		m.transcript = loaded
+		m.rebuildEntries()
+		m.notify(fmt.Sprintf("Loaded %s (%d messages)", arg, len(loaded.Messages)))
+
+	case "/clear":
+		m.transcript.Messages = nil
+		m.entries = nil
+		m.notify("Started a new conversation")
+
+	default:
+		m.fail(fmt.Errorf("unknown command %s (see /help)", name))
+	}
+	return nil
+}
+
+// notify shows a notice in the conversation view
+func (m *model) notify(text string) {
+	m.entries = append(m.entries, entry{notice: text})
+}
+
+// fail shows an error in the conversation view
+func (m *model) fail(err error) {
+	m.entries = append(m.entries, entry{notice: err.Error(), isError: true})
+}
+
+// rebuildEntries points the message entries at the transcript again after
+// it changed, keeping the notices in order
+func (m *model) rebuildEntries() {
+	var entries []entry
+	next := 0
+	for _, e := range m.entries {
+		if e.message == nil {
+			entries = append(entries, e)
+			continue
+		}
+		if next < len(m.transcript.Messages) {
+			entries = append(entries, entry{message: &m.transcript.Messages[next]})
+			next++
+		}
+	}
+	for ; next < len(m.transcript.Messages); next++ {
+		entries = append(entries, entry{message: &m.transcript.Messages[next]})
+	}
+	m.entries = entries
+}
+
+// refresh renders the conversation into the viewport
+func (m *model) refresh() {
+	if !m.ready {
+		return
+	}
+
+	width := m.width - 2
+	if width < 20 {
+		width = 20
+	}
+	wrap := lipgloss.NewStyle().Width(width)
+
+	var b strings.Builder
+	for _, e := range m.entries {
+		if e.message == nil {
+			style := noticeStyle
+			if e.isError {
+				style = errorStyle
+			}
+			b.WriteString(style.Width(width).Render(e.notice))
+			b.WriteString("\n\n")
+			continue
+		}
+		b.WriteString(renderMessage(*e.message, wrap))
+	}
+	if m.streaming {
+		b.WriteString(modelStyle.Render(m.transcript.Model))
+		b.WriteString("\n")
+		b.WriteString(wrap.Render(m.partial))
+		b.WriteString("\n")
+	}
+
+	m.viewport.SetContent(b.String())
+	m.viewport.GotoBottom()
+}
+
+// renderMessage formats a turn of the conversation
+func renderMessage(message Message, wrap lipgloss.Style) string {
+	var b strings.Builder
+	if message.Role == "user" {
+		b.WriteString(userStyle.Render("You"))
+	} else {
+		b.WriteString(modelStyle.Render(message.Model))
+		if message.Elapsed > 0 {
+			b.WriteString(noticeStyle.Render(fmt.Sprintf(" %.1fs", message.Elapsed)))
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(wrap.Render(message.Content))
+	b.WriteString("\n")
+
+	if len(message.Sources) > 0 {
+		var sources []string
+		for _, s := range message.Sources {
+			sources = append(sources, fmt.Sprintf("[%d] %s", s.Number, s.Location()))
+		}
+		b.WriteString(noticeStyle.Render("Sources: " + strings.Join(sources, "  ")))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// setOption parses a /set value into options
+func setOption(options map[string]interface{}, key, value string) error {
+	integer, ok := chatOptions[key]
+	if !ok {
+		return fmt.Errorf("unknown parameter %s (see /help)", key)
+	}
+	if value == "" {
+		return fmt.Errorf("usage: /set %s <value>", key)
+	}
+
+	switch {
+	case key == "stop":
+		options[key] = strings.Fields(value)
+	case integer:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a whole number", key)
+		}
+		options[key] = n
+	default:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s must be a number", key)
+		}
+		options[key] = f
+	}
+	return nil
+}
+
+// formatOptions renders options as key=value pairs in a stable order
+func formatOptions(options map[string]interface{}) string {
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, options[key]))
+	}
+	return strings.Join(pairs, " ")
+}
+
+func copyOptions(options map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(options))
+	for k, v := range options {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,221 @@
+// internal/cli/chat.go
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/localcloud-sh/localcloud/internal/chat"
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/localcloud-sh/localcloud/internal/rag"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/providers"
+	"github.com/spf13/cobra"
+)
+
+var chatCmd = &cobra.Command{
+	Use:   "chat",
+	Short: "Chat with a model in the terminal",
+	Long: `Open an interactive chat with an installed model. Replies are streamed as
+they are generated.
+
+Enter sends a message and Alt+Enter (or Ctrl+J) adds a line. Commands:
+  /model [name]      show or switch the model mid-session
+  /models            list installed models
+  /system [prompt]   show or set the system prompt
+  /set key value     set a parameter (temperature, top_p, num_predict, ...)
+  /save [file]       save the transcript as JSON
+  /load file         load a transcript
+  /rag [on|off]      toggle grounding (with --rag)
+  /clear, /help, /quit
+
+With --rag, each question is matched against the project's vector store and
+the best chunks are sent with it, as in lc rag query.`,
+	Example: `  lc chat
+  lc chat --model llama3.2:3b --temperature 0.2
+  lc chat --system "You are a terse assistant"
+  lc chat --rag --collection docs
+  lc chat --load chat-20250101-120000.json --save review.json`,
+	Args: cobra.NoArgs,
+	RunE: runChat,
+}
+
+var (
+	chatModel          string
+	chatSystem         string
+	chatTemperature    float64
+	chatMaxTokens      int
+	chatNumCtx         int
+	chatLoad           string
+	chatSave           string
+	chatRAG            bool
+	chatCollection     string
+	chatEmbeddingModel string
+	chatTopK           int
+)
+
+func init() {
+	chatCmd.Flags().StringVarP(&chatModel, "model", "m", "", "Model to chat with (default: configured default model)")
+	chatCmd.Flags().StringVar(&chatSystem, "system", "", "System prompt")
+	chatCmd.Flags().Float64Var(&chatTemperature, "temperature", 0, "Sampling temperature (default: model's)")
+	chatCmd.Flags().IntVar(&chatMaxTokens, "max-tokens", 0, "Maximum tokens per reply (default: model's)")
+	chatCmd.Flags().IntVar(&chatNumCtx, "num-ctx", 0, "Context window in tokens (default: model's, up to 8192 with --rag)")
+	chatCmd.Flags().StringVar(&chatLoad, "load", "", "Continue a transcript saved with /save")
+	chatCmd.Flags().StringVar(&chatSave, "save", "", "Save the transcript to this file when the chat ends")
+	chatCmd.Flags().BoolVar(&chatRAG, "rag", false, "Ground answers in the project's vector store")
+	chatCmd.Flags().StringVar(&chatCollection, "collection", "", "Collection to search with --rag, for qdrant or chroma (default: default)")
+	chatCmd.Flags().StringVar(&chatEmbeddingModel, "embedding-model", "", "Embedding model for --rag (default: configured embedding model)")
+	chatCmd.Flags().IntVar(&chatTopK, "top-k", 8, "Number of chunks to retrieve with --rag")
+}
+
+func runChat(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if cfg.Services.AI.Port == 0 {
+		return fmt.Errorf("the AI service is not configured. Add it with: lc component add llm")
+	}
+
+	backend, err := models.NewBackend(cfg.Services.AI)
+	if err != nil {
+		return err
+	}
+	if err := backend.Health(context.Background()); err != nil {
+		return fmt.Errorf("%w. Start it with: lc start ai", err)
+	}
+
+	transcript := &chat.Transcript{Options: map[string]interface{}{}}
+	if chatLoad != "" {
+		if transcript, err = chat.LoadTranscript(chatLoad); err != nil {
+			return err
+		}
+		if transcript.Options == nil {
+			transcript.Options = map[string]interface{}{}
+		}
+	}
+
+	// Flags override the loaded transcript
+	if chatModel != "" {
+		transcript.Model = chatModel
+	}
+	if transcript.Model == "" {
+		transcript.Model = cfg.Services.AI.Default
+	}
+	if transcript.Model == "" {
+		return fmt.Errorf("no default model configured. Set services.ai.default or use --model")
+	}
+	if cmd.Flags().Changed("system") {
+		transcript.System = chatSystem
+	}
+	if cmd.Flags().Changed("temperature") {
+		transcript.Options["temperature"] = chatTemperature
+	}
+	if chatMaxTokens > 0 {
+		transcript.Options["num_predict"] = chatMaxTokens
+	}
+	if chatNumCtx > 0 {
+		transcript.Options["num_ctx"] = chatNumCtx
+	}
+
+	installed, err := backend.List(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+	if !modelInstalled(installed, transcript.Model) {
+		return fmt.Errorf("model %s is not installed. Pull it with: lc models pull %s", transcript.Model, transcript.Model)
+	}
+
+	session := chat.Session{Backend: backend, Transcript: transcript}
+	if chatRAG {
+		retriever, err := chatRetriever(cfg, transcript)
+		if err != nil {
+			return err
+		}
+		session.Retriever = retriever
+		transcript.RAG = true
+	}
+
+	final, err := chat.Run(session)
+	if err != nil {
+		return fmt.Errorf("chat failed: %w", err)
+	}
+
+	if chatSave != "" && len(final.Messages) > 0 {
+		if err := final.Save(chatSave); err != nil {
+			return err
+		}
+		printSuccess(fmt.Sprintf("Transcript saved to %s", chatSave))
+	}
+	return nil
+}
+
+// chatRetriever connects --rag to the vector store. The context window is
+// fixed for the session so retrieved sources always fit beside the
+// conversation.
+func chatRetriever(cfg *config.Config, transcript *chat.Transcript) (chat.Retriever, error) {
+	if err := requireOllama(cfg, "lc chat --rag"); err != nil {
+		return nil, err
+	}
+
+	embeddingModel := chatEmbeddingModel
+	if embeddingModel == "" {
+		embeddingModel = configuredEmbeddingModel(cfg)
+	}
+	if embeddingModel == "" {
+		return nil, fmt.Errorf("no embedding model configured. Add one with 'lc component add embedding' or use --embedding-model")
+	}
+	if chatTopK <= 0 {
+		return nil, fmt.Errorf("top-k must be positive")
+	}
+
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+	db, err := providers.Open(cfg, &vectordb.Config{Collection: chatCollection})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to vector database: %w", err)
+	}
+
+	window := intOption(transcript.Options["num_ctx"])
+	if window <= 0 {
+		window, _ = manager.ContextLength(transcript.Model)
+		if window <= 0 {
+			window = 4096
+		}
+		if window > 8192 {
+			window = 8192
+		}
+		transcript.Options["num_ctx"] = window
+	}
+	answerTokens := intOption(transcript.Options["num_predict"])
+	if answerTokens <= 0 {
+		answerTokens = 1024
+	}
+
+	return func(ctx context.Context, question string, used int) ([]rag.Source, error) {
+		results, err := rag.Retrieve(ctx, manager, db, embeddingModel, question, chatTopK)
+		if err != nil {
+			return nil, err
+		}
+		budget := rag.ContextBudget(window, answerTokens, question) - used
+		if budget <= 0 {
+			return nil, fmt.Errorf("the conversation fills the %d-token context window; /clear it to keep using --rag", window)
+		}
+		return rag.SelectSources(results, budget), nil
+	}, nil
+}
+
+// intOption reads a numeric option set by a flag or loaded from JSON
+func intOption(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
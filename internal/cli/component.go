@@ -55,17 +55,30 @@ var componentInfoCmd = &cobra.Command{
 var componentUpdateCmd = &cobra.Command{
 	Use:   "update [component-id]",
 	Short: "Update component configuration (e.g., change model)",
-	Long:  `Update a component's configuration, such as changing the AI model for LLM or embedding components.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runComponentUpdate,
+	Long: `Update a component's configuration, such as changing the AI model for LLM or embedding components.
+
+For llm the model and quantization that best fit this machine are suggested,
+taking free memory, CPU cores, the other enabled components and the desired
+context length into account. Choosing a model that would likely swap or run
+out of memory asks for confirmation; with --model it warns.`,
+	Example: `  lc component update llm
+  lc component update llm --context 16384
+  lc component update llm --model llama3.1:8b`,
+	Args: cobra.ExactArgs(1),
+	RunE: runComponentUpdate,
 }
 
+var componentUpdateModel string
+
 func init() {
 	componentCmd.AddCommand(componentListCmd)
 	componentCmd.AddCommand(componentAddCmd)
 	componentCmd.AddCommand(componentRemoveCmd)
 	componentCmd.AddCommand(componentUpdateCmd)
 	componentCmd.AddCommand(componentInfoCmd)
+
+	componentUpdateCmd.Flags().StringVar(&componentUpdateModel, "model", "", "Model to use instead of choosing interactively")
+	componentUpdateCmd.Flags().IntVar(&modelContext, "context", 4096, "Context length in tokens the model should support")
 }
 
 func runComponentList(cmd *cobra.Command, args []string) error {
@@ -403,7 +416,9 @@ func runComponentInfo(cmd *cobra.Command, args []string) error {
 			fmt.Println(successColor("Enabled"))
 
 			// Show current model for AI components
-			if components.IsAIComponent(comp.ID) {
+			if comp.ID == "stt" && cfg.Services.Whisper.Model != "" {
+				fmt.Printf("Current Model: %s\n", cfg.Services.Whisper.Model)
+			} else if components.IsAIComponent(comp.ID) {
 				for _, model := range cfg.Services.AI.Models {
 					isEmbedding := models.IsEmbeddingModel(model)
 					if (comp.ID == "embedding" && isEmbedding) ||
@@ -461,6 +476,9 @@ func runComponentUpdate(cmd *cobra.Command, args []string) error {
 
 	// Find current model
 	var currentModel string
+	if componentID == "stt" {
+		currentModel = cfg.Services.Whisper.Model
+	}
 	for _, model := range cfg.Services.AI.Models {
 		isEmbedding := models.IsEmbeddingModel(model)
 		if (componentID == "embedding" && isEmbedding) ||
@@ -485,7 +503,11 @@ func runComponentUpdate(cmd *cobra.Command, args []string) error {
 	fmt.Println("\nNext steps:")
 	fmt.Println("  • Restart services to apply changes: lc restart")
 
-	if currentModel != "" {
+	if componentID == "embedding" && currentModel != "" {
+		fmt.Println("  • Re-embed stored vectors with the new model: lc vector reembed --model <model>")
+	}
+
+	if currentModel != "" && componentID != "stt" {
 		fmt.Printf("  • Remove old model if no longer needed: lc models remove %s\n", currentModel)
 	}
 
@@ -501,7 +523,7 @@ func addComponent(cfg *config.Config, comp components.Component) error {
 
 	// Configure the service
 	switch comp.ID {
-	case "llm", "embedding", "stt":
+	case "llm", "embedding":
 		if cfg.Services.AI.Port == 0 {
 			cfg.Services.AI = config.AIConfig{
 				Port:    11434,
@@ -541,6 +563,21 @@ func addComponent(cfg *config.Config, comp components.Component) error {
 			AuthEnabled: true,
 		}
 
+	case "qdrant":
+		cfg.Services.Qdrant = config.QdrantConfig{
+			Type:     "qdrant",
+			Version:  "latest",
+			Port:     6333,
+			GRPCPort: 6334,
+		}
+
+	case "chroma":
+		cfg.Services.Chroma = config.ChromaConfig{
+			Type:    "chroma",
+			Version: "0.5.23",
+			Port:    8000,
+		}
+
 	case "cache":
 		cfg.Services.Cache = config.CacheConfig{
 			Type:            "redis",
@@ -567,6 +604,13 @@ func addComponent(cfg *config.Config, comp components.Component) error {
 			Port:    9000,
 			Console: 9001,
 		}
+
+	case "stt":
+		cfg.Services.Whisper = config.WhisperConfig{
+			Type:  "whisper",
+			Port:  9100,
+			Model: components.DefaultWhisperModel(),
+		}
 	}
 
 	return nil
@@ -585,14 +629,13 @@ func removeComponent(cfg *config.Config, comp components.Component) error {
 
 	// Clear service configuration
 	switch comp.ID {
-	case "llm", "embedding", "stt":
+	case "llm", "embedding":
 		// Remove models for this component type
 		newModels := []string{}
 		for _, model := range cfg.Services.AI.Models {
 			isEmbedding := models.IsEmbeddingModel(model)
 			if (comp.ID == "embedding" && !isEmbedding) ||
-				(comp.ID == "llm" && isEmbedding) ||
-				(comp.ID == "stt") {
+				(comp.ID == "llm" && isEmbedding) {
 				newModels = append(newModels, model)
 			}
 		}
@@ -629,6 +672,12 @@ func removeComponent(cfg *config.Config, comp components.Component) error {
 	case "mongodb":
 		cfg.Services.MongoDB = config.MongoDBConfig{}
 
+	case "qdrant":
+		cfg.Services.Qdrant = config.QdrantConfig{}
+
+	case "chroma":
+		cfg.Services.Chroma = config.ChromaConfig{}
+
 	case "cache":
 		cfg.Services.Cache = config.CacheConfig{}
 
@@ -637,6 +686,9 @@ func removeComponent(cfg *config.Config, comp components.Component) error {
 
 	case "storage":
 		cfg.Services.Storage = config.StorageConfig{}
+
+	case "stt":
+		cfg.Services.Whisper = config.WhisperConfig{}
 	}
 
 	return nil
@@ -649,16 +701,31 @@ func selectAndConfigureModel(cfg *config.Config, comp components.Component) erro
 	var selectedModel string
 	var err error
 
-	if comp.ID == "embedding" {
+	if componentUpdateModel != "" {
+		selectedModel = componentUpdateModel
+		if comp.ID == "llm" {
+			warnModelFit(selectedModel, appendUnique(append([]string{}, cfg.Project.Components...), comp.ID))
+		}
+	} else if comp.ID == "embedding" {
 		selectedModel, err = selectEmbeddingModel(manager)
 	} else {
-		selectedModel, err = selectComponentModel(comp, manager)
+		selectedModel, err = selectComponentModel(comp, manager, appendUnique(append([]string{}, cfg.Project.Components...), comp.ID))
 	}
 
 	if err != nil || selectedModel == "" {
 		return fmt.Errorf("model selection cancelled")
 	}
 
+	// Whisper models belong to the speech-to-text service, not Ollama
+	if comp.ID == "stt" {
+		cfg.Services.Whisper.Model = selectedModel
+		if err := config.Save(); err != nil {
+			return fmt.Errorf("failed to save model configuration: %w", err)
+		}
+		printSuccess(fmt.Sprintf("Configured %s model: %s", comp.ID, selectedModel))
+		return nil
+	}
+
 	// Update models list
 	newModels := []string{}
 
@@ -704,6 +771,18 @@ func showComponentNextSteps(comp components.Component) {
 	if comp.ID == "storage" {
 		fmt.Println("  • Access MinIO console: lc service url minio-console")
 	}
+
+	if comp.ID == "qdrant" {
+		fmt.Println("  • Open the Qdrant dashboard: http://localhost:6333/dashboard")
+	}
+
+	if comp.ID == "chroma" {
+		fmt.Println("  • Check the Chroma API: curl http://localhost:8000/api/v1/heartbeat")
+	}
+
+	if comp.ID == "stt" {
+		fmt.Println("  • Transcribe audio: lc transcribe <audio-file> --format srt")
+	}
 }
 
 // getEnabledComponents returns list of enabled component IDs from config
@@ -0,0 +1,335 @@
+// internal/cli/eval.go
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/eval"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var evalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Evaluate models against prompt suites",
+	Long: `Run suites of prompts or conversations against local models and grade the
+responses, to compare models and catch regressions.`,
+}
+
+var evalRunCmd = &cobra.Command{
+	Use:   "run <suite.yaml>",
+	Short: "Run an eval suite against one or more models",
+	Long: `Run every case of a suite against each model concurrently, grade the
+responses and print each model's pass rate.
+
+A case is a prompt or a conversation (messages) with an expected output and
+graders. A case passes when all its graders pass:
+  exact        the response equals the expected output (ignore_case)
+  regex        the response matches pattern (default: the expected output)
+  json_schema  the response is JSON valid against schema or schema_file
+  similarity   the response embeds within threshold (default 0.8) of the
+               expected output, using embedding_model
+  judge        another local model (judge_model) grades the response against
+               criteria and the expected output
+
+A case with an expected output and no graders is an exact match.
+
+Runs are saved to .localcloud/eval/<suite>/ and compared with each model's
+previous run: cases that passed before and fail now are regressions.`,
+	Example: `  lc eval run suite.yaml
+  lc eval run suite.yaml --models llama3.2:3b,qwen2.5:3b
+  lc eval run suite.yaml --judge-model llama3.1:8b --fail-on-regression
+
+  # suite.yaml
+  #   name: support
+  #   system: You are a support assistant for Acme.
+  #   judge_model: llama3.1:8b
+  #   options: {temperature: 0}
+  #   cases:
+  #     - name: capital
+  #       prompt: What is the capital of France? Answer with one word.
+  #       expected: Paris
+  #       graders: [{type: exact, ignore_case: true}]
+  #     - name: order-json
+  #       prompt: 'Return {"id": <number>, "status": <string>} for order 42 shipped.'
+  #       graders:
+  #         - type: json_schema
+  #           schema: {type: object, required: [id, status]}
+  #     - name: refund
+  #       messages:
+  #         - role: user
+  #           content: I was charged twice.
+  #         - role: assistant
+  #           content: Sorry to hear that. Which order?
+  #         - role: user
+  #           content: Order 1234.
+  #       graders:
+  #         - type: judge
+  #           criteria: Apologizes and explains the refund process for order 1234.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEvalRun,
+}
+
+var (
+	evalModels           []string
+	evalConcurrency      int
+	evalJudgeModel       string
+	evalEmbeddingModel   string
+	evalJSON             bool
+	evalFailOnRegression bool
+)
+
+func init() {
+	evalRunCmd.Flags().StringSliceVar(&evalModels, "models", nil, "Comma-separated models to evaluate (default: configured default model)")
+	evalRunCmd.Flags().IntVar(&evalConcurrency, "concurrency", 4, "Cases evaluated at once")
+	evalRunCmd.Flags().StringVar(&evalJudgeModel, "judge-model", "", "Model for judge graders that name none (default: the suite's judge_model)")
+	evalRunCmd.Flags().StringVar(&evalEmbeddingModel, "embedding-model", "", "Model for similarity graders that name none (default: the suite's embedding_model, then the configured embedding model)")
+	evalRunCmd.Flags().BoolVar(&evalJSON, "json", false, "Print the run as JSON")
+	evalRunCmd.Flags().BoolVar(&evalFailOnRegression, "fail-on-regression", false, "Exit with an error when a case regressed")
+
+	evalCmd.AddCommand(evalRunCmd)
+}
+
+// evalRunOutput is the JSON output of lc eval run
+type evalRunOutput struct {
+	Path        string            `json:"path,omitempty"`
+	Summary     []evalModelOutput `json:"summary"`
+	Regressions []eval.Change     `json:"regressions"`
+	Fixed       []eval.Change     `json:"fixed"`
+	Run         *eval.Run         `json:"run"`
+}
+
+type evalModelOutput struct {
+	Model            string   `json:"model"`
+	Passed           int      `json:"passed"`
+	Total            int      `json:"total"`
+	Errors           int      `json:"errors"`
+	PassRate         float64  `json:"pass_rate"`
+	PreviousPassRate *float64 `json:"previous_pass_rate,omitempty"`
+}
+
+func runEvalRun(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if cfg.Services.AI.Port == 0 {
+		return fmt.Errorf("the AI service is not configured. Add it with: lc component add llm")
+	}
+
+	suite, err := eval.LoadSuite(args[0])
+	if err != nil {
+		return err
+	}
+	embeddingModel := evalEmbeddingModel
+	if embeddingModel == "" {
+		embeddingModel = configuredEmbeddingModel(cfg)
+	}
+	suite.SetDefaultModels(evalJudgeModel, embeddingModel)
+	switch suite.NeedsModel() {
+	case eval.GraderJudge:
+		return fmt.Errorf("judge graders need a model: set judge_model in the suite or use --judge-model")
+	case eval.GraderSimilarity:
+		return fmt.Errorf("similarity graders need an embedding model: set embedding_model in the suite or use --embedding-model")
+	}
+
+	names := models.UniqueModels(evalModels)
+	if len(names) == 0 {
+		if cfg.Services.AI.Default == "" {
+			return fmt.Errorf("no model given and no default model configured. Use --models")
+		}
+		names = []string{cfg.Services.AI.Default}
+	}
+
+	backend, err := models.NewBackend(cfg.Services.AI)
+	if err != nil {
+		return err
+	}
+	if err := backend.Health(context.Background()); err != nil {
+		return fmt.Errorf("%w. Start it with: lc start ai", err)
+	}
+	installed, err := backend.List(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+	for _, name := range names {
+		if models.IsEmbeddingModel(name) {
+			return fmt.Errorf("%s is an embedding model and cannot be evaluated for generation", name)
+		}
+		if !modelInstalled(installed, name) {
+			return fmt.Errorf("model %s is not installed. Download it with: lc models pull %s", name, name)
+		}
+	}
+	for _, name := range suite.GraderModels() {
+		if !modelInstalled(installed, name) {
+			return fmt.Errorf("grader model %s is not installed. Download it with: lc models pull %s", name, name)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	total := len(names) * len(suite.Cases)
+	done := 0
+	if !evalJSON {
+		fmt.Printf("Running %s: %d cases × %d models (concurrency %d)\n", suite.Name, len(suite.Cases), len(names), evalConcurrency)
+	}
+	run := eval.Execute(ctx, backend, suite, names, eval.Options{
+		Concurrency: evalConcurrency,
+		Progress: func(r eval.CaseResult) {
+			done++
+			if evalJSON {
+				return
+			}
+			mark := successColor("✓")
+			if !r.Passed {
+				mark = errorColor("✗")
+			}
+			fmt.Printf("  [%d/%d] %s %s %s (%s)\n", done, total, mark, r.Model, r.Case, formatBenchDuration(r.Duration))
+		},
+	})
+	if ctx.Err() != nil {
+		return fmt.Errorf("eval interrupted")
+	}
+
+	dir := eval.Dir(suite.Name)
+	previousRuns, err := eval.LoadRuns(dir)
+	if err != nil {
+		printWarning(fmt.Sprintf("Failed to read previous runs: %v", err))
+	}
+	changes := eval.Compare(previousRuns, run)
+
+	path, err := eval.SaveRun(dir, run)
+	if err != nil {
+		printWarning(fmt.Sprintf("Failed to save run: %v", err))
+		path = ""
+	}
+
+	var regressions, fixed []eval.Change
+	for _, c := range changes {
+		if c.Passed {
+			fixed = append(fixed, c)
+		} else {
+			regressions = append(regressions, c)
+		}
+	}
+
+	if evalJSON {
+		out := evalRunOutput{Path: path, Regressions: regressions, Fixed: fixed, Run: run}
+		if out.Regressions == nil {
+			out.Regressions = []eval.Change{}
+		}
+		if out.Fixed == nil {
+			out.Fixed = []eval.Change{}
+		}
+		for _, s := range run.Summary() {
+			m := evalModelOutput{Model: s.Model, Passed: s.Passed, Total: s.Total, Errors: s.Errors, PassRate: s.PassRate()}
+			if prev, ok := eval.PreviousSummary(previousRuns, run, s.Model); ok {
+				rate := prev.PassRate()
+				m.PreviousPassRate = &rate
+			}
+			out.Summary = append(out.Summary, m)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(out); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		printEvalSummary(run, previousRuns)
+		printEvalFailures(run)
+
+		if len(regressions) > 0 {
+			fmt.Printf("\n%s\n", errorColor(fmt.Sprintf("Regressions since the previous run (%d):", len(regressions))))
+			for _, c := range regressions {
+				fmt.Printf("  %s %s %s (passed on %s)\n", errorColor("✗"), c.Model, c.Case, c.Previous.Local().Format("2006-01-02 15:04"))
+			}
+		}
+		if len(fixed) > 0 {
+			fmt.Printf("\n%s\n", successColor(fmt.Sprintf("Fixed since the previous run (%d):", len(fixed))))
+			for _, c := range fixed {
+				fmt.Printf("  %s %s %s\n", successColor("✓"), c.Model, c.Case)
+			}
+		}
+		if path != "" {
+			fmt.Printf("\nRun saved to %s\n", path)
+		}
+	}
+
+	if evalFailOnRegression && len(regressions) > 0 {
+		return fmt.Errorf("%d case(s) regressed", len(regressions))
+	}
+	return nil
+}
+
+// printEvalSummary prints each model's pass rate next to its previous one
+func printEvalSummary(run *eval.Run, previousRuns []*eval.Run) {
+	fmt.Printf("%-28s %-10s %-10s %-18s %-10s %-8s\n", "MODEL", "PASSED", "PASS RATE", "PREVIOUS", "AVG TIME", "ERRORS")
+	fmt.Println(strings.Repeat("─", 88))
+	for _, s := range run.Summary() {
+		previous := "-"
+		if prev, ok := eval.PreviousSummary(previousRuns, run, s.Model); ok {
+			delta := (s.PassRate() - prev.PassRate()) * 100
+			previous = fmt.Sprintf("%.1f%% (%+.1f)", prev.PassRate()*100, delta)
+			switch {
+			case delta < 0:
+				previous = errorColor(fmt.Sprintf("%-18s", previous))
+			case delta > 0:
+				previous = successColor(fmt.Sprintf("%-18s", previous))
+			}
+		}
+		fmt.Printf("%-28s %-10s %-10s %-18s %-10s %-8d\n",
+			s.Model,
+			fmt.Sprintf("%d/%d", s.Passed, s.Total),
+			fmt.Sprintf("%.1f%%", s.PassRate()*100),
+			previous,
+			formatBenchDuration(s.Duration.Round(time.Millisecond)),
+			s.Errors,
+		)
+	}
+}
+
+// printEvalFailures lists why each failed case failed
+func printEvalFailures(run *eval.Run) {
+	var lines []string
+	for _, r := range run.Results {
+		if r.Passed {
+			continue
+		}
+		if r.Error != "" {
+			lines = append(lines, fmt.Sprintf("  %s %s: %s", r.Model, r.Case, errorColor(r.Error)))
+			continue
+		}
+		for _, g := range r.Grades {
+			if !g.Passed {
+				lines = append(lines, fmt.Sprintf("  %s %s: %s: %s", r.Model, r.Case, g.Type, evalSnippet(g.Detail)))
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Printf("\nFailures:\n%s\n", strings.Join(lines, "\n"))
+}
+
+// evalSnippet shortens grader details to one line
+func evalSnippet(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) > 120 {
+		return text[:117] + "..."
+	}
+	return text
+}
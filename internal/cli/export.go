@@ -5,7 +5,6 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"context"
-	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,9 +14,10 @@ import (
 	"strings"
 	"time"
 
-	_ "github.com/lib/pq"
 	"github.com/localcloud-sh/localcloud/internal/config"
 	"github.com/localcloud-sh/localcloud/internal/services/postgres"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/providers"
 	"github.com/minio/minio-go/v7"
 	"github.com/spf13/cobra"
 )
@@ -151,7 +151,7 @@ The archive contains:
 
 var exportVectorCmd = &cobra.Command{
 	Use:     "vector",
-	Aliases: []string{"embeddings", "pgvector"},
+	Aliases: []string{"embeddings", "pgvector", "qdrant", "chroma"},
 	Short:   "Export vector database embeddings",
 	Long: `Export vector database embeddings to a JSON file for migration to cloud vector services.
 
@@ -162,6 +162,9 @@ The exported file contains all vector embeddings, metadata, and can be imported
 - Qdrant: Using their upsert API
 - Any pgvector instance: Using the included SQL import script
 
+Every configured store is exported: pgvector, and the qdrant and chroma
+components, each to its own file in the same JSON format.
+
 The export includes:
 - Document IDs and content
 - Vector embeddings (1536-dimensional by default)
@@ -248,13 +251,13 @@ func runExportAll(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Export Vector Database if PostgreSQL is configured (pgvector)
-	if cfg.Services.Database.Type != "" {
-		printInfo("Exporting vector database embeddings...")
-		if err := exportVectorDatabase(cfg); err != nil {
-			printWarning(fmt.Sprintf("Failed to export vector database: %v", err))
+	// Export every configured vector store
+	for _, provider := range providers.Configured(cfg) {
+		printInfo(fmt.Sprintf("Exporting %s embeddings...", provider))
+		if err := exportVectorStore(cfg, provider, getOutputPath(vectorExportComponent(provider), "json")); err != nil {
+			printWarning(fmt.Sprintf("Failed to export %s: %v", provider, err))
 		} else {
-			exported = append(exported, "Vector Database")
+			exported = append(exported, string(provider))
 		}
 	}
 
@@ -492,11 +495,32 @@ func runExportVector(cmd *cobra.Command, args []string) error {
 	}
 
 	cfg := config.Get()
-	if cfg.Services.Database.Type == "" {
-		return fmt.Errorf("PostgreSQL database not configured (required for vector database)")
+	configured := providers.Configured(cfg)
+	if len(configured) == 0 {
+		return fmt.Errorf("no vector database configured. Add one with: lc component add vector")
+	}
+
+	// Export every store, so a project using more than one loses nothing
+	var failed []string
+	for _, provider := range configured {
+		outputFile := getOutputPath(vectorExportComponent(provider), "json")
+		// A file named with --output gets the provider appended so the
+		// exports do not overwrite each other
+		if len(configured) > 1 && outputFile == exportOutput {
+			ext := filepath.Ext(outputFile)
+			outputFile = strings.TrimSuffix(outputFile, ext) + "-" + string(provider) + ext
+		}
+
+		if err := exportVectorStore(cfg, provider, outputFile); err != nil {
+			printWarning(fmt.Sprintf("Failed to export %s: %v", provider, err))
+			failed = append(failed, string(provider))
+		}
 	}
 
-	return exportVectorDatabase(cfg)
+	if len(failed) > 0 {
+		return fmt.Errorf("export failed for %s", strings.Join(failed, ", "))
+	}
+	return nil
 }
 
 // VectorExportData represents the structure for vector database export
@@ -525,119 +549,96 @@ type EmbeddingData struct {
 	CreatedAt  time.Time              `json:"created_at"`
 }
 
-func exportVectorDatabase(cfg *config.Config) error {
-	outputFile := getOutputPath("vector", "json")
-
-	// Create database connection
-	connStr := fmt.Sprintf("host=localhost port=%d user=localcloud password=localcloud dbname=localcloud sslmode=disable",
-		cfg.Services.Database.Port)
-
-	db, err := sql.Open("postgres", connStr)
+// exportVectorStore writes every record of a vector store to outputFile
+func exportVectorStore(cfg *config.Config, provider vectordb.Provider, outputFile string) error {
+	ctx := context.Background()
+	db, err := providers.Open(cfg, &vectordb.Config{Provider: string(provider)})
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return fmt.Errorf("failed to connect to %s: %w", provider, err)
 	}
-	defer db.Close()
-
-	// Test connection
-	if err := db.Ping(); err != nil {
-		return fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	// Build query with optional collection filter
-	var query string
-	var args []interface{}
-
-	if exportCollection != "" {
-		query = `
-			SELECT id, document_id, content, embedding, metadata, 
-			       COALESCE(collection_name, 'default') as collection_name, created_at
-			FROM localcloud.embeddings 
-			WHERE collection_name = $1 OR (collection_name IS NULL AND $1 = 'default')
-			ORDER BY created_at`
-		args = append(args, exportCollection)
-	} else {
-		query = `
-			SELECT id, document_id, content, embedding, metadata, 
-			       COALESCE(collection_name, 'default') as collection_name, created_at
-			FROM localcloud.embeddings 
-			ORDER BY created_at`
+	migrator, ok := db.(vectordb.Migrator)
+	if !ok {
+		return fmt.Errorf("%s does not support reading back embeddings", provider)
 	}
 
-	rows, err := db.Query(query, args...)
-	if err != nil {
-		return fmt.Errorf("failed to query embeddings: %w", err)
+	// Export a single collection or all of them, leaving out re-embedding
+	// shadows
+	collections := []string{exportCollection}
+	if exportCollection == "" {
+		names, err := migrator.ListCollections(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list collections: %w", err)
+		}
+		collections = nil
+		for _, name := range names {
+			if !vectordb.IsShadow(name) {
+				collections = append(collections, name)
+			}
+		}
 	}
-	defer rows.Close()
 
 	var embeddings []EmbeddingData
-	var collections = make(map[string]bool)
 	var dimension int
 
-	for rows.Next() {
-		var embedding EmbeddingData
-		var embeddingStr string
-		var metadataStr sql.NullString
-
-		err := rows.Scan(
-			&embedding.ID,
-			&embedding.DocumentID,
-			&embedding.Content,
-			&embeddingStr,
-			&metadataStr,
-			&embedding.Collection,
-			&embedding.CreatedAt,
-		)
+	for _, collection := range collections {
+		records, err := migrator.ListRecords(ctx, collection)
 		if err != nil {
-			return fmt.Errorf("failed to scan row: %w", err)
+			return fmt.Errorf("failed to read collection %s: %w", collection, err)
 		}
 
-		// Parse vector embedding from PostgreSQL array format
-		if err := parsePostgresArray(embeddingStr, &embedding.Embedding); err != nil {
-			printWarning(fmt.Sprintf("Failed to parse embedding for document %s: %v", embedding.DocumentID, err))
-			continue
-		}
-
-		// Parse metadata JSON
-		if metadataStr.Valid {
-			if err := json.Unmarshal([]byte(metadataStr.String), &embedding.Metadata); err != nil {
-				printWarning(fmt.Sprintf("Failed to parse metadata for document %s: %v", embedding.DocumentID, err))
+		for _, record := range records {
+			embedding := EmbeddingData{
+				ID:         record.ID,
+				DocumentID: record.DocumentID,
+				Content:    record.Content,
+				Embedding:  make([]float64, len(record.Vector)),
+				Metadata:   record.Metadata,
+				Collection: record.Collection,
+				CreatedAt:  record.CreatedAt,
 			}
+			for i, v := range record.Vector {
+				embedding.Embedding[i] = float64(v)
+			}
+			if len(embedding.Embedding) > 0 {
+				dimension = len(embedding.Embedding)
+			}
+			embeddings = append(embeddings, embedding)
 		}
-
-		// Track dimension and collections
-		if len(embedding.Embedding) > 0 {
-			dimension = len(embedding.Embedding)
-		}
-		collections[embedding.Collection] = true
-
-		embeddings = append(embeddings, embedding)
 	}
 
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("error iterating rows: %w", err)
+	if err := writeVectorExport(outputFile, "LocalCloud "+string(provider), collections, embeddings, dimension); err != nil {
+		return err
 	}
 
-	// Convert collections map to slice
-	var collectionList []string
-	for collection := range collections {
-		collectionList = append(collectionList, collection)
+	printSuccess(fmt.Sprintf("Exported %d %s embeddings to: %s", len(embeddings), provider, outputFile))
+
+	return nil
+}
+
+// vectorExportComponent names a provider's export file; pgvector keeps the
+// original localcloud-vector name
+func vectorExportComponent(provider vectordb.Provider) string {
+	if provider == vectordb.ProviderPgVector {
+		return "vector"
 	}
+	return string(provider)
+}
 
-	// Create export data structure
+// writeVectorExport writes embeddings in the common vector export format
+func writeVectorExport(outputFile, source string, collections []string, embeddings []EmbeddingData, dimension int) error {
 	exportData := VectorExportData{
 		ExportInfo: ExportInfo{
 			ExportedAt:   time.Now(),
-			Source:       "LocalCloud pgvector",
+			Source:       source,
 			Version:      "1.0",
 			TotalVectors: len(embeddings),
 			Dimension:    dimension,
 		},
-		Collections:  collectionList,
+		Collections:  collections,
 		Embeddings:   embeddings,
 		ImportScript: generateImportScript(embeddings),
 	}
 
-	// Write to JSON file
 	file, err := os.Create(outputFile)
 	if err != nil {
 		return fmt.Errorf("failed to create output file: %w", err)
@@ -650,35 +651,6 @@ func exportVectorDatabase(cfg *config.Config) error {
 		return fmt.Errorf("failed to encode JSON: %w", err)
 	}
 
-	printSuccess(fmt.Sprintf("Vector database exported to: %s", outputFile))
-
-	return nil
-}
-
-// parsePostgresArray parses PostgreSQL array format like [1.0,2.0,3.0] to []float64
-func parsePostgresArray(arrayStr string, result *[]float64) error {
-	// Remove brackets and split by commas
-	if !strings.HasPrefix(arrayStr, "[") || !strings.HasSuffix(arrayStr, "]") {
-		return fmt.Errorf("invalid array format: %s", arrayStr)
-	}
-
-	content := strings.TrimPrefix(strings.TrimSuffix(arrayStr, "]"), "[")
-	if content == "" {
-		*result = []float64{}
-		return nil
-	}
-
-	parts := strings.Split(content, ",")
-	*result = make([]float64, len(parts))
-
-	for i, part := range parts {
-		var val float64
-		if _, err := fmt.Sscanf(strings.TrimSpace(part), "%f", &val); err != nil {
-			return fmt.Errorf("failed to parse float: %s", part)
-		}
-		(*result)[i] = val
-	}
-
 	return nil
 }
 
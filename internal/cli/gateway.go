@@ -0,0 +1,706 @@
+// internal/cli/gateway.go
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/gateway"
+	"github.com/localcloud-sh/localcloud/internal/logging"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/localcloud-sh/localcloud/internal/services/redis"
+	"github.com/spf13/cobra"
+)
+
+var gatewayCmd = &cobra.Command{
+	Use:   "gateway",
+	Short: "OpenAI-compatible API gateway for local models",
+	Long: `Run an OpenAI-compatible API in front of the project's Ollama service.
+
+Applications written against the OpenAI SDKs can use local models by pointing
+their base URL at the gateway. Model aliases map OpenAI model names to local
+models, API keys control access, and every request is logged.`,
+}
+
+var gatewayServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Start the gateway",
+	Long: `Start the OpenAI-compatible gateway.
+
+Supported endpoints (streaming and non-streaming):
+  POST /v1/chat/completions
+  POST /v1/completions
+  POST /v1/embeddings
+  GET  /v1/models
+
+Ollama's native API is passed through for chat, generate, embed, embeddings,
+show, tags and ps, so Ollama clients can use the gateway as their host too.
+Endpoints that pull, create or delete models are not passed through, and
+browsers calling /api are subject to Ollama's OLLAMA_ORIGINS policy.
+
+If any API keys exist, requests must send one as a bearer token. Requests are
+logged to .localcloud/logs/gateway.log, and the tokens, latency and caller of
+every request that runs a model are recorded for lc models usage.
+
+With the response cache enabled (lc gateway cache enable), completions are
+cached in the project's Redis cache service. Requests control it with the
+X-LocalCloud-Cache header (bypass, refresh or no-store) and
+X-LocalCloud-Cache-TTL, and responses report HIT, MISS or BYPASS in
+X-LocalCloud-Cache.`,
+	Example: `  lc gateway serve
+  lc gateway serve --port 9000
+
+  # Then, in your application environment:
+  export OPENAI_BASE_URL=http://localhost:8092/v1
+  export OPENAI_API_KEY=<key from 'lc gateway keys create'>`,
+	RunE: runGatewayServe,
+}
+
+var gatewayKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "List gateway API keys",
+	RunE:  runGatewayKeysList,
+}
+
+var gatewayKeysCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a gateway API key",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runGatewayKeysCreate,
+}
+
+var gatewayKeysRevokeCmd = &cobra.Command{
+	Use:   "revoke <name>",
+	Short: "Revoke a gateway API key",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runGatewayKeysRevoke,
+}
+
+var gatewayAliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "List model aliases",
+	Long: `List the model aliases the gateway applies.
+
+Aliases let applications keep OpenAI model names while running local models,
+for example gpt-4o-mini -> qwen2.5:3b. They are stored in services.ai.aliases.`,
+	RunE: runGatewayAliasList,
+}
+
+var gatewayAliasSetCmd = &cobra.Command{
+	Use:   "set <alias> <model>",
+	Short: "Map a model name to a local model",
+	Example: `  lc gateway alias set gpt-4o-mini qwen2.5:3b
+  lc gateway alias set text-embedding-3-small nomic-embed-text`,
+	Args: cobra.ExactArgs(2),
+	RunE: runGatewayAliasSet,
+}
+
+var gatewayAliasRemoveCmd = &cobra.Command{
+	Use:   "remove <alias>",
+	Short: "Remove a model alias",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runGatewayAliasRemove,
+}
+
+var gatewayCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Show response cache statistics",
+	Long: `Show how the gateway's response cache answered requests.
+
+The response cache keeps chat and text completions in the project's Redis
+cache service, keyed by model, parameters and prompt (extra whitespace is
+ignored). A prompt whose embedding is similar enough to a
+cached prompt with the same model, parameters and earlier messages also hits,
+so repeated test runs against local models return instantly.
+
+Requests send X-LocalCloud-Cache to opt out:
+  bypass    neither read nor write the cache (also Cache-Control: no-store)
+  refresh   skip the cached response and replace it (also Cache-Control: no-cache)
+  no-store  read the cache but do not store the response
+and X-LocalCloud-Cache-TTL (10m, 3600) to keep their response for another time.`,
+	RunE: runGatewayCacheStats,
+}
+
+var gatewayCacheEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Cache gateway responses in the cache service",
+	Example: `  lc gateway cache enable
+  lc gateway cache enable --ttl 1h --similarity 0.9
+  lc gateway cache enable --similarity 1   # exact matches only`,
+	RunE: runGatewayCacheEnable,
+}
+
+var gatewayCacheDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Stop caching gateway responses",
+	RunE:  runGatewayCacheDisable,
+}
+
+var gatewayCacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete cached responses",
+	RunE:  runGatewayCacheClear,
+}
+
+// gatewayServiceName is the name lc gateway serve registers under
+const gatewayServiceName = "gateway"
+
+// keepAliveInterval is how often the gateway checks loaded models against
+// their keep-alive
+const keepAliveInterval = 30 * time.Second
+
+var (
+	gatewayHost  string
+	gatewayPort  int
+	gatewayLog   bool
+	gatewayUsage bool
+
+	gatewayNoCache             bool
+	gatewayCacheTTL            string
+	gatewayCacheSimilarity     float64
+	gatewayCacheEmbeddingModel string
+	gatewayCacheClearStats     bool
+	gatewayCacheJSON           bool
+)
+
+func init() {
+	gatewayServeCmd.Flags().StringVar(&gatewayHost, "host", "localhost", "Address to listen on")
+	gatewayServeCmd.Flags().IntVar(&gatewayPort, "port", gateway.DefaultPort, "Port to listen on")
+	gatewayServeCmd.Flags().BoolVar(&gatewayLog, "log", true, "Log requests to .localcloud/logs/gateway.log")
+	gatewayServeCmd.Flags().BoolVar(&gatewayUsage, "usage", true, "Record token usage to .localcloud/metrics (lc models usage)")
+	gatewayServeCmd.Flags().BoolVar(&gatewayNoCache, "no-cache", false, "Serve without the response cache even if it is enabled")
+
+	gatewayCacheCmd.Flags().BoolVar(&gatewayCacheJSON, "json", false, "Print statistics as JSON")
+	gatewayCacheEnableCmd.Flags().StringVar(&gatewayCacheTTL, "ttl", "", "How long responses are kept (default 24h)")
+	gatewayCacheEnableCmd.Flags().Float64Var(&gatewayCacheSimilarity, "similarity", 0, "Minimum similarity for near-duplicate prompts to hit, 1 for exact matches only (default 0.95)")
+	gatewayCacheEnableCmd.Flags().StringVar(&gatewayCacheEmbeddingModel, "embedding-model", "", "Model that embeds prompts (default: the configured embedding model)")
+	gatewayCacheClearCmd.Flags().BoolVar(&gatewayCacheClearStats, "stats", false, "Also reset the statistics")
+
+	gatewayKeysCmd.AddCommand(gatewayKeysCreateCmd)
+	gatewayKeysCmd.AddCommand(gatewayKeysRevokeCmd)
+
+	gatewayAliasCmd.AddCommand(gatewayAliasSetCmd)
+	gatewayAliasCmd.AddCommand(gatewayAliasRemoveCmd)
+
+	gatewayCacheCmd.AddCommand(gatewayCacheEnableCmd)
+	gatewayCacheCmd.AddCommand(gatewayCacheDisableCmd)
+	gatewayCacheCmd.AddCommand(gatewayCacheClearCmd)
+
+	gatewayCmd.AddCommand(gatewayServeCmd)
+	gatewayCmd.AddCommand(gatewayKeysCmd)
+	gatewayCmd.AddCommand(gatewayAliasCmd)
+	gatewayCmd.AddCommand(gatewayCacheCmd)
+}
+
+func runGatewayServe(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if err := requireOllama(cfg, "lc gateway serve"); err != nil {
+		return err
+	}
+	if cfg.Services.AI.Port == 0 {
+		return fmt.Errorf("AI component not configured. Add it with: lc component add llm")
+	}
+
+	keys, err := gateway.LoadKeyStore(gateway.KeysPath())
+	if err != nil {
+		return err
+	}
+
+	keepAlive, err := models.NewKeepAlivePolicy(cfg.Services.AI.KeepAlive)
+	if err != nil {
+		return err
+	}
+
+	opts := gateway.Options{
+		OllamaURL:      fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port),
+		Aliases:        cfg.Services.AI.Aliases,
+		DefaultModel:   cfg.Services.AI.Default,
+		EmbeddingModel: configuredEmbeddingModel(cfg),
+		Keys:           keys,
+		KeepAlive:      keepAlive,
+	}
+
+	if cfg.Services.Cache.ResponseCache.Enabled && !gatewayNoCache {
+		cache, err := gatewayResponseCache(cfg)
+		if err != nil {
+			return err
+		}
+		opts.Cache = cache
+	}
+
+	if gatewayLog {
+		writer, err := logging.NewRotatingWriter(filepath.Join(".localcloud", "logs", "gateway.log"), 10*1024*1024)
+		if err != nil {
+			return fmt.Errorf("failed to open request log: %w", err)
+		}
+		defer writer.Close()
+		opts.Log = writer
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if gatewayUsage {
+		metrics, err := logging.NewPerformanceMetrics()
+		if err != nil {
+			return fmt.Errorf("failed to open usage metrics: %w", err)
+		}
+		defer metrics.Close()
+		go logging.CollectAIMetrics(ctx, opts.OllamaURL, metrics)
+		opts.Metrics = metrics
+	}
+
+	addr := net.JoinHostPort(gatewayHost, fmt.Sprintf("%d", gatewayPort))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	listener.Close()
+
+	unregister := registerHostService(gatewayServiceName, gatewayPort, "ai", nil)
+	defer unregister()
+
+	baseURL := fmt.Sprintf("http://localhost:%d/v1", gatewayPort)
+	printSuccess(fmt.Sprintf("OpenAI-compatible gateway listening on %s", baseURL))
+	fmt.Println()
+	fmt.Println("Use it from any OpenAI SDK:")
+	fmt.Printf("  export OPENAI_BASE_URL=%s\n", baseURL)
+	if keys.Empty() {
+		fmt.Println("  export OPENAI_API_KEY=local")
+		fmt.Println()
+		printWarning("No API keys configured; the gateway accepts any request. Create one with: lc gateway keys create <name>")
+	} else {
+		fmt.Println("  export OPENAI_API_KEY=<your gateway key>")
+	}
+	if len(cfg.Services.AI.Aliases) > 0 {
+		fmt.Println()
+		fmt.Println("Aliases:")
+		for _, alias := range cfg.Services.AI.Aliases {
+			fmt.Printf("  %s -> %s\n", alias.Name, alias.Model)
+		}
+	}
+	if opts.Cache != nil {
+		fmt.Println()
+		printGatewayCacheStatus(opts.Cache)
+	}
+	if opts.Metrics != nil {
+		fmt.Println()
+		fmt.Println("Recording token usage per model and caller (lc models usage)")
+	}
+	if !keepAlive.Empty() {
+		fmt.Println()
+		fmt.Println("Unloading idle models after their keep-alive (lc models keep-alive)")
+		manager := models.NewManager(opts.OllamaURL)
+		go manager.WatchKeepAlive(ctx, keepAlive, keepAliveInterval, func(changed []string, err error) {
+			if err != nil {
+				if verbose {
+					printWarning(fmt.Sprintf("keep-alive: %v", err))
+				}
+				return
+			}
+			if verbose {
+				printInfo(fmt.Sprintf("keep-alive: shortened %s", strings.Join(changed, ", ")))
+			}
+		})
+	}
+	fmt.Println("\nPress Ctrl+C to stop")
+
+	if err := gateway.New(opts).ListenAndServe(ctx, addr); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+
+	fmt.Println("\nGateway stopped")
+	return nil
+}
+
+func runGatewayKeysList(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	keys, err := gateway.LoadKeyStore(gateway.KeysPath())
+	if err != nil {
+		return err
+	}
+
+	list := keys.List()
+	if len(list) == 0 {
+		printInfo("No gateway API keys. Create one with: lc gateway keys create <name>")
+		return nil
+	}
+
+	fmt.Printf("%-20s %-14s %-20s %-20s\n", "NAME", "KEY", "CREATED", "LAST USED")
+	fmt.Println(strings.Repeat("-", 76))
+	for _, key := range list {
+		lastUsed := "never"
+		if !key.LastUsed.IsZero() {
+			lastUsed = key.LastUsed.Format("2006-01-02 15:04")
+		}
+		fmt.Printf("%-20s %-14s %-20s %-20s\n", key.Name, key.Prefix+"...", key.CreatedAt.Format("2006-01-02 15:04"), lastUsed)
+	}
+
+	return nil
+}
+
+func runGatewayKeysCreate(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	keys, err := gateway.LoadKeyStore(gateway.KeysPath())
+	if err != nil {
+		return err
+	}
+
+	token, err := keys.Create(args[0])
+	if err != nil {
+		return err
+	}
+
+	printSuccess(fmt.Sprintf("Created gateway key %s", args[0]))
+	fmt.Println()
+	fmt.Printf("  %s\n", token)
+	fmt.Println()
+	printWarning("Store this key now; it cannot be shown again")
+	return nil
+}
+
+func runGatewayKeysRevoke(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	keys, err := gateway.LoadKeyStore(gateway.KeysPath())
+	if err != nil {
+		return err
+	}
+
+	if err := keys.Revoke(args[0]); err != nil {
+		return err
+	}
+
+	printSuccess(fmt.Sprintf("Revoked gateway key %s", args[0]))
+	return nil
+}
+
+func runGatewayAliasList(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+
+	if len(cfg.Services.AI.Aliases) == 0 {
+		printInfo("No model aliases. Add one with: lc gateway alias set <alias> <model>")
+		return nil
+	}
+
+	for _, alias := range cfg.Services.AI.Aliases {
+		fmt.Printf("  %-30s -> %s\n", alias.Name, alias.Model)
+	}
+	return nil
+}
+
+func runGatewayAliasSet(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+
+	name, model := args[0], args[1]
+	updated := false
+	for i, alias := range cfg.Services.AI.Aliases {
+		if strings.EqualFold(alias.Name, name) {
+			cfg.Services.AI.Aliases[i].Model = model
+			updated = true
+		}
+	}
+	if !updated {
+		cfg.Services.AI.Aliases = append(cfg.Services.AI.Aliases, config.ModelAlias{Name: name, Model: model})
+	}
+
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("%s -> %s", name, model))
+	if hostServicePort(gatewayServiceName) > 0 {
+		printInfo("Restart the gateway to apply the change")
+	}
+	return nil
+}
+
+func runGatewayAliasRemove(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+
+	aliases := cfg.Services.AI.Aliases[:0]
+	found := false
+	for _, alias := range cfg.Services.AI.Aliases {
+		if strings.EqualFold(alias.Name, args[0]) {
+			found = true
+			continue
+		}
+		aliases = append(aliases, alias)
+	}
+	if !found {
+		return fmt.Errorf("alias %s not found", args[0])
+	}
+	cfg.Services.AI.Aliases = aliases
+
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("Removed alias %s", args[0]))
+	return nil
+}
+
+// gatewayCacheOptions reads the response cache settings, applying defaults
+func gatewayCacheOptions(cfg *config.Config) (gateway.CacheOptions, error) {
+	rc := cfg.Services.Cache.ResponseCache
+	opts := gateway.CacheOptions{
+		TTL:            gateway.DefaultCacheTTL,
+		Similarity:     rc.Similarity,
+		EmbeddingModel: rc.EmbeddingModel,
+	}
+	if rc.TTL != "" {
+		ttl, err := time.ParseDuration(rc.TTL)
+		if err != nil || ttl <= 0 {
+			return opts, fmt.Errorf("invalid services.cache.response_cache.ttl %q", rc.TTL)
+		}
+		opts.TTL = ttl
+	}
+	if opts.Similarity <= 0 {
+		opts.Similarity = gateway.DefaultCacheSimilarity
+	}
+	if opts.EmbeddingModel == "" {
+		opts.EmbeddingModel = configuredEmbeddingModel(cfg)
+	}
+	return opts, nil
+}
+
+// gatewayResponseCache connects the response cache to the cache service.
+// Near-duplicate matching is turned off when its embedding model is not
+// installed.
+func gatewayResponseCache(cfg *config.Config) (*gateway.ResponseCache, error) {
+	if cfg.Services.Cache.Port == 0 {
+		return nil, fmt.Errorf("the response cache needs the cache component. Add it with: lc component add cache")
+	}
+	opts, err := gatewayCacheOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.EmbeddingModel != "" && opts.Similarity < 1 {
+		installed, err := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port)).List()
+		if err == nil && !modelInstalled(installed, opts.EmbeddingModel) {
+			printWarning(fmt.Sprintf("Embedding model %s is not installed; caching exact matches only. Download it with: lc models pull %s", opts.EmbeddingModel, opts.EmbeddingModel))
+			opts.EmbeddingModel = ""
+		}
+	}
+
+	client := redis.NewClient(fmt.Sprintf("localhost:%d", cfg.Services.Cache.Port))
+	return gateway.NewResponseCache(client, opts), nil
+}
+
+// printGatewayCacheStatus describes the response cache and warns when Redis
+// is not reachable
+func printGatewayCacheStatus(cache *gateway.ResponseCache) {
+	opts := cache.Options()
+	matching := "exact prompts only"
+	if opts.EmbeddingModel != "" && opts.Similarity < 1 {
+		matching = fmt.Sprintf("near-duplicates at similarity >= %.2f with %s", opts.Similarity, opts.EmbeddingModel)
+	}
+	fmt.Printf("Caching responses for %s (%s)\n", opts.TTL, matching)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := cache.Ping(ctx); err != nil {
+		printWarning(fmt.Sprintf("The cache service is not reachable; requests are served uncached until it is. Start it with: lc start cache (%v)", err))
+	}
+}
+
+func runGatewayCacheStats(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if cfg.Services.Cache.Port == 0 {
+		return fmt.Errorf("the response cache needs the cache component. Add it with: lc component add cache")
+	}
+
+	client := redis.NewClient(fmt.Sprintf("localhost:%d", cfg.Services.Cache.Port))
+	defer client.Close()
+	cache := gateway.NewResponseCache(client, gateway.CacheOptions{})
+
+	stats, err := cache.Stats(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to read cache statistics: %w. Start the cache service with: lc start cache", err)
+	}
+
+	if gatewayCacheJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Enabled bool `json:"enabled"`
+			gateway.CacheStats
+			HitRate float64 `json:"hit_rate"`
+		}{cfg.Services.Cache.ResponseCache.Enabled, stats, stats.HitRate()})
+	}
+
+	if cfg.Services.Cache.ResponseCache.Enabled {
+		opts, err := gatewayCacheOptions(cfg)
+		if err != nil {
+			return err
+		}
+		matching := "exact matches only"
+		if opts.EmbeddingModel != "" && opts.Similarity < 1 {
+			matching = fmt.Sprintf("similarity %.2f with %s", opts.Similarity, opts.EmbeddingModel)
+		}
+		fmt.Printf("Response cache: %s (TTL %s, %s)\n", successColor("enabled"), opts.TTL, matching)
+	} else {
+		fmt.Printf("Response cache: %s (enable it with: lc gateway cache enable)\n", warningColor("disabled"))
+	}
+	fmt.Println()
+	fmt.Printf("  %-12s %d\n", "Entries:", stats.Entries)
+	fmt.Printf("  %-12s %d (%d near-duplicate)\n", "Hits:", stats.Hits, stats.SemanticHits)
+	fmt.Printf("  %-12s %d\n", "Misses:", stats.Misses)
+	fmt.Printf("  %-12s %.1f%%\n", "Hit rate:", stats.HitRate()*100)
+	fmt.Printf("  %-12s %d\n", "Bypassed:", stats.Bypassed)
+	fmt.Printf("  %-12s %d\n", "Stored:", stats.Stored)
+	fmt.Printf("  %-12s %d\n", "Errors:", stats.Errors)
+	fmt.Printf("  %-12s %s\n", "Time saved:", (time.Duration(stats.SavedMs) * time.Millisecond).Round(time.Millisecond))
+	return nil
+}
+
+func runGatewayCacheEnable(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if cfg.Services.Cache.Port == 0 {
+		return fmt.Errorf("the response cache needs the cache component. Add it with: lc component add cache")
+	}
+
+	rc := &cfg.Services.Cache.ResponseCache
+	if cmd.Flags().Changed("ttl") {
+		ttl, err := time.ParseDuration(gatewayCacheTTL)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid --ttl %q, use a duration such as 30m or 24h", gatewayCacheTTL)
+		}
+		rc.TTL = ttl.String()
+	}
+	if cmd.Flags().Changed("similarity") {
+		if gatewayCacheSimilarity <= 0 || gatewayCacheSimilarity > 1 {
+			return fmt.Errorf("--similarity must be greater than 0 and at most 1")
+		}
+		rc.Similarity = gatewayCacheSimilarity
+	}
+	if cmd.Flags().Changed("embedding-model") {
+		rc.EmbeddingModel = gatewayCacheEmbeddingModel
+	}
+	rc.Enabled = true
+
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	opts, err := gatewayCacheOptions(cfg)
+	if err != nil {
+		return err
+	}
+	printSuccess("Response cache enabled")
+	printGatewayCacheStatus(gateway.NewResponseCache(redis.NewClient(fmt.Sprintf("localhost:%d", cfg.Services.Cache.Port)), opts))
+	if opts.EmbeddingModel == "" && opts.Similarity < 1 {
+		printInfo("No embedding model configured; only exact prompts match. Add one with: lc component add embedding")
+	}
+	if hostServicePort(gatewayServiceName) > 0 {
+		printInfo("Restart the gateway to apply the change")
+	}
+	return nil
+}
+
+func runGatewayCacheDisable(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+
+	cfg.Services.Cache.ResponseCache.Enabled = false
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	printSuccess("Response cache disabled")
+	if hostServicePort(gatewayServiceName) > 0 {
+		printInfo("Restart the gateway to apply the change")
+	}
+	return nil
+}
+
+func runGatewayCacheClear(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if cfg.Services.Cache.Port == 0 {
+		return fmt.Errorf("the response cache needs the cache component. Add it with: lc component add cache")
+	}
+
+	client := redis.NewClient(fmt.Sprintf("localhost:%d", cfg.Services.Cache.Port))
+	defer client.Close()
+
+	n, err := gateway.NewResponseCache(client, gateway.CacheOptions{}).Clear(context.Background(), gatewayCacheClearStats)
+	if err != nil {
+		return fmt.Errorf("failed to clear the response cache: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("Deleted %d cached responses", n))
+	return nil
+}
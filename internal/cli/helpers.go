@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -168,6 +169,45 @@ func IsProjectInitialized() bool {
 	return err == nil
 }
 
+// updateEnvFile sets keys in an existing .env file, replacing their current
+// values and appending keys that are missing. It returns false without
+// error when the file does not exist.
+func updateEnvFile(path string, values map[string]string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	seen := make(map[string]bool)
+	for i, line := range lines {
+		key, _, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found || strings.HasPrefix(key, "#") {
+			continue
+		}
+		if value, ok := values[key]; ok {
+			lines[i] = key + "=" + value
+			seen[key] = true
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, key+"="+values[key])
+	}
+
+	return true, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
 // GetProjectRoot finds the project root directory by looking for .localcloud folder
 func GetProjectRoot() (string, error) {
 	dir, err := os.Getwd()
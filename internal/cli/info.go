@@ -73,6 +73,12 @@ func runInfo(cmd *cobra.Command, args []string) error {
 	if cfg.Services.Database.Type != "" && cfg.Services.Database.Port > 0 {
 		connMgr.RegisterService("postgres", cfg.Services.Database.Port)
 	}
+	if cfg.Services.Qdrant.Type != "" && cfg.Services.Qdrant.Port > 0 {
+		connMgr.RegisterService("qdrant", cfg.Services.Qdrant.Port)
+	}
+	if cfg.Services.Chroma.Type != "" && cfg.Services.Chroma.Port > 0 {
+		connMgr.RegisterService("chroma", cfg.Services.Chroma.Port)
+	}
 	if cfg.Services.Cache.Type != "" && cfg.Services.Cache.Port > 0 {
 		connMgr.RegisterService("redis", cfg.Services.Cache.Port)
 	}
@@ -80,6 +86,15 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		connMgr.RegisterService("minio", cfg.Services.Storage.Port)
 		connMgr.RegisterService("minio-console", cfg.Services.Storage.Console)
 	}
+	if cfg.Services.Whisper.Type != "" && cfg.Services.Whisper.Port > 0 {
+		connMgr.RegisterService("whisper", cfg.Services.Whisper.Port)
+	}
+	if port := vectorAPIPort(); port > 0 {
+		connMgr.RegisterService(vectorAPIServiceName, port)
+	}
+	if port := hostServicePort(gatewayServiceName); port > 0 {
+		connMgr.RegisterService(gatewayServiceName, port)
+	}
 
 	// Also check for common web ports
 	connMgr.RegisterService("web", 3000)
@@ -209,6 +224,14 @@ func displayConnectionInfo(cfg *config.Config, info *network.ConnectionInfo, sav
 		fmt.Println()
 	}
 
+	if cfg.Services.Whisper.Type != "" {
+		fmt.Println(bold("Speech-to-Text:"))
+		fmt.Printf("  • Model: whisper %s\n", cfg.Services.Whisper.Model)
+		fmt.Printf("  • Transcribe: POST http://localhost:%d/asr (multipart audio_file)\n", cfg.Services.Whisper.Port)
+		fmt.Println("  • CLI: lc transcribe <audio-file> --format srt|vtt|json")
+		fmt.Println()
+	}
+
 	// Instructions
 	fmt.Println(bold("Quick Tips:"))
 	fmt.Println("  • Use 'lc info --mobile-config' to get mobile app configuration")
@@ -269,12 +292,22 @@ func formatServiceName(service string) string {
 		return "AI Models"
 	case "postgres":
 		return "PostgreSQL"
+	case "qdrant":
+		return "Qdrant"
+	case "chroma":
+		return "Chroma"
 	case "redis":
 		return "Redis"
 	case "minio":
 		return "MinIO"
 	case "minio-console":
 		return "MinIO Console"
+	case "whisper":
+		return "Speech-to-Text"
+	case "vector-api":
+		return "Vector API"
+	case "gateway":
+		return "OpenAI Gateway"
 	case "web":
 		return "Web UI"
 	case "api":
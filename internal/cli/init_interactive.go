@@ -3,10 +3,10 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"time"
 
@@ -18,6 +18,7 @@ import (
 	"github.com/localcloud-sh/localcloud/internal/components"
 	"github.com/localcloud-sh/localcloud/internal/config"
 	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/localcloud-sh/localcloud/internal/system"
 )
 
 // InteractiveConfig represents the configuration built during interactive init
@@ -146,7 +147,7 @@ func selectModels(componentIDs []string) (map[string]string, error) {
 		}
 
 		// Regular model selection
-		model, err := selectComponentModel(comp, manager)
+		model, err := selectComponentModel(comp, manager, componentIDs)
 		if err != nil {
 			return nil, err
 		}
@@ -175,7 +176,7 @@ func selectEmbeddingModel(manager *models.Manager) (string, error) {
 			isPredefined := false
 			modelBaseName := strings.TrimSuffix(model.Name, ":latest")
 
-			for _, predef := range models.PredefinedEmbeddingModels {
+			for _, predef := range models.EmbeddingModels() {
 				if predef.Name == modelBaseName || predef.Name == model.Name {
 					isPredefined = true
 					// Update model name to match predefined name (without :latest)
@@ -369,9 +370,16 @@ func checkResources(componentIDs []string, selectedModels map[string]string) err
 	// Calculate total RAM requirement
 	totalRAM := components.CalculateRAMRequirement(componentIDs)
 
-	// Add model-specific RAM requirements
+	// Replace the component minimum with the selected model's estimate
 	for compID, modelName := range selectedModels {
 		comp, _ := components.GetComponent(compID)
+		// Whisper models are not in the model catalog; the component sizes them
+		if compID != "stt" {
+			if fit := assessModel(modelName, componentIDs); fit != nil && fit.RAM > 0 {
+				totalRAM += fit.RAM - comp.MinRAM
+				continue
+			}
+		}
 		for _, model := range comp.Models {
 			if model.Name == modelName && model.RAM > 0 {
 				totalRAM += model.RAM - comp.MinRAM // Adjust for model-specific RAM
@@ -431,7 +439,7 @@ func generateInteractiveConfig(projectName, projectType string, componentIDs []s
 	// Only configure services that were actually selected
 	for _, compID := range componentIDs {
 		switch compID {
-		case "llm", "embedding", "stt":
+		case "llm", "embedding":
 			// Initialize AI service only if AI components are selected
 			if cfg.Services.AI.Port == 0 {
 				cfg.Services.AI = config.AIConfig{
@@ -482,6 +490,21 @@ func generateInteractiveConfig(projectName, projectType string, componentIDs []s
 				AuthEnabled: true,
 			}
 
+		case "qdrant":
+			cfg.Services.Qdrant = config.QdrantConfig{
+				Type:     "qdrant",
+				Version:  "latest",
+				Port:     6333,
+				GRPCPort: 6334,
+			}
+
+		case "chroma":
+			cfg.Services.Chroma = config.ChromaConfig{
+				Type:    "chroma",
+				Version: "0.5.23",
+				Port:    8000,
+			}
+
 		case "cache":
 			cfg.Services.Cache = config.CacheConfig{
 				Type:            "redis",
@@ -508,6 +531,16 @@ func generateInteractiveConfig(projectName, projectType string, componentIDs []s
 				Port:    9000,
 				Console: 9001,
 			}
+
+		case "stt":
+			cfg.Services.Whisper = config.WhisperConfig{
+				Type:  "whisper",
+				Port:  9100,
+				Model: components.DefaultWhisperModel(),
+			}
+			if model, ok := selectedModels[compID]; ok {
+				cfg.Services.Whisper.Model = model
+			}
 		}
 	}
 
@@ -528,6 +561,8 @@ func createProjectStructure(projectName string) error {
 		gitignoreContent := `# LocalCloud
 .localcloud/data/
 .localcloud/logs/
+.localcloud/cache/
+.localcloud/*.pid
 *.log
 `
 		if err := os.WriteFile(gitignorePath, []byte(gitignoreContent), 0644); err != nil {
@@ -555,6 +590,9 @@ func saveInteractiveConfig(cfg *config.Config, projectName string) error {
 		v.Set("services.ai.port", cfg.Services.AI.Port)
 		v.Set("services.ai.models", cfg.Services.AI.Models)
 		v.Set("services.ai.default", cfg.Services.AI.Default)
+		if len(cfg.Services.AI.Aliases) > 0 {
+			v.Set("services.ai.aliases", cfg.Services.AI.Aliases)
+		}
 	}
 
 	if cfg.Services.Database.Type != "" {
@@ -595,6 +633,19 @@ func saveInteractiveConfig(cfg *config.Config, projectName string) error {
 		v.Set("services.mongodb.auth_enabled", cfg.Services.MongoDB.AuthEnabled)
 	}
 
+	if cfg.Services.Qdrant.Type != "" {
+		v.Set("services.qdrant.type", cfg.Services.Qdrant.Type)
+		v.Set("services.qdrant.version", cfg.Services.Qdrant.Version)
+		v.Set("services.qdrant.port", cfg.Services.Qdrant.Port)
+		v.Set("services.qdrant.grpc_port", cfg.Services.Qdrant.GRPCPort)
+	}
+
+	if cfg.Services.Chroma.Type != "" {
+		v.Set("services.chroma.type", cfg.Services.Chroma.Type)
+		v.Set("services.chroma.version", cfg.Services.Chroma.Version)
+		v.Set("services.chroma.port", cfg.Services.Chroma.Port)
+	}
+
 	if cfg.Services.Whisper.Type != "" {
 		v.Set("services.whisper.type", cfg.Services.Whisper.Type)
 		v.Set("services.whisper.port", cfg.Services.Whisper.Port)
@@ -819,14 +870,11 @@ func formatDuration(d time.Duration) string {
 
 // Helper functions for resource checking
 func getAvailableRAM() int64 {
-	// This is a simplified version - in production you'd use runtime.MemStats
-	// or system-specific calls to get actual available memory
-	var m runtime.MemStats
-	runtime.ReadMemStats(&m)
-
-	// For now, return a reasonable default based on system
-	// In a real implementation, this would query actual system memory
-	return 8 * 1024 * 1024 * 1024 // 8GB default
+	_, available, err := system.NewChecker(context.Background()).GetRAM()
+	if err != nil {
+		return 8 * 1024 * 1024 * 1024 // Assume 8GB when memory cannot be read
+	}
+	return available
 }
 
 // internal/cli/init_interactive.go - Fixes
@@ -849,7 +897,7 @@ func selectComponents(projectType string) ([]string, error) {
 	var componentMap = make(map[string]string)
 
 	// Order components logically
-	componentOrder := []string{"llm", "embedding", "database", "vector", "mongodb", "stt", "cache", "queue", "storage"}
+	componentOrder := []string{"llm", "embedding", "database", "vector", "qdrant", "chroma", "mongodb", "stt", "cache", "queue", "storage"}
 
 	for _, compID := range componentOrder {
 		comp, err := components.GetComponent(compID)
@@ -902,34 +950,68 @@ func selectComponents(projectType string) ([]string, error) {
 }
 
 // Fix selectComponentModel to use components.ModelOption
-func selectComponentModel(comp components.Component, manager *models.Manager) (string, error) {
+func selectComponentModel(comp components.Component, manager *models.Manager, componentIDs []string) (string, error) {
 	fmt.Printf("\n%s %s\n", infoColor("Selecting model for:"), comp.Name)
 
 	// Get available models for this component
 	availableModels := comp.Models
 
-	// Check which models are already installed
-	installedModels, _ := manager.List()
+	// Size the language model for this machine and the other components
+	var plan *system.ModelPlan
+	if comp.ID == "llm" {
+		if plan = planLLM(componentIDs); plan != nil {
+			printModelPlan(plan)
+		}
+	}
+
+	// Check which models are already installed. Whisper models are not
+	// Ollama's; the speech-to-text service downloads its own on first start.
 	installedMap := make(map[string]bool)
-	for _, m := range installedModels {
-		installedMap[m.Name] = true
-		// Also check without :latest suffix
-		installedMap[strings.TrimSuffix(m.Name, ":latest")] = true
+	if comp.ID != "stt" {
+		installedModels, _ := manager.List()
+		for _, m := range installedModels {
+			installedMap[m.Name] = true
+			// Also check without :latest suffix
+			installedMap[strings.TrimSuffix(m.Name, ":latest")] = true
+		}
 	}
 
 	// Build options
 	var options []string
 	var modelMap = make(map[string]string)
+	var defaultOption string
 
-	for _, model := range availableModels {
-		var option string
-		if installedMap[model.Name] {
-			option = fmt.Sprintf("✓ %s (%s) [Installed]", model.Name, model.Size)
-		} else {
-			option = fmt.Sprintf("  %s (%s) [Not installed]", model.Name, model.Size)
+	modelOption := func(name, size string) string {
+		if comp.ID == "stt" {
+			return fmt.Sprintf("  %s (%s)", name, size)
 		}
+		if installedMap[name] {
+			return fmt.Sprintf("✓ %s (%s) [Installed]", name, size)
+		}
+		return fmt.Sprintf("  %s (%s) [Not installed]", name, size)
+	}
 
-		if model.Default {
+	// The planned model leads the list when the component does not offer it
+	if plan != nil {
+		offered := false
+		for _, model := range availableModels {
+			offered = offered || model.Name == plan.Tag
+		}
+		if !offered {
+			option := modelOption(plan.Tag, fmt.Sprintf("needs ~%s", FormatBytes(plan.RAM))) + " - Best fit"
+			options = append(options, option)
+			modelMap[option] = plan.Tag
+			defaultOption = option
+		}
+	}
+
+	for _, model := range availableModels {
+		option := modelOption(model.Name, model.Size)
+
+		if plan != nil && model.Name == plan.Tag {
+			option += " - Best fit"
+			defaultOption = option
+		} else if model.Default {
 			option += " - Recommended"
 		}
 
@@ -941,30 +1023,42 @@ func selectComponentModel(comp components.Component, manager *models.Manager) (s
 	customOption := "  Custom model..."
 	options = append(options, customOption)
 
-	prompt := &survey.Select{
-		Message: "Select model:",
-		Options: options,
-	}
+	for {
+		prompt := &survey.Select{
+			Message: "Select model:",
+			Options: options,
+		}
+		if defaultOption != "" {
+			prompt.Default = defaultOption
+		}
 
-	var selected string
-	if err := survey.AskOne(prompt, &selected); err != nil {
-		return "", err
-	}
+		var selected string
+		if err := survey.AskOne(prompt, &selected); err != nil {
+			return "", err
+		}
 
-	// Handle custom model
-	if selected == customOption {
-		var customModel string
-		customPrompt := &survey.Input{
-			Message: "Enter custom model name:",
-			Help:    "e.g., llama3.2:3b, mistral:latest",
+		modelName := modelMap[selected]
+
+		// Handle custom model
+		if selected == customOption {
+			customPrompt := &survey.Input{
+				Message: "Enter custom model name:",
+				Help:    "e.g., llama3.2:3b, mistral:latest",
+			}
+			if comp.ID == "stt" {
+				customPrompt.Help = "A Whisper model, e.g., small.en, large-v2"
+			}
+			if err := survey.AskOne(customPrompt, &modelName); err != nil {
+				return "", err
+			}
 		}
-		if err := survey.AskOne(customPrompt, &customModel); err != nil {
-			return "", err
+
+		// Choices other than the plan may not fit; let the user pick again
+		if comp.ID == "llm" && (plan == nil || modelName != plan.Tag) && !confirmModelFit(modelName, componentIDs) {
+			continue
 		}
-		return customModel, nil
+		return modelName, nil
 	}
-
-	return modelMap[selected], nil
 }
 
 // 4. Fix handleComponentModification
@@ -997,6 +1091,12 @@ func handleComponentModification(toAdd, toRemove []string) error {
 		selectedModels := make(map[string]string)
 		manager := models.NewManager("http://localhost:11434")
 
+		// Models are sized against every component the project will have
+		planned := append([]string{}, cfg.Project.Components...)
+		for _, compID := range toAdd {
+			planned = appendUnique(planned, compID)
+		}
+
 		for _, compID := range toAdd {
 			if compID == "llm" || compID == "embedding" || compID == "stt" {
 				comp, err := components.GetComponent(compID)
@@ -1008,7 +1108,7 @@ func handleComponentModification(toAdd, toRemove []string) error {
 				if compID == "embedding" {
 					model, err = selectEmbeddingModel(manager)
 				} else {
-					model, err = selectComponentModel(comp, manager)
+					model, err = selectComponentModel(comp, manager, planned)
 				}
 
 				if err != nil {
@@ -1095,6 +1195,19 @@ func updateConfig(cfg *config.Config, componentIDs []string, selectedModels map[
 				ReplicaSet:  false,
 				AuthEnabled: true,
 			}
+		case "qdrant":
+			cfg.Services.Qdrant = config.QdrantConfig{
+				Type:     "qdrant",
+				Version:  "latest",
+				Port:     6333,
+				GRPCPort: 6334,
+			}
+		case "chroma":
+			cfg.Services.Chroma = config.ChromaConfig{
+				Type:    "chroma",
+				Version: "0.5.23",
+				Port:    8000,
+			}
 		case "cache":
 			cfg.Services.Cache = config.CacheConfig{
 				Type:            "redis",
@@ -1121,8 +1234,9 @@ func updateConfig(cfg *config.Config, componentIDs []string, selectedModels map[
 			}
 		case "stt":
 			cfg.Services.Whisper = config.WhisperConfig{
-				Type: "localllama",
-				Port: 9000,
+				Type:  "whisper",
+				Port:  9100,
+				Model: components.DefaultWhisperModel(),
 			}
 			if model, ok := selectedModels[compID]; ok {
 				cfg.Services.Whisper.Model = model
@@ -1204,6 +1318,12 @@ func removeComponentsFromConfig(cfg *config.Config, componentIDs []string) {
 		case "mongodb":
 			cfg.Services.MongoDB = config.MongoDBConfig{}
 
+		case "qdrant":
+			cfg.Services.Qdrant = config.QdrantConfig{}
+
+		case "chroma":
+			cfg.Services.Chroma = config.ChromaConfig{}
+
 		case "stt":
 			cfg.Services.Whisper = config.WhisperConfig{}
 		}
@@ -0,0 +1,96 @@
+// internal/cli/model_plan.go
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/localcloud-sh/localcloud/internal/components"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/localcloud-sh/localcloud/internal/system"
+)
+
+// modelContext is the context length models are sized for, set by --context
+// on setup and component update
+var modelContext int
+
+// planRequest describes the memory the project's other components take
+func planRequest(componentIDs []string) system.PlanRequest {
+	var others []string
+	for _, id := range componentIDs {
+		if id != "llm" {
+			others = append(others, id)
+		}
+	}
+	req := system.PlanRequest{Context: modelContext}
+	if len(others) > 0 {
+		req.ReservedRAM = components.CalculateRAMRequirement(others)
+	}
+	return req
+}
+
+// planLLM recommends a language model for this machine, or returns nil when
+// the system cannot be inspected
+func planLLM(componentIDs []string) *system.ModelPlan {
+	plan, err := system.NewChecker(context.Background()).PlanModel(planRequest(componentIDs))
+	if err != nil {
+		if verbose {
+			printWarning(fmt.Sprintf("Could not plan a model: %v", err))
+		}
+		return nil
+	}
+	return plan
+}
+
+// printModelPlan explains a recommendation
+func printModelPlan(plan *system.ModelPlan) {
+	fmt.Printf("%s %s\n", infoColor("Best fit for this machine:"), plan.Tag)
+	for _, reason := range plan.Reasons {
+		fmt.Printf("  • %s\n", reason)
+	}
+	if plan.Risk != system.FitOK {
+		printWarning("Even this model exceeds free memory; close other applications or remove components")
+	}
+}
+
+// assessModel returns the expected fit of a model the user chose, or nil for
+// embedding models and when the system cannot be inspected
+func assessModel(name string, componentIDs []string) *system.ModelFit {
+	if models.IsEmbeddingModel(name) {
+		return nil
+	}
+	fit, err := system.NewChecker(context.Background()).AssessModel(name, planRequest(componentIDs))
+	if err != nil {
+		return nil
+	}
+	return fit
+}
+
+// warnModelFit warns when a chosen model would likely swap or be killed and
+// reports whether it did
+func warnModelFit(name string, componentIDs []string) bool {
+	fit := assessModel(name, componentIDs)
+	if fit == nil || (fit.Risk != system.FitSwap && fit.Risk != system.FitOOM) {
+		return false
+	}
+	printWarning(fit.Message)
+	return true
+}
+
+// confirmModelFit warns about a model that does not fit and asks whether to
+// use it anyway
+func confirmModelFit(name string, componentIDs []string) bool {
+	if !warnModelFit(name, componentIDs) {
+		return true
+	}
+	var proceed bool
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("Use %s anyway?", name),
+		Default: false,
+	}
+	if err := survey.AskOne(prompt, &proceed); err != nil {
+		return false
+	}
+	return proceed
+}
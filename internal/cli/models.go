@@ -2,14 +2,19 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/briandowns/spinner"
 	"github.com/localcloud-sh/localcloud/internal/config"
 	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/providers"
 	"github.com/spf13/cobra"
 )
 
@@ -32,12 +37,21 @@ var modelsListCmd = &cobra.Command{
 }
 
 var modelsPullCmd = &cobra.Command{
-	Use:     "pull [model-name]",
-	Short:   "Download a model",
+	Use:     "pull [model-name...]",
+	Short:   "Download models",
 	Aliases: []string{"download", "get"},
-	Long:    `Download an AI model from the Ollama library.`,
-	Args:    cobra.ExactArgs(1),
-	RunE:    runModelsPull,
+	Long: `Download AI models from the Ollama library.
+
+Several models download at once (--concurrency) and failed downloads are
+retried with backoff. Ollama keeps partially downloaded layers, so running
+the command again after an interruption resumes where it stopped.
+
+With --from-config every model referenced in config.yaml that is not yet
+installed is pulled, which bootstraps a fresh clone in one step.`,
+	Example: `  lc models pull llama3.2:3b
+  lc models pull qwen2.5:3b nomic-embed-text --concurrency 2
+  lc models pull --from-config`,
+	RunE: runModelsPull,
 }
 
 var modelsRemoveCmd = &cobra.Command{
@@ -49,6 +63,22 @@ var modelsRemoveCmd = &cobra.Command{
 	RunE:    runModelsRemove,
 }
 
+var modelsUseCmd = &cobra.Command{
+	Use:   "use [model-name]",
+	Short: "Set the active model",
+	Long: `Make an installed model the project's active model.
+
+Language models become services.ai.default; embedding models replace the
+project's embedding model. The component is detected from the model unless
+--component is given. AI_MODEL and EMBEDDING_MODEL in .env are updated, and
+switching embedding models warns if the vector store holds vectors of a
+different dimension.`,
+	Example: `  lc models use llama3.2:3b
+  lc models use mxbai-embed-large --component embedding`,
+	Args: cobra.ExactArgs(1),
+	RunE: runModelsUse,
+}
+
 var modelsInfoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Show model information",
@@ -56,15 +86,31 @@ var modelsInfoCmd = &cobra.Command{
 	RunE:  runModelsInfo,
 }
 
+var (
+	modelsUseComponent string
+	pullFromConfig     bool
+	pullConcurrency    int
+	pullRetries        int
+)
+
 func init() {
+	modelsPullCmd.Flags().BoolVar(&pullFromConfig, "from-config", false, "Pull every model referenced in config.yaml")
+	modelsPullCmd.Flags().IntVar(&pullConcurrency, "concurrency", 2, "Number of models to download at once")
+	modelsPullCmd.Flags().IntVar(&pullRetries, "retries", 3, "Retries per model after a failed download")
+	modelsUseCmd.Flags().StringVar(&modelsUseComponent, "component", "", "Component to set the model for: llm or embedding (default: detected)")
+
 	modelsCmd.AddCommand(modelsListCmd)
 	modelsCmd.AddCommand(modelsPullCmd)
 	modelsCmd.AddCommand(modelsRemoveCmd)
+	modelsCmd.AddCommand(modelsUseCmd)
 	modelsCmd.AddCommand(modelsInfoCmd)
 }
 
 func runModelsList(cmd *cobra.Command, args []string) error {
 	cfg := config.Get()
+	if !ollamaAPI(cfg) {
+		return listBackendModels(cfg)
+	}
 	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
 
 	// Check if Ollama is available
@@ -109,10 +155,15 @@ func runModelsList(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%-30s %-12s %-20s\n", "NAME", "SIZE", "MODIFIED")
 		fmt.Println(strings.Repeat("─", 62))
 		for _, model := range llmModels {
-			fmt.Printf("%-30s %-12s %-20s\n",
+			derived := ""
+			if profile := models.FindProfile(cfg.Services.AI.Profiles, model.Name); profile != nil {
+				derived = fmt.Sprintf("profile of %s", profile.Base)
+			}
+			fmt.Printf("%-30s %-12s %-20s %s\n",
 				model.Name,
 				FormatBytes(model.Size),
 				model.ModifiedAt.Format("2006-01-02 15:04"),
+				derived,
 			)
 		}
 	}
@@ -135,11 +186,15 @@ func runModelsList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Get active model
-	activeModel, _ := manager.GetActiveModel(cfg.Services.AI.Default)
+	// Get active models
+	activeModel, _ := manager.GetActiveModel(models.ComponentLLM)
 	if activeModel != "" {
 		fmt.Printf("\nActive model: %s\n", infoColor(activeModel))
 	}
+	activeEmbedding, _ := manager.GetActiveModel(models.ComponentEmbedding)
+	if activeEmbedding != "" {
+		fmt.Printf("Active embedding model: %s\n", infoColor(activeEmbedding))
+	}
 
 	// Show recommendations
 	fmt.Println()
@@ -149,8 +204,21 @@ func runModelsList(cmd *cobra.Command, args []string) error {
 }
 
 func runModelsPull(cmd *cobra.Command, args []string) error {
-	modelName := args[0]
 	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+
+	if len(args) == 0 && !pullFromConfig {
+		return fmt.Errorf("specify one or more models, or use --from-config")
+	}
+	if !ollamaAPI(cfg) {
+		if pullFromConfig {
+			return fmt.Errorf("--from-config needs Ollama; name the GGUF files to import")
+		}
+		return pullBackendModels(cfg, models.UniqueModels(args))
+	}
+
 	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
 
 	// Check if Ollama is available
@@ -158,86 +226,248 @@ func runModelsPull(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
 	}
 
-	// Check if model is an embedding model
-	isEmbedding := models.IsEmbeddingModel(modelName)
-	modelType := "language"
-	if isEmbedding {
-		modelType = "embedding"
+	names := args
+	if pullFromConfig {
+		configured := configuredModels(cfg)
+		if len(configured) == 0 {
+			return fmt.Errorf("no models referenced in config.yaml")
+		}
+
+		installed, err := manager.List()
+		if err != nil {
+			return fmt.Errorf("failed to list installed models: %w", err)
+		}
+
+		// Models already on disk are skipped; name them explicitly to update them
+		for _, name := range configured {
+			if modelInstalled(installed, name) {
+				printInfo(fmt.Sprintf("%s is already installed", name))
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	names = models.UniqueModels(names)
+	if len(names) == 0 {
+		printSuccess("All configured models are installed")
+		return nil
 	}
 
-	fmt.Printf("Pulling %s model: %s\n", modelType, modelName)
+	if len(names) == 1 {
+		modelType := "language"
+		if models.IsEmbeddingModel(names[0]) {
+			modelType = "embedding"
+		}
+		fmt.Printf("Pulling %s model: %s\n", modelType, names[0])
+	} else {
+		fmt.Printf("Pulling %d models (%d at a time)\n", len(names), pullConcurrency)
+	}
 
-	// Create progress channel
-	progress := make(chan models.PullProgress)
-	done := make(chan error)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Start pull in goroutine
+	updates := make(chan models.PullUpdate)
+	resultsCh := make(chan []models.PullResult, 1)
 	go func() {
-		done <- manager.Pull(modelName, progress)
+		resultsCh <- manager.PullAll(ctx, names, models.PullOptions{
+			Concurrency: pullConcurrency,
+			Retries:     pullRetries,
+		}, updates)
 	}()
 
-	// Display progress
-	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
-	s.Start()
+	display := newPullDisplay(names)
+	for u := range updates {
+		display.Update(u)
+	}
+	display.Finish()
+	results := <-resultsCh
 
-	for {
-		select {
-		case p := <-progress:
-			s.Stop()
-			if p.Total > 0 {
-				percentage := int((p.Completed * 100) / p.Total)
-				bar := progressBar(percentage, 30)
-				fmt.Printf("\r%s: %d%% [%s] %s/%s",
-					p.Status,
-					percentage,
-					bar,
-					FormatBytes(p.Completed),
-					FormatBytes(p.Total))
-			} else {
-				fmt.Printf("\r%s: %s", p.Status, p.Digest)
-			}
+	if ctx.Err() != nil {
+		fmt.Println()
+		printWarning("Interrupted. Run the same command again to resume the downloads")
+		return fmt.Errorf("pull interrupted")
+	}
 
-		case err := <-done:
-			s.Stop()
-			fmt.Println() // New line after progress
+	failed := pullSummary(results)
+	if len(failed) > 0 {
+		fmt.Println()
+		if len(failed) < len(results) {
+			printSuccess(fmt.Sprintf("Pulled %d of %d models", len(results)-len(failed), len(results)))
+		}
+		printInfo("Partial downloads are kept; run the command again to resume")
+		return fmt.Errorf("failed to pull %s", joinModels(failed))
+	}
 
-			if err != nil {
-				return fmt.Errorf("failed to pull model: %w", err)
-			}
+	if len(names) > 1 {
+		printSuccess(fmt.Sprintf("Pulled %d models: %s", len(names), joinModels(names)))
+		return nil
+	}
 
-			printSuccess(fmt.Sprintf("Model '%s' pulled successfully!", modelName))
-
-			// Show usage examples based on model type
-			fmt.Println("\nTry it out:")
-			if isEmbedding {
-				fmt.Println("  # Generate embedding")
-				fmt.Printf("  curl http://localhost:%d/api/embeddings \\\n", cfg.Services.AI.Port)
-				fmt.Printf("    -d '{\"model\":\"%s\",\"prompt\":\"Hello world\"}'\n", modelName)
-				fmt.Println()
-				fmt.Println("  # Python example")
-				fmt.Println("  import requests")
-				fmt.Printf("  resp = requests.post('http://localhost:%d/api/embeddings',\n", cfg.Services.AI.Port)
-				fmt.Printf("      json={'model': '%s', 'prompt': 'Hello world'})\n", modelName)
-				fmt.Println("  embedding = resp.json()['embedding']")
-			} else {
-				fmt.Println("  # Chat completion")
-				fmt.Printf("  curl http://localhost:%d/api/chat \\\n", cfg.Services.AI.Port)
-				fmt.Printf("    -d '{\"model\":\"%s\",\"messages\":[{\"role\":\"user\",\"content\":\"Hello!\"}]}'\n", modelName)
-				fmt.Println()
-				fmt.Println("  # Generate text")
-				fmt.Printf("  curl http://localhost:%d/api/generate \\\n", cfg.Services.AI.Port)
-				fmt.Printf("    -d '{\"model\":\"%s\",\"prompt\":\"Once upon a time\"}'\n", modelName)
-			}
+	modelName := names[0]
+	printSuccess(fmt.Sprintf("Model '%s' pulled successfully!", modelName))
+	printModelUsage(cfg, modelName)
 
-			// Update config if this is the first model
-			if cfg.Services.AI.Default == "" {
-				fmt.Printf("\nSetting %s as default model\n", modelName)
-				// This would update the config
-			}
+	// Update config if this is the first model
+	if cfg.Services.AI.Default == "" {
+		fmt.Printf("\nSetting %s as default model\n", modelName)
+		// This would update the config
+	}
+
+	return nil
+}
+
+// configuredModels returns every model config.yaml references that can be
+// downloaded: the model list, the default model, the targets of model
+// aliases and the bases of model profiles. Profiles themselves are built
+// with lc models create.
+func configuredModels(cfg *config.Config) []string {
+	var names []string
+	names = append(names, cfg.Services.AI.Models...)
+	if cfg.Services.AI.Default != "" {
+		names = append(names, cfg.Services.AI.Default)
+	}
+	for _, alias := range cfg.Services.AI.Aliases {
+		names = append(names, alias.Model)
+	}
+	for _, profile := range cfg.Services.AI.Profiles {
+		names = append(names, profile.Base)
+	}
+
+	var pullable []string
+	for _, name := range models.UniqueModels(names) {
+		if models.FindProfile(cfg.Services.AI.Profiles, name) == nil {
+			pullable = append(pullable, name)
+		}
+	}
+	return pullable
+}
+
+// modelInstalled reports whether name is in installed, matching the implicit
+// :latest tag
+func modelInstalled(installed []models.Model, name string) bool {
+	for _, model := range installed {
+		if model.Name == name || model.Model == name || model.Name == name+":latest" {
+			return true
+		}
+	}
+	return false
+}
+
+// printModelUsage shows how to call a freshly pulled model
+func printModelUsage(cfg *config.Config, modelName string) {
+	fmt.Println("\nTry it out:")
+	if models.IsEmbeddingModel(modelName) {
+		fmt.Println("  # Generate embedding")
+		fmt.Printf("  curl http://localhost:%d/api/embeddings \\\n", cfg.Services.AI.Port)
+		fmt.Printf("    -d '{\"model\":\"%s\",\"prompt\":\"Hello world\"}'\n", modelName)
+		fmt.Println()
+		fmt.Println("  # Python example")
+		fmt.Println("  import requests")
+		fmt.Printf("  resp = requests.post('http://localhost:%d/api/embeddings',\n", cfg.Services.AI.Port)
+		fmt.Printf("      json={'model': '%s', 'prompt': 'Hello world'})\n", modelName)
+		fmt.Println("  embedding = resp.json()['embedding']")
+	} else {
+		fmt.Println("  # Chat completion")
+		fmt.Printf("  curl http://localhost:%d/api/chat \\\n", cfg.Services.AI.Port)
+		fmt.Printf("    -d '{\"model\":\"%s\",\"messages\":[{\"role\":\"user\",\"content\":\"Hello!\"}]}'\n", modelName)
+		fmt.Println()
+		fmt.Println("  # Generate text")
+		fmt.Printf("  curl http://localhost:%d/api/generate \\\n", cfg.Services.AI.Port)
+		fmt.Printf("    -d '{\"model\":\"%s\",\"prompt\":\"Once upon a time\"}'\n", modelName)
+	}
+}
 
-			return nil
+func runModelsUse(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	modelName := args[0]
+	cfg := config.Get()
+	if !ollamaAPI(cfg) {
+		return useBackendModel(cfg, modelName)
+	}
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+
+	if !manager.IsOllamaAvailable() {
+		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
+	}
+
+	component := modelsUseComponent
+	if component == "" {
+		component = models.ComponentLLM
+		if manager.CheckModelType(modelName) == "embedding" {
+			component = models.ComponentEmbedding
 		}
 	}
+
+	previous, err := manager.GetActiveModel(component)
+	if err != nil {
+		return err
+	}
+	if previous == modelName {
+		printInfo(fmt.Sprintf("%s is already the active %s model", modelName, component))
+		return nil
+	}
+
+	if err := manager.SetActiveModel(modelName, component); err != nil {
+		return err
+	}
+
+	if component == models.ComponentEmbedding {
+		printSuccess(fmt.Sprintf("Active embedding model: %s", modelName))
+	} else {
+		printSuccess(fmt.Sprintf("Active model: %s", modelName))
+	}
+
+	envKey := "AI_MODEL"
+	if component == models.ComponentEmbedding {
+		envKey = "EMBEDDING_MODEL"
+	}
+	updated, err := updateEnvFile(".env", map[string]string{envKey: modelName})
+	if err != nil {
+		printWarning(fmt.Sprintf("Failed to update .env: %v", err))
+	} else if updated {
+		printInfo(fmt.Sprintf("Updated %s in .env; restart your application to pick it up", envKey))
+	}
+
+	if component == models.ComponentEmbedding {
+		checkVectorDimension(cfg, manager, modelName)
+	}
+
+	return nil
+}
+
+// checkVectorDimension warns when the vector store holds embeddings whose
+// dimension differs from what modelName produces
+func checkVectorDimension(cfg *config.Config, manager *models.Manager, modelName string) {
+	dimension, err := manager.EmbeddingDimension(modelName)
+	if err != nil {
+		if verbose {
+			printWarning(fmt.Sprintf("Could not determine embedding dimension: %v", err))
+		}
+		return
+	}
+
+	db, err := providers.Open(cfg, &vectordb.Config{EmbeddingDim: dimension})
+	if err != nil {
+		// No vector store configured or running, so nothing can mismatch
+		return
+	}
+	reporter, ok := db.(vectordb.DimensionReporter)
+	if !ok {
+		return
+	}
+
+	stored, err := reporter.CollectionDimension(context.Background(), "")
+	if err != nil || stored == 0 || stored == dimension {
+		return
+	}
+
+	fmt.Println()
+	printWarning(fmt.Sprintf("The vector store holds %d-dimensional embeddings, but %s produces %d dimensions", stored, modelName, dimension))
+	fmt.Println("  Searches will fail until existing content is re-embedded:")
+	fmt.Printf("    %s\n", infoColor(fmt.Sprintf("lc vector reembed --model %s", modelName)))
 }
 
 func runModelsRemove(cmd *cobra.Command, args []string) error {
@@ -245,8 +475,11 @@ func runModelsRemove(cmd *cobra.Command, args []string) error {
 	cfg := config.Get()
 	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
 
+	// llama.cpp and LocalAI models are files in the models directory
+	ggufModel := !ollamaAPI(cfg)
+
 	// Check if Ollama is available
-	if !manager.IsOllamaAvailable() {
+	if !ggufModel && !manager.IsOllamaAvailable() {
 		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
 	}
 
@@ -265,7 +498,12 @@ func runModelsRemove(cmd *cobra.Command, args []string) error {
 	s.Suffix = fmt.Sprintf(" Removing %s...", modelName)
 	s.Start()
 
-	err := manager.Remove(modelName)
+	var err error
+	if ggufModel {
+		err = models.RemoveGGUF(models.GGUFDir(), modelName)
+	} else {
+		err = manager.Remove(modelName)
+	}
 	s.Stop()
 
 	if err != nil {
@@ -286,8 +524,21 @@ func runModelsInfo(cmd *cobra.Command, args []string) error {
 	// Provider detection
 	provider := manager.DetectProvider()
 
-	// Ollama status
-	if manager.IsOllamaAvailable() {
+	// llama.cpp or LocalAI status
+	if !ollamaAPI(cfg) {
+		fmt.Printf("Provider: %s\n", infoColor(cfg.Services.AI.ProviderName()))
+		backend, err := models.NewBackend(cfg.Services.AI)
+		if err != nil {
+			return err
+		}
+		if err := backend.Health(context.Background()); err != nil {
+			fmt.Printf("Status: %s\n", errorColor("Not Running ✗"))
+		} else {
+			fmt.Printf("Status: %s\n", successColor("Running ✓"))
+			fmt.Printf("Endpoint: %s\n", infoColor(fmt.Sprintf("http://localhost:%d/v1", cfg.Services.AI.Port)))
+		}
+		fmt.Printf("Models directory: %s\n", infoColor(models.GGUFDir()))
+	} else if manager.IsOllamaAvailable() {
 		fmt.Printf("Ollama Status: %s\n", successColor("Running ✓"))
 		fmt.Printf("Endpoint: %s\n", infoColor(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port)))
 
@@ -307,7 +558,9 @@ func runModelsInfo(cmd *cobra.Command, args []string) error {
 	}
 
 	// Active provider
-	fmt.Printf("\nActive Provider: %s\n", infoColor(string(provider)))
+	if ollamaAPI(cfg) {
+		fmt.Printf("\nActive Provider: %s\n", infoColor(string(provider)))
+	}
 
 	// Configuration
 	fmt.Println("\nConfiguration:")
@@ -341,7 +594,7 @@ func showRecommendedModels() {
 	fmt.Printf("%-20s %-10s %s\n", "MODEL", "SIZE", "DIMENSIONS")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	for _, model := range models.PredefinedEmbeddingModels {
+	for _, model := range models.EmbeddingModels() {
 		fmt.Printf("%-20s %-10s %d dimensions\n",
 			infoColor(model.Name),
 			model.Size,
@@ -378,7 +631,7 @@ func showRecommendedModelsNotInstalled(installed []models.Model) {
 
 	// Find recommended embedding models not installed
 	notInstalledEmbeddings := []models.EmbeddingModel{}
-	for _, rec := range models.PredefinedEmbeddingModels {
+	for _, rec := range models.EmbeddingModels() {
 		found := false
 		for _, inst := range installed {
 			if inst.Name == rec.Name {
@@ -0,0 +1,101 @@
+// internal/cli/models_backend.go
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/docker"
+	"github.com/localcloud-sh/localcloud/internal/services"
+	"github.com/localcloud-sh/localcloud/internal/system"
+	"github.com/spf13/cobra"
+)
+
+var modelsBackendCmd = &cobra.Command{
+	Use:   "backend [host|container]",
+	Short: "Choose between a host-installed Ollama and the container",
+	Long: `Show or set where Ollama runs (services.ai.backend).
+
+container (default): lc start runs Ollama in a container.
+host: lc start uses an Ollama already installed and running on this machine,
+      on services.ai.port. LocalCloud neither starts nor stops it, and lc models
+      manages the models installed there.`,
+	Example: `  lc models backend
+  lc models backend host
+  lc models backend container`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{config.AIBackendHost, config.AIBackendContainer},
+	RunE:      runModelsBackend,
+}
+
+func init() {
+	modelsCmd.AddCommand(modelsBackendCmd)
+}
+
+func runModelsBackend(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if cfg.Services.AI.Port == 0 {
+		return fmt.Errorf("the AI service is not configured. Add it with: lc component add llm")
+	}
+
+	port := cfg.Services.AI.Port
+	installed := system.NewChecker(context.Background()).CheckOllama()
+	version, hostErr := docker.HostAIVersion(port)
+
+	// Show
+	if len(args) == 0 {
+		backend := cfg.Services.AI.Backend
+		if backend == "" {
+			backend = config.AIBackendContainer
+		}
+		fmt.Printf("Backend: %s\n", backend)
+		switch {
+		case hostErr == nil && cfg.Services.AI.HostBackend():
+			fmt.Printf("Host Ollama: %s, answering on port %d\n", version, port)
+		case installed:
+			fmt.Println("Host Ollama: installed")
+		default:
+			fmt.Println("Host Ollama: not installed")
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case config.AIBackendHost:
+		if !installed && hostErr != nil {
+			return fmt.Errorf("Ollama is not installed on this machine; install it from https://ollama.com first")
+		}
+		cfg.Services.AI.Backend = config.AIBackendHost
+	case config.AIBackendContainer:
+		cfg.Services.AI.Backend = config.AIBackendContainer
+	default:
+		return fmt.Errorf("unknown backend %q (use host or container)", args[0])
+	}
+
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	// The host endpoint is registered by lc start
+	if args[0] == config.AIBackendContainer {
+		services.NewServiceRegistry(".").Unregister("ai")
+	}
+
+	printSuccess(fmt.Sprintf("AI backend: %s", args[0]))
+	if cfg.Services.AI.Mode == config.AIModeMock {
+		printWarning("services.ai.mode is mock; the mock backend is used until the mode is removed")
+	}
+	if args[0] == config.AIBackendHost && port != 11434 {
+		printInfo(fmt.Sprintf("Ollama must listen on port %d: OLLAMA_HOST=127.0.0.1:%d ollama serve", port, port))
+	}
+	printInfo("Apply with: lc stop && lc start")
+	return nil
+}
@@ -0,0 +1,299 @@
+// internal/cli/models_bench.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/docker"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var modelsBenchCmd = &cobra.Command{
+	Use:   "bench [model...]",
+	Short: "Benchmark installed models",
+	Long: `Run a prompt set against installed models and compare them.
+
+Each model is unloaded and loaded again to measure a cold load, then every
+prompt is run to measure time to first token and prompt and generation speed
+in tokens per second. Peak memory of the AI container is sampled while the
+model runs.
+
+Results are saved to .localcloud/bench/ and shown as a comparison table. The
+standard prompt set covers short answers, explanations, code and
+summarisation; --prompts runs your own.`,
+	Example: `  lc models bench
+  lc models bench llama3.2:3b qwen2.5:3b --runs 3
+  lc models bench phi3:mini --prompts prompts.yaml
+
+  # prompts.yaml
+  #   - name: support
+  #     prompt: How do I reset my password?
+
+  # Show saved results without running
+  lc models bench --results`,
+	RunE: runModelsBench,
+}
+
+var (
+	benchPrompts    string
+	benchRuns       int
+	benchNumPredict int
+	benchResults    bool
+)
+
+func init() {
+	modelsBenchCmd.Flags().StringVar(&benchPrompts, "prompts", "", "YAML file with the prompts to run (default: standard set)")
+	modelsBenchCmd.Flags().IntVar(&benchRuns, "runs", 1, "Times to run each prompt")
+	modelsBenchCmd.Flags().IntVar(&benchNumPredict, "num-predict", 128, "Maximum tokens to generate per prompt")
+	modelsBenchCmd.Flags().BoolVar(&benchResults, "results", false, "Show the latest saved result of each model")
+
+	modelsCmd.AddCommand(modelsBenchCmd)
+}
+
+func runModelsBench(cmd *cobra.Command, args []string) error {
+	if benchResults {
+		results, err := models.LatestBenchResults(models.BenchDir())
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			printInfo("No benchmark results yet. Run: lc models bench")
+			return nil
+		}
+		printBenchTable(results)
+		return nil
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if err := requireOllama(cfg, "lc models bench"); err != nil {
+		return err
+	}
+
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+	if !manager.IsOllamaAvailable() {
+		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
+	}
+
+	names := args
+	if len(names) == 0 {
+		if cfg.Services.AI.Default == "" {
+			return fmt.Errorf("no model given and no default model configured")
+		}
+		names = []string{cfg.Services.AI.Default}
+	}
+	names = models.UniqueModels(names)
+
+	installed, err := manager.List()
+	if err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+	for _, name := range names {
+		if models.IsEmbeddingModel(name) {
+			return fmt.Errorf("%s is an embedding model and cannot be benchmarked for generation", name)
+		}
+		if !modelInstalled(installed, name) {
+			return fmt.Errorf("model %s is not installed. Download it with: lc models pull %s", name, name)
+		}
+	}
+
+	prompts := models.DefaultBenchPrompts
+	if benchPrompts != "" {
+		if prompts, err = models.LoadBenchPrompts(benchPrompts); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sampler := newMemorySampler()
+	defer sampler.Close()
+	if sampler == nil {
+		printInfo("AI container not found; peak memory will not be measured")
+	}
+
+	var results []*models.BenchResult
+	for _, name := range names {
+		fmt.Printf("Benchmarking %s (%d prompts × %d runs)...\n", name, len(prompts), benchRuns)
+
+		sampler.Start()
+		result, err := manager.Bench(ctx, name, prompts, models.BenchOptions{Runs: benchRuns, NumPredict: benchNumPredict})
+		peak := sampler.Stop()
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("benchmark interrupted")
+			}
+			printError(fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		result.PeakMemory = peak
+
+		path, err := models.SaveBenchResult(models.BenchDir(), result)
+		if err != nil {
+			printWarning(fmt.Sprintf("Failed to save result: %v", err))
+		} else if verbose {
+			fmt.Printf("  Saved %s\n", path)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return fmt.Errorf("no model could be benchmarked")
+	}
+
+	fmt.Println()
+	printBenchTable(results)
+	fmt.Printf("\nResults saved to %s\n", models.BenchDir())
+	return nil
+}
+
+// printBenchTable prints results side by side, marking the best value of
+// each column when more than one model is compared
+func printBenchTable(results []*models.BenchResult) {
+	best := struct {
+		load, ttft   time.Duration
+		prompt, eval float64
+		memory       uint64
+	}{}
+	for i, r := range results {
+		if i == 0 || r.LoadTime < best.load {
+			best.load = r.LoadTime
+		}
+		if i == 0 || r.TTFT < best.ttft {
+			best.ttft = r.TTFT
+		}
+		if r.PromptTokPerSec > best.prompt {
+			best.prompt = r.PromptTokPerSec
+		}
+		if r.EvalTokPerSec > best.eval {
+			best.eval = r.EvalTokPerSec
+		}
+		if r.PeakMemory > 0 && (best.memory == 0 || r.PeakMemory < best.memory) {
+			best.memory = r.PeakMemory
+		}
+	}
+
+	mark := func(value string, isBest bool) string {
+		if isBest && len(results) > 1 {
+			return value + "*"
+		}
+		return value
+	}
+
+	fmt.Printf("%-28s %-10s %-10s %-14s %-14s %-12s %-16s\n", "MODEL", "LOAD", "TTFT", "PROMPT TOK/S", "GEN TOK/S", "PEAK MEM", "DATE")
+	fmt.Println(strings.Repeat("─", 108))
+	for _, r := range results {
+		memory := "n/a"
+		if r.PeakMemory > 0 {
+			memory = mark(FormatBytes(int64(r.PeakMemory)), r.PeakMemory == best.memory)
+		}
+		fmt.Printf("%-28s %-10s %-10s %-14s %-14s %-12s %-16s\n",
+			r.Model,
+			mark(formatBenchDuration(r.LoadTime), r.LoadTime == best.load),
+			mark(formatBenchDuration(r.TTFT), r.TTFT == best.ttft),
+			mark(fmt.Sprintf("%.1f", r.PromptTokPerSec), r.PromptTokPerSec == best.prompt),
+			mark(fmt.Sprintf("%.1f", r.EvalTokPerSec), r.EvalTokPerSec == best.eval),
+			memory,
+			r.Timestamp.Local().Format("2006-01-02 15:04"),
+		)
+	}
+	if len(results) > 1 {
+		fmt.Println("\n* best in column")
+	}
+}
+
+func formatBenchDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
+
+// memorySampler records the peak memory of the AI container between Start
+// and Stop. A nil sampler is valid and measures nothing.
+type memorySampler struct {
+	client      *docker.Client
+	containerID string
+
+	mu   sync.Mutex
+	peak uint64
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newMemorySampler returns nil when the AI container is not available, for
+// example with a host Ollama or the mock backend
+func newMemorySampler() *memorySampler {
+	client, err := docker.NewClient(context.Background())
+	if err != nil {
+		return nil
+	}
+	id, err := client.FindOllamaContainer()
+	if err != nil {
+		client.Close()
+		return nil
+	}
+	return &memorySampler{client: client, containerID: id}
+}
+
+// Start begins sampling
+func (s *memorySampler) Start() {
+	if s == nil {
+		return
+	}
+	s.peak = 0
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	containers := s.client.NewContainerManager()
+	go func() {
+		defer close(s.done)
+		for {
+			// Stats blocks for about a second while Docker takes a sample
+			if stats, err := containers.Stats(s.containerID); err == nil {
+				s.mu.Lock()
+				if stats.MemoryUsage > s.peak {
+					s.peak = stats.MemoryUsage
+				}
+				s.mu.Unlock()
+			}
+			select {
+			case <-s.stop:
+				return
+			default:
+			}
+		}
+	}()
+}
+
+// Stop ends sampling and returns the peak
+func (s *memorySampler) Stop() uint64 {
+	if s == nil {
+		return 0
+	}
+	close(s.stop)
+	<-s.done
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.peak
+}
+
+// Close releases the Docker client
+func (s *memorySampler) Close() {
+	if s != nil {
+		s.client.Close()
+	}
+}
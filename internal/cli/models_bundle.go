@@ -0,0 +1,245 @@
+// internal/cli/models_bundle.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/docker"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var modelsExportCmd = &cobra.Command{
+	Use:   "export [model-name]",
+	Short: "Export a model to a bundle file",
+	Long: `Package a model's manifest and blobs from the Ollama store into a tar
+bundle, for copying to machines without internet access.
+
+The store is read from the running AI container, or from the host Ollama
+directory (~/.ollama/models or $OLLAMA_MODELS) when the container is not
+running. Use --models-dir to point at another directory.`,
+	Example: `  lc models export llama3.2:3b -o llama3.2-3b.tar
+  lc models export nomic-embed-text -o /media/usb/nomic.tar`,
+	Args: cobra.ExactArgs(1),
+	RunE: runModelsExport,
+}
+
+var modelsImportCmd = &cobra.Command{
+	Use:   "import [bundle.tar]",
+	Short: "Import a model from a bundle or GGUF file",
+	Long: `Load a model bundle written by 'lc models export' into the Ollama store.
+
+Every blob is checked against its digest before anything is written, and
+blobs the store already has are skipped. --name imports the model under a
+different name.
+
+With --gguf, a model is created from a local GGUF file through the Ollama API
+instead; this needs the AI service to be running.`,
+	Example: `  lc models import llama3.2-3b.tar
+  lc models import llama3.2-3b.tar --name llama-offline
+  lc models import --gguf ./mistral-7b.Q4_K_M.gguf --name mistral-custom`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runModelsImport,
+}
+
+var (
+	bundleOutput    string
+	bundleModelsDir string
+	importName      string
+	importGGUF      string
+)
+
+func init() {
+	modelsExportCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "Bundle file to write (default: <model>.tar)")
+	modelsExportCmd.Flags().StringVar(&bundleModelsDir, "models-dir", "", "Ollama models directory to read instead of the AI container")
+
+	modelsImportCmd.Flags().StringVar(&importName, "name", "", "Name for the imported model (required with --gguf)")
+	modelsImportCmd.Flags().StringVar(&importGGUF, "gguf", "", "Create the model from a local GGUF file")
+	modelsImportCmd.Flags().StringVar(&bundleModelsDir, "models-dir", "", "Ollama models directory to write instead of the AI container")
+
+	modelsCmd.AddCommand(modelsExportCmd)
+	modelsCmd.AddCommand(modelsImportCmd)
+}
+
+func runModelsExport(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	output := bundleOutput
+	if output == "" {
+		output = strings.NewReplacer(":", "-", "/", "-").Replace(name) + ".tar"
+	}
+
+	store, location, cleanup, err := openModelStore()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if _, _, err := models.ReadManifest(store, name); err != nil {
+		return fmt.Errorf("%w. Installed models: lc models list", err)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", output, err)
+	}
+
+	fmt.Printf("Exporting %s from %s\n", name, location)
+	index, err := models.ExportModel(store, name, file, bundleProgress())
+	fmt.Println()
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(output)
+		return fmt.Errorf("failed to export %s: %w", name, err)
+	}
+
+	printSuccess(fmt.Sprintf("Exported %s (%s) to %s", name, FormatBytes(index.Size), output))
+	fmt.Printf("\nImport it on another machine with:\n  lc models import %s\n", filepath.Base(output))
+	return nil
+}
+
+func runModelsImport(cmd *cobra.Command, args []string) error {
+	if importGGUF != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("pass either a bundle file or --gguf, not both")
+		}
+		return importGGUFModel()
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("specify a bundle file, or a GGUF file with --gguf")
+	}
+
+	store, location, cleanup, err := openModelStore()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	fmt.Printf("Importing %s into %s\n", args[0], location)
+	index, err := models.ImportModel(store, args[0], importName, bundleProgress())
+	fmt.Println()
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", args[0], err)
+	}
+
+	printSuccess(fmt.Sprintf("Imported %s (%s)", index.Name, FormatBytes(index.Size)))
+	fmt.Printf("\nMake it the active model with:\n  lc models use %s\n", index.Name)
+	return nil
+}
+
+// importGGUFModel uploads a GGUF file to Ollama and creates a model from it
+func importGGUFModel() error {
+	if importName == "" {
+		return fmt.Errorf("--name is required with --gguf")
+	}
+	if _, err := os.Stat(importGGUF); err != nil {
+		return err
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+	if !manager.IsOllamaAvailable() {
+		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
+	}
+
+	ctx := context.Background()
+
+	fmt.Printf("Uploading %s\n", filepath.Base(importGGUF))
+	progress := bundleProgress()
+	digest, err := manager.PushBlob(ctx, importGGUF, func(done, total int64) {
+		progress("uploading", done, total)
+	})
+	fmt.Println()
+	if err != nil {
+		return err
+	}
+
+	updates := make(chan models.PullProgress)
+	done := make(chan error, 1)
+	go func() {
+		done <- manager.Create(ctx, models.CreateRequest{
+			Model: importName,
+			Files: map[string]string{filepath.Base(importGGUF): digest},
+		}, updates)
+	}()
+	for update := range updates {
+		if verbose && update.Status != "" {
+			fmt.Printf("  %s\n", update.Status)
+		}
+	}
+	if err := <-done; err != nil {
+		return err
+	}
+
+	printSuccess(fmt.Sprintf("Created %s from %s", importName, filepath.Base(importGGUF)))
+	fmt.Printf("\nMake it the active model with:\n  lc models use %s\n", importName)
+	return nil
+}
+
+// openModelStore returns the Ollama store bundles are read from and written
+// to: --models-dir, the running AI container, or the host Ollama directory
+// (always, with services.ai.backend: host)
+func openModelStore() (models.Store, string, func(), error) {
+	if bundleModelsDir != "" {
+		if _, err := os.Stat(bundleModelsDir); err != nil {
+			return nil, "", nil, err
+		}
+		return models.NewDirStore(bundleModelsDir), bundleModelsDir, func() {}, nil
+	}
+
+	cfg := config.Get()
+	if cfg != nil && cfg.Services.AI.Mode == config.AIModeMock {
+		return nil, "", nil, fmt.Errorf("the mock AI backend has no model store; use --models-dir")
+	}
+	if cfg != nil {
+		if err := requireOllama(cfg, "Model bundles"); err != nil {
+			return nil, "", nil, err
+		}
+	}
+
+	// A host Ollama keeps its models in the host directory
+	if cfg == nil || !cfg.Services.AI.HostBackend() {
+		client, err := docker.NewClient(context.Background())
+		if err == nil {
+			if id, err := client.FindOllamaContainer(); err == nil {
+				return client.NewOllamaStore(id), "the AI container", func() { client.Close() }, nil
+			}
+			client.Close()
+		}
+	}
+
+	dir := models.DefaultModelsDir()
+	if dir != "" {
+		if _, err := os.Stat(dir); err == nil {
+			return models.NewDirStore(dir), dir, func() {}, nil
+		}
+	}
+
+	return nil, "", nil, fmt.Errorf("no Ollama model store found. Start the AI service with 'lc start ai' or use --models-dir")
+}
+
+// bundleProgress returns a progress callback that redraws a single line
+func bundleProgress() models.BundleProgress {
+	var last time.Time
+	return func(stage string, done, total int64) {
+		if total <= 0 || (time.Since(last) < 100*time.Millisecond && done < total) {
+			return
+		}
+		last = time.Now()
+
+		percentage := int(done * 100 / total)
+		fmt.Printf("\r\033[K  %-10s [%s] %3d%% %s/%s", stage, progressBar(percentage, 30), percentage, FormatBytes(done), FormatBytes(total))
+	}
+}
@@ -0,0 +1,140 @@
+// internal/cli/models_create.go
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var modelsCreateCmd = &cobra.Command{
+	Use:   "create [profile...]",
+	Short: "Build the model profiles defined in config.yaml",
+	Long: `Build custom models from the profiles in services.ai.profiles.
+
+A profile names a base model and the system prompt, parameters and template to
+apply to it. Each profile becomes a model of its own that applications select
+by name, instead of repeating these settings in their code.
+
+Only profiles that are missing or changed since they were last built are
+created; --force rebuilds them all. Missing base models are downloaded first.
+'lc start' also builds changed profiles once the AI service is up.`,
+	Example: `  # config.yaml
+  #   services:
+  #     ai:
+  #       profiles:
+  #         - name: support-bot
+  #           base: llama3.2:3b
+  #           system: You are a concise support assistant.
+  #           temperature: 0.2
+  #           num_ctx: 8192
+  #           stop: ["</answer>"]
+
+  lc models create
+  lc models create support-bot --force`,
+	RunE: runModelsCreate,
+}
+
+var createForce bool
+
+func init() {
+	modelsCreateCmd.Flags().BoolVar(&createForce, "force", false, "Rebuild profiles even if unchanged")
+
+	modelsCmd.AddCommand(modelsCreateCmd)
+}
+
+func runModelsCreate(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if err := requireOllama(cfg, "lc models create"); err != nil {
+		return err
+	}
+
+	profiles := cfg.Services.AI.Profiles
+	if len(profiles) == 0 {
+		printInfo("No model profiles defined. Add them under services.ai.profiles in .localcloud/config.yaml")
+		return nil
+	}
+
+	for _, name := range args {
+		if models.FindProfile(profiles, name) == nil {
+			return fmt.Errorf("profile %s not found in services.ai.profiles", name)
+		}
+	}
+
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+	if !manager.IsOllamaAvailable() {
+		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
+	}
+
+	// Bases that are profiles themselves are built by SyncProfiles
+	for _, profile := range profiles {
+		if profile.Base == "" || models.FindProfile(profiles, profile.Base) != nil {
+			continue
+		}
+		if len(args) > 0 && !contains(args, profile.Name) {
+			continue
+		}
+		if err := ensureModelPulled(manager, profile.Base); err != nil {
+			return err
+		}
+	}
+
+	results, err := manager.SyncProfiles(context.Background(), profiles, args, models.ProfileStatePath(), createForce)
+	if printProfileResults(results) > 0 {
+		return fmt.Errorf("some model profiles could not be built")
+	}
+	return err
+}
+
+// syncModelProfiles builds changed profiles after lc start. Base models are
+// not downloaded here; failures are reported but do not fail the start.
+func syncModelProfiles(cfg *config.Config) {
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+	if !manager.IsOllamaAvailable() {
+		return
+	}
+
+	results, err := manager.SyncProfiles(context.Background(), cfg.Services.AI.Profiles, nil, models.ProfileStatePath(), false)
+	if err != nil {
+		printWarning(fmt.Sprintf("Model profiles: %v", err))
+	}
+
+	changed := false
+	for _, result := range results {
+		if result.Action != models.ProfileUnchanged {
+			changed = true
+		}
+	}
+	if changed {
+		fmt.Println()
+		fmt.Println("Model profiles:")
+		printProfileResults(results)
+	}
+}
+
+// printProfileResults prints one line per profile and returns the number of failures
+func printProfileResults(results []models.ProfileResult) int {
+	failed := 0
+	for _, result := range results {
+		switch result.Action {
+		case models.ProfileCreated, models.ProfileUpdated:
+			fmt.Printf("  %s %s %s (from %s)\n", successColor("✓"), result.Name, result.Action, result.Base)
+		case models.ProfileUnchanged:
+			fmt.Printf("  %s %s is up to date\n", infoColor("•"), result.Name)
+		default:
+			failed++
+			fmt.Printf("  %s %s: %v\n", errorColor("✗"), result.Name, result.Err)
+		}
+	}
+	return failed
+}
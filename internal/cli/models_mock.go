@@ -0,0 +1,116 @@
+// internal/cli/models_mock.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var modelsMockCmd = &cobra.Command{
+	Use:   "mock",
+	Short: "Run a deterministic mock of the Ollama API",
+	Long: `Run a mock AI backend that speaks the Ollama API without downloading models.
+
+Generate and chat responses come from a fixtures file or from deterministic
+pseudo-text seeded by the model and prompt. Embeddings are deterministic unit
+vectors with the dimensions of the configured embedding model, and texts that
+share words get similar vectors. Pulls complete instantly.
+
+Set services.ai.mode to "mock" (or LOCALCLOUD_SERVICES_AI_MODE=mock) and
+'lc start' runs this in place of the Ollama container, which keeps CI fast and
+hermetic.`,
+	Example: `  # Use the mock for every lc start in this project
+  #   services:
+  #     ai:
+  #       mode: mock
+  #       fixtures: test/fixtures/ai.yaml
+
+  # Or only in CI
+  LOCALCLOUD_SERVICES_AI_MODE=mock lc start
+
+  # Run it directly
+  lc models mock --port 11434 --fixtures fixtures.yaml`,
+	RunE: runModelsMock,
+}
+
+var (
+	mockHost     string
+	mockPort     int
+	mockFixtures string
+	mockModels   []string
+)
+
+func init() {
+	modelsMockCmd.Flags().StringVar(&mockHost, "host", "localhost", "Address to listen on")
+	modelsMockCmd.Flags().IntVar(&mockPort, "port", 0, "Port to listen on (default: services.ai.port)")
+	modelsMockCmd.Flags().StringVar(&mockFixtures, "fixtures", "", "Fixtures file with scripted responses (default: services.ai.fixtures)")
+	modelsMockCmd.Flags().StringSliceVar(&mockModels, "models", nil, "Models reported as installed (default: services.ai.models)")
+
+	modelsCmd.AddCommand(modelsMockCmd)
+}
+
+func runModelsMock(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+
+	port := mockPort
+	if port == 0 && cfg != nil {
+		port = cfg.Services.AI.Port
+	}
+	if port == 0 {
+		port = 11434
+	}
+
+	fixturesPath := mockFixtures
+	if fixturesPath == "" && cfg != nil {
+		fixturesPath = cfg.Services.AI.Fixtures
+	}
+
+	installed := mockModels
+	if len(installed) == 0 && cfg != nil {
+		installed = append(installed, cfg.Services.AI.Models...)
+		if cfg.Services.AI.Default != "" && !contains(installed, cfg.Services.AI.Default) {
+			installed = append(installed, cfg.Services.AI.Default)
+		}
+	}
+
+	var fixtures *models.MockFixtures
+	if fixturesPath != "" {
+		var err error
+		fixtures, err = models.LoadMockFixtures(fixturesPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	server := models.NewMockServer(installed, fixtures)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	addr := net.JoinHostPort(mockHost, fmt.Sprintf("%d", port))
+	printSuccess(fmt.Sprintf("Mock AI backend listening on http://%s", addr))
+	if fixturesPath != "" {
+		fmt.Printf("  Fixtures: %s (%d responses)\n", fixturesPath, len(fixtures.Responses))
+	}
+	for _, model := range installed {
+		if models.IsEmbeddingModel(model) {
+			fmt.Printf("  Model: %s (%d dimensions)\n", model, server.Dimensions(model))
+		} else {
+			fmt.Printf("  Model: %s\n", model)
+		}
+	}
+
+	if err := server.ListenAndServe(ctx, addr); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+// internal/cli/models_provider.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var modelsProviderCmd = &cobra.Command{
+	Use:   "provider [ollama|llamacpp|localai]",
+	Short: "Choose the inference server that runs models",
+	Long: `Show or set the AI provider (services.ai.provider).
+
+ollama (default): Ollama, with its model registry.
+llamacpp:         llama.cpp's llama-server. It serves one GGUF file, the
+                  default model, with services.ai.chat_template and
+                  services.ai.context_size.
+localai:          LocalAI, which serves the GGUF files in the models directory
+                  and installs others from its gallery.
+
+llama.cpp and LocalAI keep GGUF files in ~/.localcloud/models
+($LOCALCLOUD_GGUF_DIR). Import them with lc models pull followed by a URL,
+hf://owner/repo/file.gguf or a local path.`,
+	Example: `  lc models provider
+  lc models provider llamacpp
+  lc models pull hf://Qwen/Qwen2.5-3B-Instruct-GGUF/qwen2.5-3b-instruct-q4_k_m.gguf
+  lc models use qwen2.5-3b-instruct-q4_k_m`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{config.AIProviderOllama, config.AIProviderLlamaCpp, config.AIProviderLocalAI},
+	RunE:      runModelsProvider,
+}
+
+func init() {
+	modelsCmd.AddCommand(modelsProviderCmd)
+}
+
+func runModelsProvider(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if cfg.Services.AI.Port == 0 {
+		return fmt.Errorf("the AI service is not configured. Add it with: lc component add llm")
+	}
+
+	// Show
+	if len(args) == 0 {
+		fmt.Printf("Provider: %s\n", cfg.Services.AI.ProviderName())
+		if !ollamaAPI(cfg) {
+			fmt.Printf("Models directory: %s\n", models.GGUFDir())
+		}
+		if backend, err := models.NewBackend(cfg.Services.AI); err == nil {
+			if err := backend.Health(context.Background()); err != nil {
+				fmt.Printf("Status: %s\n", warningColor("not running"))
+			} else {
+				fmt.Printf("Status: %s\n", successColor("running"))
+			}
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case config.AIProviderOllama, config.AIProviderLlamaCpp, config.AIProviderLocalAI:
+	default:
+		return fmt.Errorf("unknown provider %q (use ollama, llamacpp or localai)", args[0])
+	}
+	if args[0] == cfg.Services.AI.ProviderName() {
+		printInfo(fmt.Sprintf("The provider is already %s", args[0]))
+		return nil
+	}
+
+	cfg.Services.AI.Provider = args[0]
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("AI provider: %s", args[0]))
+	if cfg.Services.AI.Mode == config.AIModeMock {
+		printWarning("services.ai.mode is mock; the mock backend is used until the mode is removed")
+	}
+	if args[0] == config.AIProviderLlamaCpp && (cfg.Services.AI.Default == "" || !ggufInstalled(cfg.Services.AI.Default)) {
+		printInfo("llama.cpp serves the default model from a GGUF file. Import one first:")
+		fmt.Printf("  %s\n", infoColor("lc models pull hf://<owner>/<repo>/<file>.gguf"))
+		fmt.Printf("  %s\n", infoColor("lc models use <file>"))
+	}
+	printInfo("Apply with: lc stop && lc start")
+	return nil
+}
+
+// ollamaAPI reports whether the AI service speaks Ollama's API: the Ollama
+// provider or the mock backend
+func ollamaAPI(cfg *config.Config) bool {
+	return cfg.Services.AI.Mode == config.AIModeMock || cfg.Services.AI.ProviderName() == config.AIProviderOllama
+}
+
+// requireOllama fails commands that use Ollama's own API when another
+// provider is configured
+func requireOllama(cfg *config.Config, command string) error {
+	if ollamaAPI(cfg) {
+		return nil
+	}
+	return fmt.Errorf("%s needs Ollama, but services.ai.provider is %s", command, cfg.Services.AI.Provider)
+}
+
+// ggufInstalled reports whether a GGUF model is in the models directory
+func ggufInstalled(name string) bool {
+	_, err := os.Stat(models.GGUFPath(models.GGUFDir(), name))
+	return err == nil
+}
+
+// listBackendModels lists the models of llama.cpp or LocalAI
+func listBackendModels(cfg *config.Config) error {
+	backend, err := models.NewBackend(cfg.Services.AI)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var list []models.Model
+	if err := backend.Health(ctx); err == nil {
+		list, err = backend.List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list models: %w", err)
+		}
+	} else {
+		// The files are listed even while the server is down
+		if backend.Provider() == models.ProviderLocalAI {
+			printWarning(fmt.Sprintf("%s; showing the files in the models directory", err))
+		}
+		list, err = models.ListGGUF(models.GGUFDir())
+		if err != nil {
+			return fmt.Errorf("failed to list models: %w", err)
+		}
+	}
+
+	if len(list) == 0 {
+		fmt.Printf("No models in %s yet. Import a GGUF file with:\n", models.GGUFDir())
+		fmt.Printf("  %s\n", infoColor("lc models pull hf://<owner>/<repo>/<file>.gguf"))
+		return nil
+	}
+
+	fmt.Printf("Installed Models (%s):\n", backend.Provider())
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("%-40s %-12s %-20s\n", "NAME", "SIZE", "MODIFIED")
+	fmt.Println(strings.Repeat("─", 72))
+	for _, m := range list {
+		size, modified := "-", "-"
+		if m.Size > 0 {
+			size = FormatBytes(m.Size)
+		}
+		if !m.ModifiedAt.IsZero() {
+			modified = m.ModifiedAt.Format("2006-01-02 15:04")
+		}
+		marker := ""
+		if m.Name == cfg.Services.AI.Default {
+			marker = "default"
+		}
+		fmt.Printf("%-40s %-12s %-20s %s\n", m.Name, size, modified, marker)
+	}
+	fmt.Printf("\nModels directory: %s\n", models.GGUFDir())
+	return nil
+}
+
+// pullBackendModels imports GGUF files or installs LocalAI gallery models
+func pullBackendModels(cfg *config.Config, names []string) error {
+	backend, err := models.NewBackend(cfg.Services.AI)
+	if err != nil {
+		return err
+	}
+
+	// LocalAI installs gallery models itself, so it must be running
+	for _, name := range names {
+		if !models.IsGGUFSource(name) && backend.Provider() == models.ProviderLocalAI {
+			if err := backend.Health(context.Background()); err != nil {
+				return fmt.Errorf("%w. Start it with: lc start ai", err)
+			}
+			break
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	display := newPullDisplay(names)
+	var failed []string
+	for _, name := range names {
+		progress := make(chan models.PullProgress)
+		done := make(chan error, 1)
+		go func() {
+			done <- backend.Pull(ctx, name, progress)
+		}()
+		for p := range progress {
+			display.Update(models.PullUpdate{Model: name, Progress: p, Attempt: 1})
+		}
+		err := <-done
+		display.Update(models.PullUpdate{Model: name, Attempt: 1, Err: err, Done: true})
+		if err != nil {
+			failed = append(failed, name)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	display.Finish()
+
+	if ctx.Err() != nil {
+		fmt.Println()
+		printWarning("Interrupted. Run the same command again to resume the downloads")
+		return fmt.Errorf("pull interrupted")
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to pull %s", joinModels(failed))
+	}
+
+	for _, name := range names {
+		if models.IsGGUFSource(name) {
+			printSuccess(fmt.Sprintf("Imported %s into %s", models.GGUFModelName(name), models.GGUFDir()))
+		} else {
+			printSuccess(fmt.Sprintf("Installed %s", name))
+		}
+	}
+	if backend.Provider() == models.ProviderLlamaCpp {
+		printInfo(fmt.Sprintf("Serve it with: lc models use %s && lc restart", models.GGUFModelName(names[len(names)-1])))
+	}
+	return nil
+}
+
+// useBackendModel makes a llama.cpp or LocalAI model the active one
+func useBackendModel(cfg *config.Config, modelName string) error {
+	backend, err := models.NewBackend(cfg.Services.AI)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var installed []models.Model
+	if backend.Health(ctx) == nil {
+		installed, err = backend.List(ctx)
+	} else {
+		installed, err = models.ListGGUF(models.GGUFDir())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+	if !modelInstalled(installed, modelName) {
+		return fmt.Errorf("model %s is not installed. Import it with: lc models pull <url or path to the .gguf file>", modelName)
+	}
+
+	component := modelsUseComponent
+	if component == "" {
+		component = models.ComponentLLM
+		if models.IsEmbeddingModel(modelName) {
+			component = models.ComponentEmbedding
+		}
+	}
+	if err := models.SaveActiveModel(modelName, component); err != nil {
+		return err
+	}
+
+	envKey := "AI_MODEL"
+	if component == models.ComponentEmbedding {
+		envKey = "EMBEDDING_MODEL"
+	}
+	printSuccess(fmt.Sprintf("Active %s model: %s", component, modelName))
+	if updated, err := updateEnvFile(".env", map[string]string{envKey: modelName}); err != nil {
+		printWarning(fmt.Sprintf("Failed to update .env: %v", err))
+	} else if updated {
+		printInfo(fmt.Sprintf("Updated %s in .env; restart your application to pick it up", envKey))
+	}
+
+	// llama-server loads its model at startup
+	if backend.Provider() == models.ProviderLlamaCpp && component == models.ComponentLLM {
+		printInfo("Restart the AI service to serve it: lc restart")
+	}
+	return nil
+}
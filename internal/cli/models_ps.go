@@ -0,0 +1,271 @@
+// internal/cli/models_ps.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var modelsPsCmd = &cobra.Command{
+	Use:   "ps",
+	Short: "Show models loaded in memory",
+	Long: `Show which models Ollama has loaded, the memory each uses, whether it runs
+on the CPU or GPU, and when it will be unloaded if it stays idle.`,
+	RunE: runModelsPs,
+}
+
+var modelsUnloadCmd = &cobra.Command{
+	Use:   "unload [model...]",
+	Short: "Unload models to free memory",
+	Long: `Unload models from memory. They stay installed and load again on the next
+request.`,
+	Example: `  lc models unload llama3.1:8b
+  lc models unload --all`,
+	RunE: runModelsUnload,
+}
+
+var modelsKeepAliveCmd = &cobra.Command{
+	Use:   "keep-alive [model] [duration]",
+	Short: "Set how long models stay loaded when idle",
+	Long: `Show or set how long each model stays in memory after its last request.
+
+Durations use Ollama's syntax: 10m, 30s, 1h; 0 unloads the model after every
+request and -1 keeps it loaded. The model * sets the default for all others.
+Settings are stored in services.ai.keep_alive.
+
+The default is passed to Ollama as OLLAMA_KEEP_ALIVE when the AI service
+starts. Requests through lc gateway carry each model's own setting, and the
+gateway unloads models that clients calling Ollama directly left loaded
+longer than their setting.`,
+	Example: `  lc models keep-alive
+  lc models keep-alive '*' 10m
+  lc models keep-alive llama3.1:8b 2m
+  lc models keep-alive nomic-embed-text 30s
+  lc models keep-alive llama3.1:8b --unset`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: runModelsKeepAlive,
+}
+
+var (
+	unloadAll      bool
+	keepAliveUnset bool
+)
+
+func init() {
+	modelsUnloadCmd.Flags().BoolVar(&unloadAll, "all", false, "Unload every loaded model")
+	modelsKeepAliveCmd.Flags().BoolVar(&keepAliveUnset, "unset", false, "Remove the model's setting")
+
+	modelsCmd.AddCommand(modelsPsCmd)
+	modelsCmd.AddCommand(modelsUnloadCmd)
+	modelsCmd.AddCommand(modelsKeepAliveCmd)
+}
+
+func runModelsPs(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if err := requireOllama(cfg, "lc models ps"); err != nil {
+		return err
+	}
+
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+	if !manager.IsOllamaAvailable() {
+		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
+	}
+
+	running, err := manager.Running()
+	if err != nil {
+		return fmt.Errorf("failed to list loaded models: %w", err)
+	}
+	if len(running) == 0 {
+		printInfo("No models loaded")
+		return nil
+	}
+
+	policy, err := models.NewKeepAlivePolicy(cfg.Services.AI.KeepAlive)
+	if err != nil {
+		printWarning(err.Error())
+	}
+
+	fmt.Printf("%-30s %-10s %-16s %-20s %s\n", "NAME", "MEMORY", "PROCESSOR", "UNLOADS", "KEEP ALIVE")
+	fmt.Println(strings.Repeat("─", 96))
+	var total int64
+	for _, r := range running {
+		keepAlive := "Ollama default"
+		if d, ok := policy.For(r.Name); ok {
+			keepAlive = models.FormatKeepAlive(d)
+		}
+		fmt.Printf("%-30s %-10s %-16s %-20s %s\n", r.Name, FormatBytes(r.Size), r.Processor(), formatExpiry(r.ExpiresAt), keepAlive)
+		total += r.Size
+	}
+	fmt.Printf("\nTotal: %s in %d model(s). Free memory with: lc models unload <model>\n", FormatBytes(total), len(running))
+	return nil
+}
+
+// formatExpiry describes when an idle model unloads
+func formatExpiry(t time.Time) string {
+	remaining := time.Until(t)
+	switch {
+	case t.IsZero():
+		return "unknown"
+	case remaining > 100*365*24*time.Hour:
+		return "never"
+	case remaining <= 0:
+		return "now"
+	case remaining < time.Minute:
+		return fmt.Sprintf("in %ds", int(remaining.Seconds()))
+	case remaining < time.Hour:
+		return fmt.Sprintf("in %dm %ds", int(remaining.Minutes()), int(remaining.Seconds())%60)
+	default:
+		return fmt.Sprintf("in %dh %dm", int(remaining.Hours()), int(remaining.Minutes())%60)
+	}
+}
+
+func runModelsUnload(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !unloadAll {
+		return fmt.Errorf("name the models to unload or use --all")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if err := requireOllama(cfg, "lc models unload"); err != nil {
+		return err
+	}
+
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+	if !manager.IsOllamaAvailable() {
+		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
+	}
+
+	running, err := manager.Running()
+	if err != nil {
+		return fmt.Errorf("failed to list loaded models: %w", err)
+	}
+
+	names := args
+	if unloadAll {
+		names = nil
+		for _, r := range running {
+			names = append(names, r.Name)
+		}
+		if len(names) == 0 {
+			printInfo("No models loaded")
+			return nil
+		}
+	}
+
+	ctx := context.Background()
+	failed := 0
+	for _, name := range names {
+		loaded := false
+		for _, r := range running {
+			if r.Name == name || r.Model == name || r.Name == name+":latest" {
+				loaded, name = true, r.Name
+				break
+			}
+		}
+		if !loaded {
+			printInfo(fmt.Sprintf("%s is not loaded", name))
+			continue
+		}
+		if err := manager.Unload(ctx, name); err != nil {
+			printError(fmt.Sprintf("%s: %v", name, err))
+			failed++
+			continue
+		}
+		printSuccess(fmt.Sprintf("Unloaded %s", name))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d model(s) could not be unloaded", failed)
+	}
+	return nil
+}
+
+func runModelsKeepAlive(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+
+	// List
+	if len(args) == 0 {
+		if len(cfg.Services.AI.KeepAlive) == 0 {
+			printInfo("No keep-alive settings; Ollama unloads idle models after 5m")
+			return nil
+		}
+		fmt.Printf("%-30s %s\n", "MODEL", "KEEP ALIVE")
+		fmt.Println(strings.Repeat("─", 50))
+		for _, entry := range cfg.Services.AI.KeepAlive {
+			shown := entry.Duration
+			if d, err := models.ParseKeepAlive(entry.Duration); err == nil {
+				shown = models.FormatKeepAlive(d)
+			}
+			fmt.Printf("%-30s %s\n", entry.Model, shown)
+		}
+		return nil
+	}
+
+	model := args[0]
+	entries := cfg.Services.AI.KeepAlive[:0]
+	found := false
+	var message string
+
+	if keepAliveUnset {
+		for _, entry := range cfg.Services.AI.KeepAlive {
+			if strings.EqualFold(entry.Model, model) {
+				found = true
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		if !found {
+			return fmt.Errorf("no keep-alive setting for %s", model)
+		}
+		cfg.Services.AI.KeepAlive = entries
+		message = fmt.Sprintf("Removed keep-alive setting for %s", model)
+	} else {
+		if len(args) < 2 {
+			return fmt.Errorf("give a duration, for example: lc models keep-alive %s 5m", model)
+		}
+		d, err := models.ParseKeepAlive(args[1])
+		if err != nil {
+			return err
+		}
+		for i, entry := range cfg.Services.AI.KeepAlive {
+			if strings.EqualFold(entry.Model, model) {
+				cfg.Services.AI.KeepAlive[i].Duration = args[1]
+				found = true
+			}
+		}
+		if !found {
+			cfg.Services.AI.KeepAlive = append(cfg.Services.AI.KeepAlive, config.ModelKeepAlive{Model: model, Duration: args[1]})
+		}
+		message = fmt.Sprintf("%s: %s", model, models.FormatKeepAlive(d))
+	}
+
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	printSuccess(message)
+	if model == config.KeepAliveDefault {
+		printInfo("Restart the AI service to apply the default: lc restart ai")
+	} else if hostServicePort(gatewayServiceName) > 0 {
+		printInfo("Restart the gateway to apply the change")
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// internal/cli/models_search.go
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/localcloud-sh/localcloud/internal/catalog"
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var modelsSearchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search the model catalog",
+	Long: `Search the catalog of models LocalCloud knows about.
+
+The catalog is built into lc and can be extended with
+~/.localcloud/catalog.yaml: entries there replace built-in models of the same
+name and new entries are added. Every model lists its parameters,
+quantization, download size, memory needed, context length, capabilities and
+license. Results are sorted by memory needed.`,
+	Example: `  lc models search qwen
+  lc models search --capability embedding --max-ram 4GB
+  lc models search --capability code --variants`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runModelsSearch,
+}
+
+var (
+	searchCapability string
+	searchMaxRAM     string
+	searchFamily     string
+	searchVariants   bool
+)
+
+func init() {
+	modelsSearchCmd.Flags().StringVar(&searchCapability, "capability", "", "Only models with a capability: chat, code, tools, vision or embedding")
+	modelsSearchCmd.Flags().StringVar(&searchMaxRAM, "max-ram", "", "Only models that run in this much memory (e.g. 4GB)")
+	modelsSearchCmd.Flags().StringVar(&searchFamily, "family", "", "Only models of a family (e.g. llama, qwen2)")
+	modelsSearchCmd.Flags().BoolVar(&searchVariants, "variants", false, "Also show other quantizations of each model")
+
+	modelsCmd.AddCommand(modelsSearchCmd)
+}
+
+func runModelsSearch(cmd *cobra.Command, args []string) error {
+	cat := catalog.Default()
+	if err := catalog.Err(); err != nil {
+		printWarning(fmt.Sprintf("Using the built-in catalog: %v", err))
+	}
+
+	filter := catalog.Filter{
+		Capability: searchCapability,
+		Family:     searchFamily,
+	}
+	if len(args) > 0 {
+		filter.Query = args[0]
+	}
+	if searchMaxRAM != "" {
+		maxRAM, err := catalog.ParseSize(searchMaxRAM)
+		if err != nil {
+			return fmt.Errorf("invalid --max-ram: %w", err)
+		}
+		filter.MaxRAM = maxRAM
+	}
+
+	results := cat.Search(filter)
+	if len(results) == 0 {
+		printInfo("No models match the search")
+		return nil
+	}
+
+	// Mark installed models when Ollama is reachable
+	var installed []models.Model
+	if cfg := config.Get(); cfg != nil && cfg.Services.AI.Port > 0 {
+		manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+		if manager.IsOllamaAvailable() {
+			installed, _ = manager.List()
+		}
+	}
+
+	fmt.Printf("%-24s %-7s %-8s %-8s %-7s %-8s %-6s %-20s %s\n",
+		"NAME", "PARAMS", "QUANT", "SIZE", "RAM", "CTX", "DIM", "CAPABILITIES", "LICENSE")
+	fmt.Println(strings.Repeat("─", 110))
+	for _, m := range results {
+		name := m.Name
+		if modelInstalled(installed, m.Name) {
+			name += " ✓"
+		}
+		dim := "-"
+		if m.Dimensions > 0 {
+			dim = fmt.Sprintf("%d", m.Dimensions)
+		}
+		fmt.Printf("%-24s %-7s %-8s %-8s %-7s %-8s %-6s %-20s %s\n",
+			name, m.Parameters, m.Quantization, m.Size, m.RAM,
+			formatContext(m.Context), dim, strings.Join(m.Capabilities, ","), m.License)
+
+		if searchVariants {
+			for _, v := range m.Variants {
+				if filter.MaxRAM > 0 && int64(v.RAM) > filter.MaxRAM {
+					continue
+				}
+				fmt.Printf("  └ %-28s %-8s %-8s %s\n", v.Tag, v.Quantization, v.Size, v.RAM)
+			}
+		}
+	}
+
+	fmt.Printf("\nCatalog version %d (%s)\n", cat.Version, strings.Join(cat.Sources, ", "))
+	if len(installed) > 0 {
+		fmt.Println("✓ installed")
+	}
+	fmt.Println("Download a model with: lc models pull <name>")
+	return nil
+}
+
+// formatContext shortens a context length, 131072 becoming 128K
+func formatContext(n int) string {
+	if n <= 0 {
+		return "-"
+	}
+	if n >= 1024 && n%1024 == 0 {
+		return fmt.Sprintf("%dK", n/1024)
+	}
+	return fmt.Sprintf("%d", n)
+}
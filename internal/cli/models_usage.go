@@ -0,0 +1,270 @@
+// internal/cli/models_usage.go
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/logging"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var modelsUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show token usage per model and caller",
+	Long: `Show the requests, tokens, latency and errors of the AI service per model and
+per caller, and what the same tokens would have cost on hosted APIs.
+
+Usage is recorded by lc gateway serve for every request that runs a model,
+through its OpenAI-compatible API or Ollama's native API under /api. Callers
+are identified by their gateway key, else the X-LocalCloud-App header, else
+their client's User-Agent.`,
+	Example: `  lc models usage
+  lc models usage --since 7d
+  lc models usage --since 2026-01-01 --json`,
+	RunE: runModelsUsage,
+}
+
+var (
+	usageSince string
+	usageJSON  bool
+)
+
+func init() {
+	modelsUsageCmd.Flags().StringVar(&usageSince, "since", "24h", "Report usage since a duration ago (1h, 24h, 7d) or a date")
+	modelsUsageCmd.Flags().BoolVar(&usageJSON, "json", false, "Print usage as JSON")
+
+	modelsCmd.AddCommand(modelsUsageCmd)
+}
+
+// usageTotals sums the usage of a model, a caller or everything
+type usageTotals struct {
+	Name             string `json:"name,omitempty"`
+	Requests         int    `json:"requests"`
+	Errors           int    `json:"errors"`
+	Cached           int    `json:"cached"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+	EmbeddingTokens  int    `json:"embedding_tokens"`
+	DurationMs       int64  `json:"duration_ms"`
+	GenerationMs     int64  `json:"generation_ms"`
+}
+
+func (t *usageTotals) add(record logging.UsageRecord) {
+	t.Requests++
+	if !record.Success() {
+		t.Errors++
+	}
+	if record.Cached {
+		t.Cached++
+	}
+	if record.Embedding {
+		t.EmbeddingTokens += record.PromptTokens
+	} else {
+		t.PromptTokens += record.PromptTokens
+		t.CompletionTokens += record.CompletionTokens
+	}
+	t.DurationMs += record.DurationMs
+	t.GenerationMs += record.GenerationMs
+}
+
+// averageLatency returns the mean time to answer a request
+func (t usageTotals) averageLatency() time.Duration {
+	if t.Requests == 0 {
+		return 0
+	}
+	return time.Duration(t.DurationMs/int64(t.Requests)) * time.Millisecond
+}
+
+// tokensPerSecond returns the generation speed, or 0 without completions
+func (t usageTotals) tokensPerSecond() float64 {
+	if t.GenerationMs == 0 {
+		return 0
+	}
+	return float64(t.CompletionTokens) / (float64(t.GenerationMs) / 1000)
+}
+
+// usageEstimate is what the recorded tokens would have cost on a hosted model
+type usageEstimate struct {
+	Model string  `json:"model"`
+	Cost  float64 `json:"cost_usd"`
+}
+
+// usageOutput is the JSON output of lc models usage
+type usageOutput struct {
+	Since     time.Time       `json:"since"`
+	Total     usageTotals     `json:"total"`
+	Models    []usageTotals   `json:"models"`
+	Callers   []usageTotals   `json:"callers"`
+	Estimates []usageEstimate `json:"estimates"`
+}
+
+func runModelsUsage(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	since, err := parseUsageSince(usageSince)
+	if err != nil {
+		return err
+	}
+
+	records, err := logging.LoadUsage(logging.MetricsDir(), since)
+	if err != nil {
+		return fmt.Errorf("failed to read usage: %w", err)
+	}
+
+	out := usageOutput{Since: since}
+	byModel := make(map[string]*usageTotals)
+	byCaller := make(map[string]*usageTotals)
+	for _, record := range records {
+		out.Total.add(record)
+		addUsage(byModel, record.Model, record)
+		addUsage(byCaller, record.Caller, record)
+	}
+	out.Models = sortedUsage(byModel)
+	out.Callers = sortedUsage(byCaller)
+	for _, price := range models.HostedPrices {
+		cost := price.Cost(out.Total.PromptTokens, out.Total.CompletionTokens) +
+			models.HostedEmbeddingPrice.Cost(out.Total.EmbeddingTokens, 0)
+		out.Estimates = append(out.Estimates, usageEstimate{Model: price.Model, Cost: cost})
+	}
+
+	if usageJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(out)
+	}
+
+	if len(records) == 0 {
+		printInfo(fmt.Sprintf("No AI usage recorded since %s", since.Local().Format("2006-01-02 15:04")))
+		fmt.Println("Usage is recorded for requests made through: lc gateway serve")
+		return nil
+	}
+
+	fmt.Printf("AI usage since %s\n\n", since.Local().Format("2006-01-02 15:04"))
+	printUsageTable("MODEL", out.Models, out.Total)
+	fmt.Println()
+	printUsageTable("CALLER", out.Callers, out.Total)
+
+	fmt.Println()
+	fmt.Println("Estimated cost of the same tokens on hosted APIs:")
+	for _, e := range out.Estimates {
+		fmt.Printf("  %-24s %s\n", e.Model, formatUsageCost(e.Cost))
+	}
+	note := "Estimates use list prices, which providers change."
+	if out.Total.EmbeddingTokens > 0 {
+		note = fmt.Sprintf("Embedding tokens are priced as %s. %s", models.HostedEmbeddingPrice.Model, note)
+	}
+	fmt.Printf("  %s\n", infoColor(note))
+	return nil
+}
+
+// addUsage adds a record to the totals of name
+func addUsage(totals map[string]*usageTotals, name string, record logging.UsageRecord) {
+	if name == "" {
+		name = "unknown"
+	}
+	t, ok := totals[name]
+	if !ok {
+		t = &usageTotals{Name: name}
+		totals[name] = t
+	}
+	t.add(record)
+}
+
+// sortedUsage returns totals with the most tokens first
+func sortedUsage(totals map[string]*usageTotals) []usageTotals {
+	list := make([]usageTotals, 0, len(totals))
+	for _, t := range totals {
+		list = append(list, *t)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		ti := list[i].PromptTokens + list[i].CompletionTokens + list[i].EmbeddingTokens
+		tj := list[j].PromptTokens + list[j].CompletionTokens + list[j].EmbeddingTokens
+		if ti != tj {
+			return ti > tj
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// printUsageTable prints one row per model or caller and the total
+func printUsageTable(title string, rows []usageTotals, total usageTotals) {
+	format := "%-28s %-9s %-7s %-7s %-12s %-12s %-12s %-10s %s\n"
+	fmt.Printf(format, title, "REQUESTS", "ERRORS", "CACHED", "PROMPT", "COMPLETION", "EMBEDDING", "LATENCY", "TOK/S")
+	fmt.Println(strings.Repeat("─", 112))
+	row := func(t usageTotals) {
+		speed := "-"
+		if tps := t.tokensPerSecond(); tps > 0 {
+			speed = fmt.Sprintf("%.1f", tps)
+		}
+		fmt.Printf(format,
+			t.Name,
+			fmt.Sprint(t.Requests),
+			fmt.Sprint(t.Errors),
+			fmt.Sprint(t.Cached),
+			formatTokens(t.PromptTokens),
+			formatTokens(t.CompletionTokens),
+			formatTokens(t.EmbeddingTokens),
+			formatBenchDuration(t.averageLatency()),
+			speed,
+		)
+	}
+	for _, t := range rows {
+		row(t)
+	}
+	if len(rows) > 1 {
+		total.Name = "TOTAL"
+		row(total)
+	}
+}
+
+// parseUsageSince reads --since as a duration, a number of days such as 7d,
+// or a date
+func parseUsageSince(value string) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Now().AddDate(0, 0, -n), nil
+		}
+	}
+	since, err := parseSinceTime(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q, use a duration such as 24h or 7d, or a date such as 2026-01-02", value)
+	}
+	return since, nil
+}
+
+// formatTokens groups a token count in thousands, or - for none
+func formatTokens(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	digits := fmt.Sprint(n)
+	var sb strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(d)
+	}
+	return sb.String()
+}
+
+// formatUsageCost formats a cost in USD, keeping small amounts visible
+func formatUsageCost(cost float64) string {
+	if cost > 0 && cost < 0.0001 {
+		return "<$0.0001"
+	}
+	if cost > 0 && cost < 0.01 {
+		return fmt.Sprintf("$%.4f", cost)
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
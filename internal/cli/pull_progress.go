@@ -0,0 +1,184 @@
+// internal/cli/pull_progress.go
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"golang.org/x/term"
+)
+
+// pullDisplay renders the progress of concurrent model pulls as one line per
+// model. On a terminal the lines are redrawn in place; otherwise a line is
+// printed whenever a model's status changes, which keeps CI logs readable.
+type pullDisplay struct {
+	names  []string
+	states map[string]*pullState
+	tty    bool
+	drawn  int
+	last   time.Time
+}
+
+// pullState tracks one model. Ollama reports progress per layer, so the
+// model's progress is the sum over its layers.
+type pullState struct {
+	status  string
+	layers  map[string]models.PullProgress
+	attempt int
+	retry   time.Duration
+	err     error
+	done    bool
+	logged  string
+}
+
+func newPullDisplay(names []string) *pullDisplay {
+	d := &pullDisplay{
+		names:  names,
+		states: make(map[string]*pullState, len(names)),
+		tty:    term.IsTerminal(int(os.Stdout.Fd())),
+	}
+	for _, name := range names {
+		d.states[name] = &pullState{status: "queued", layers: make(map[string]models.PullProgress)}
+	}
+	return d
+}
+
+// Update records an update and redraws
+func (d *pullDisplay) Update(u models.PullUpdate) {
+	state := d.states[u.Model]
+	if state == nil {
+		return
+	}
+
+	important := false
+	switch {
+	case u.Done:
+		state.done = true
+		state.err = u.Err
+		important = true
+	case u.Retrying > 0:
+		state.retry = u.Retrying
+		state.err = u.Err
+		state.status = "retrying"
+		important = true
+	default:
+		if u.Attempt != state.attempt {
+			// A retry restarts the stream; Ollama resends completed layers
+			state.attempt = u.Attempt
+			state.retry = 0
+			state.err = nil
+		}
+		state.status = u.Progress.Status
+		if u.Progress.Digest != "" && u.Progress.Total > 0 {
+			state.layers[u.Progress.Digest] = u.Progress
+		}
+	}
+
+	if d.tty {
+		// Throttle redraws; byte counts arrive many times per second
+		if !important && time.Since(d.last) < 100*time.Millisecond {
+			return
+		}
+		d.redraw()
+		return
+	}
+
+	if line := d.logLine(u.Model, state); line != state.logged {
+		state.logged = line
+		fmt.Println(line)
+	}
+}
+
+// Finish draws the final state
+func (d *pullDisplay) Finish() {
+	if d.tty {
+		d.redraw()
+	}
+}
+
+func (d *pullDisplay) redraw() {
+	d.last = time.Now()
+	if d.drawn > 0 {
+		fmt.Printf("\033[%dA", d.drawn)
+	}
+
+	width := 100
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 20 {
+		width = w - 1
+	}
+
+	for _, name := range d.names {
+		line := d.line(name, d.states[name])
+		if len([]rune(line)) > width {
+			line = string([]rune(line)[:width])
+		}
+		fmt.Printf("\r\033[K%s\n", line)
+	}
+	d.drawn = len(d.names)
+}
+
+// line renders a model's state for the terminal
+func (d *pullDisplay) line(name string, state *pullState) string {
+	switch {
+	case state.done && state.err == nil:
+		return fmt.Sprintf("%s %-30s done", successColor("✓"), name)
+	case state.done:
+		return fmt.Sprintf("%s %-30s %v", errorColor("✗"), name, state.err)
+	case state.retry > 0:
+		return fmt.Sprintf("%s %-30s retrying in %s (attempt %d failed: %v)", warningColor("!"), name, state.retry, state.attempt, state.err)
+	}
+
+	completed, total := state.bytes()
+	if total == 0 {
+		return fmt.Sprintf("  %-30s %s", name, state.status)
+	}
+
+	percentage := int(completed * 100 / total)
+	return fmt.Sprintf("  %-30s [%s] %3d%% %s/%s", name, progressBar(percentage, 24), percentage, FormatBytes(completed), FormatBytes(total))
+}
+
+// logLine renders a model's state for a log, with progress in 25% steps so
+// repeated byte counts do not produce new lines
+func (d *pullDisplay) logLine(name string, state *pullState) string {
+	switch {
+	case state.done && state.err == nil:
+		return fmt.Sprintf("%s: done", name)
+	case state.done:
+		return fmt.Sprintf("%s: failed: %v", name, state.err)
+	case state.retry > 0:
+		return fmt.Sprintf("%s: attempt %d failed (%v), retrying in %s", name, state.attempt, state.err, state.retry)
+	}
+
+	completed, total := state.bytes()
+	if total == 0 {
+		return fmt.Sprintf("%s: %s", name, state.status)
+	}
+	step := int(completed*100/total) / 25 * 25
+	return fmt.Sprintf("%s: downloading %d%% of %s", name, step, FormatBytes(total))
+}
+
+func (s *pullState) bytes() (completed, total int64) {
+	for _, layer := range s.layers {
+		completed += layer.Completed
+		total += layer.Total
+	}
+	return completed, total
+}
+
+// pullSummary returns the names of failed pulls
+func pullSummary(results []models.PullResult) (failed []string) {
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Model)
+		}
+	}
+	return failed
+}
+
+// joinModels formats a list of model names for messages
+func joinModels(names []string) string {
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,398 @@
+// internal/cli/rag.go
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/localcloud-sh/localcloud/internal/rag"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/providers"
+	"github.com/spf13/cobra"
+)
+
+var ragCmd = &cobra.Command{
+	Use:   "rag",
+	Short: "Ingest and query documents for retrieval-augmented generation",
+	Long: `Build a retrieval-augmented generation (RAG) pipeline on top of the
+project's embedding model and vector database.`,
+}
+
+var ragIngestCmd = &cobra.Command{
+	Use:   "ingest <path>",
+	Short: "Chunk, embed and store documents",
+	Long: `Walk a file or directory and ingest Markdown, plain text, HTML, source
+code and PDF files into the vector database.
+
+Files are split into chunks with the selected strategy:
+  auto      Pick a strategy per file type (default)
+  fixed     Fixed-size token windows with overlap
+  markdown  Keep heading sections together
+  code      Keep top-level functions and types together
+
+Chunks are embedded with the configured embedding model and stored with their
+source path and byte offsets. Re-ingestion is incremental: files whose content
+hash and settings are unchanged are skipped.`,
+	Example: `  lc rag ingest ./docs
+  lc rag ingest README.md --strategy fixed --chunk-size 256 --overlap 32
+  lc rag ingest ./src --strategy code --prune
+  lc rag ingest ./docs --model mxbai-embed-large --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRagIngest,
+}
+
+var ragQueryCmd = &cobra.Command{
+	Use:   "query <question>",
+	Short: "Answer a question from ingested documents",
+	Long: `Answer a question using retrieval-augmented generation.
+
+The question is embedded with the configured embedding model and matched
+against ingested chunks with a hybrid (vector + keyword) search. The best
+chunks that fit in the model's context window are sent to the default model,
+and the answer is streamed with numbered citations pointing to the source
+documents and byte offsets.`,
+	Example: `  lc rag query "How do I configure the cache?"
+  lc rag query "What ports are used?" --top-k 4 --show-context
+  lc rag query "Summarize the setup steps" --model llama3.2:3b
+  lc rag query "Which databases are supported?" --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRagQuery,
+}
+
+var (
+	ragStrategy  string
+	ragChunkSize int
+	ragOverlap   int
+	ragModel     string
+	ragBatchSize int
+	ragForce     bool
+	ragPrune     bool
+
+	ragQueryModel          string
+	ragQueryEmbeddingModel string
+	ragQueryTopK           int
+	ragQueryNumCtx         int
+	ragQueryMaxTokens      int
+	ragCollection          string
+	ragJSON                bool
+	ragShowContext         bool
+)
+
+func init() {
+	defaults := rag.DefaultChunkOptions()
+	ragIngestCmd.Flags().StringVar(&ragStrategy, "strategy", string(defaults.Strategy), "Chunking strategy (auto, fixed, markdown, code)")
+	ragIngestCmd.Flags().IntVar(&ragChunkSize, "chunk-size", defaults.ChunkSize, "Maximum tokens per chunk")
+	ragIngestCmd.Flags().IntVar(&ragOverlap, "overlap", defaults.Overlap, "Tokens shared between consecutive chunks")
+	ragIngestCmd.Flags().StringVar(&ragModel, "model", "", "Embedding model (default: configured embedding model)")
+	ragIngestCmd.Flags().IntVar(&ragBatchSize, "batch-size", 32, "Number of chunks embedded per request")
+	ragIngestCmd.Flags().BoolVar(&ragForce, "force", false, "Re-ingest files even if they are unchanged")
+	ragIngestCmd.Flags().BoolVar(&ragPrune, "prune", false, "Remove documents whose files no longer exist")
+
+	ragQueryCmd.Flags().StringVar(&ragQueryModel, "model", "", "Model that answers (default: configured default model)")
+	ragQueryCmd.Flags().StringVar(&ragQueryEmbeddingModel, "embedding-model", "", "Embedding model (default: configured embedding model)")
+	ragQueryCmd.Flags().IntVar(&ragQueryTopK, "top-k", 8, "Number of chunks to retrieve")
+	ragQueryCmd.Flags().IntVar(&ragQueryNumCtx, "num-ctx", 0, "Context window in tokens (default: model's, up to 8192)")
+	ragQueryCmd.Flags().IntVar(&ragQueryMaxTokens, "max-tokens", 1024, "Tokens reserved for the answer")
+	ragQueryCmd.Flags().StringVar(&ragCollection, "collection", "", "Collection to search with qdrant or chroma (default: default)")
+	ragQueryCmd.Flags().BoolVar(&ragJSON, "json", false, "Print the answer and sources as JSON")
+	ragQueryCmd.Flags().BoolVar(&ragShowContext, "show-context", false, "Show the retrieved context sent to the model")
+
+	ragCmd.AddCommand(ragIngestCmd)
+	ragCmd.AddCommand(ragQueryCmd)
+}
+
+func runRagIngest(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if err := requireOllama(cfg, "lc rag ingest"); err != nil {
+		return err
+	}
+
+	strategy, err := rag.ParseStrategy(ragStrategy)
+	if err != nil {
+		return err
+	}
+	if ragChunkSize <= 0 {
+		return fmt.Errorf("chunk size must be positive")
+	}
+	if ragOverlap < 0 || ragOverlap >= ragChunkSize {
+		return fmt.Errorf("overlap must be between 0 and chunk size")
+	}
+
+	modelName := ragModel
+	if modelName == "" {
+		modelName = configuredEmbeddingModel(cfg)
+	}
+	if modelName == "" {
+		return fmt.Errorf("no embedding model configured. Add one with 'lc component add embedding' or use --model")
+	}
+
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+	if !manager.IsOllamaAvailable() {
+		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
+	}
+	if err := ensureModelPulled(manager, modelName); err != nil {
+		return err
+	}
+
+	dimension, err := manager.EmbeddingDimension(modelName)
+	if err != nil {
+		return fmt.Errorf("failed to determine embedding dimension: %w", err)
+	}
+
+	provider := providers.DefaultProvider(cfg)
+	db, err := providers.Open(cfg, &vectordb.Config{Provider: string(provider), EmbeddingDim: dimension})
+	if err != nil {
+		return fmt.Errorf("failed to connect to vector database: %w", err)
+	}
+
+	// A fixed-size vector column rejects every chunk of a model with another
+	// dimension, so check it before embedding anything
+	if enforcer, ok := db.(vectordb.DimensionEnforcer); ok {
+		err := enforcer.EnsureDimension(context.Background(), "default", dimension)
+		if errors.Is(err, vectordb.ErrDimensionMismatch) {
+			return fmt.Errorf("%v\nSwitch the stored documents to %s first with: lc vector reembed --model %s", err, modelName, modelName)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check embedding dimension: %w", err)
+		}
+	}
+
+	// Unchanged chunks are served from the embedding cache
+	embedder := models.NewCachedEmbedder(manager, models.NewEmbeddingCache(models.DefaultEmbeddingCacheDir()))
+
+	ingester, err := rag.NewIngester(embedder, db, rag.IngestOptions{
+		Model:    modelName,
+		Provider: string(provider),
+		Chunk: rag.ChunkOptions{
+			Strategy:  strategy,
+			ChunkSize: ragChunkSize,
+			Overlap:   ragOverlap,
+		},
+		BatchSize: ragBatchSize,
+		Force:     ragForce,
+		Prune:     ragPrune,
+	})
+	if err != nil {
+		return err
+	}
+
+	ingester.OnFile = func(docID, status string, chunks int) {
+		switch status {
+		case "ingested":
+			fmt.Printf("  %s %s (%d chunks)\n", successColor("✓"), docID, chunks)
+		case "failed":
+			fmt.Printf("  %s %s\n", errorColor("✗"), docID)
+		case "removed":
+			fmt.Printf("  %s %s (removed)\n", warningColor("-"), docID)
+		default:
+			if verbose {
+				fmt.Printf("  %s %s (unchanged)\n", infoColor("•"), docID)
+			}
+		}
+	}
+
+	fmt.Printf("Ingesting %s with %s\n", args[0], modelName)
+	result, err := ingester.Ingest(context.Background(), args[0])
+	if flushErr := embedder.Flush(); flushErr != nil {
+		printWarning(fmt.Sprintf("Failed to save embedding cache: %v", flushErr))
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(result.Errors) > 0 {
+		docIDs := make([]string, 0, len(result.Errors))
+		for docID := range result.Errors {
+			docIDs = append(docIDs, docID)
+		}
+		sort.Strings(docIDs)
+		for _, docID := range docIDs {
+			printWarning(fmt.Sprintf("%s: %v", docID, result.Errors[docID]))
+		}
+	}
+
+	summary := fmt.Sprintf("Ingested %d file(s), %d chunk(s); %d unchanged", result.Ingested, result.Chunks, result.Skipped)
+	if result.Removed > 0 {
+		summary += fmt.Sprintf(", %d removed", result.Removed)
+	}
+	if result.Failed > 0 {
+		summary += fmt.Sprintf(", %d failed", result.Failed)
+	}
+	printSuccess(summary)
+	if embedder.Hits+embedder.Misses > 0 {
+		printInfo(fmt.Sprintf("Embedding cache: %d hits, %d misses (%.0f%% hit rate)", embedder.Hits, embedder.Misses, embedder.HitRate()*100))
+	}
+
+	return nil
+}
+
+// ragQueryResult is the --json output of lc rag query
+type ragQueryResult struct {
+	Question       string       `json:"question"`
+	Model          string       `json:"model"`
+	EmbeddingModel string       `json:"embedding_model"`
+	Answer         string       `json:"answer"`
+	Sources        []rag.Source `json:"sources"`
+	Context        string       `json:"context,omitempty"`
+}
+
+func runRagQuery(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if err := requireOllama(cfg, "lc rag query"); err != nil {
+		return err
+	}
+
+	question := strings.TrimSpace(args[0])
+	if question == "" {
+		return fmt.Errorf("question cannot be empty")
+	}
+	if ragQueryTopK <= 0 {
+		return fmt.Errorf("top-k must be positive")
+	}
+
+	chatModel := ragQueryModel
+	if chatModel == "" {
+		chatModel = cfg.Services.AI.Default
+	}
+	if chatModel == "" {
+		return fmt.Errorf("no default model configured. Set services.ai.default or use --model")
+	}
+
+	embeddingModel := ragQueryEmbeddingModel
+	if embeddingModel == "" {
+		embeddingModel = configuredEmbeddingModel(cfg)
+	}
+	if embeddingModel == "" {
+		return fmt.Errorf("no embedding model configured. Add one with 'lc component add embedding' or use --embedding-model")
+	}
+
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+	if !manager.IsOllamaAvailable() {
+		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
+	}
+
+	db, err := providers.Open(cfg, &vectordb.Config{Collection: ragCollection})
+	if err != nil {
+		return fmt.Errorf("failed to connect to vector database: %w", err)
+	}
+
+	results, err := rag.Retrieve(context.Background(), manager, db, embeddingModel, question, ragQueryTopK)
+	if err != nil {
+		return err
+	}
+
+	// Fit the retrieved chunks into the model's context window
+	window := ragQueryNumCtx
+	if window <= 0 {
+		window, _ = manager.ContextLength(chatModel)
+		if window <= 0 {
+			window = 4096
+		}
+		if window > 8192 {
+			window = 8192
+		}
+	}
+	if ragQueryMaxTokens >= window {
+		return fmt.Errorf("max tokens (%d) must be smaller than the context window (%d)", ragQueryMaxTokens, window)
+	}
+	sources := rag.SelectSources(results, rag.ContextBudget(window, ragQueryMaxTokens, question))
+
+	options := map[string]interface{}{
+		"num_ctx":     window,
+		"num_predict": ragQueryMaxTokens,
+	}
+	messages := rag.BuildMessages(question, sources)
+
+	if ragJSON {
+		answer := ""
+		if len(sources) > 0 {
+			answer, err = manager.Chat(chatModel, messages, options, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		result := ragQueryResult{
+			Question:       question,
+			Model:          chatModel,
+			EmbeddingModel: embeddingModel,
+			Answer:         answer,
+			Sources:        sources,
+		}
+		if result.Sources == nil {
+			result.Sources = []rag.Source{}
+		}
+		if ragShowContext {
+			result.Context = rag.FormatContext(sources)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	if ragShowContext {
+		fmt.Printf("%s (%d of %d chunks, %d-token window)\n", infoColor("Context"), len(sources), len(results), window)
+		for _, s := range sources {
+			fmt.Printf("\n%s %s (score %.3f)\n", successColor(fmt.Sprintf("[%d]", s.Number)), s.Location(), s.Score)
+			fmt.Println(strings.TrimSpace(s.Content))
+		}
+		fmt.Println()
+		fmt.Println(strings.Repeat("─", 60))
+		fmt.Println()
+	}
+
+	if len(sources) == 0 {
+		printWarning("No matching documents found. Ingest some with: lc rag ingest <path>")
+		return nil
+	}
+
+	if _, err := manager.Chat(chatModel, messages, options, func(token string) {
+		fmt.Print(token)
+	}); err != nil {
+		fmt.Println()
+		return err
+	}
+	fmt.Println()
+
+	fmt.Println()
+	fmt.Println("Sources:")
+	for _, s := range sources {
+		fmt.Printf("  [%d] %s\n", s.Number, s.Location())
+	}
+
+	return nil
+}
+
+// configuredEmbeddingModel returns the embedding model from the project
+// configuration, or "" if none is configured
+func configuredEmbeddingModel(cfg *config.Config) string {
+	for _, model := range cfg.Services.AI.Models {
+		if models.IsEmbeddingModel(model) {
+			return model
+		}
+	}
+	return ""
+}
@@ -98,6 +98,12 @@ func init() {
 	rootCmd.AddCommand(logsCmd)
 	rootCmd.AddCommand(psCmd)
 	rootCmd.AddCommand(modelsCmd)
+	rootCmd.AddCommand(vectorCmd)
+	rootCmd.AddCommand(ragCmd)
+	rootCmd.AddCommand(chatCmd)
+	rootCmd.AddCommand(transcribeCmd)
+	rootCmd.AddCommand(evalCmd)
+	rootCmd.AddCommand(gatewayCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(storageCmd)
 	rootCmd.AddCommand(infoCmd)
@@ -12,6 +12,7 @@ import (
 	"github.com/localcloud-sh/localcloud/internal/services"
 	"github.com/localcloud-sh/localcloud/internal/templates"
 	"github.com/spf13/cobra"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -1016,3 +1017,71 @@ func runServicesList(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// registerHostService records a service that runs on the host rather than in a
+// container (such as lc vector serve) so lc info and tunnels can find it. The
+// returned function removes the registration.
+func registerHostService(name string, port int, serviceType string, metadata map[string]interface{}) func() {
+	registry := services.NewServiceRegistry(".")
+
+	// A previous run that was killed may have left its entry behind
+	registry.Unregister(name)
+
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata["pid"] = os.Getpid()
+
+	if err := registry.Register(services.Service{
+		Name:      name,
+		Port:      port,
+		URL:       fmt.Sprintf("http://localhost:%d", port),
+		Status:    "running",
+		StartedAt: time.Now(),
+		Type:      serviceType,
+		Metadata:  metadata,
+	}); err != nil {
+		printWarning(fmt.Sprintf("Failed to register service: %v", err))
+	}
+
+	return func() {
+		registry.Unregister(name)
+	}
+}
+
+// hostServicePort returns the port of a running host service, or 0
+func hostServicePort(name string) int {
+	service, err := services.NewServiceRegistry(".").Get(name)
+	if err != nil {
+		return 0
+	}
+
+	// The entry outlives a killed process, so check the port is actually served
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", service.Port), time.Second)
+	if err != nil {
+		return 0
+	}
+	conn.Close()
+
+	return service.Port
+}
+
+// registerHostAI records the host-installed Ollama used with
+// services.ai.backend: host. Unlike registerHostService the entry is not tied
+// to this process, since Ollama keeps running after lc exits.
+func registerHostAI(port int) {
+	registry := services.NewServiceRegistry(".")
+	registry.Unregister("ai")
+
+	if err := registry.Register(services.Service{
+		Name:      "ai",
+		Port:      port,
+		URL:       fmt.Sprintf("http://localhost:%d", port),
+		Status:    "running",
+		StartedAt: time.Now(),
+		Type:      "ai",
+		Metadata:  map[string]interface{}{"backend": config.AIBackendHost},
+	}); err != nil {
+		printWarning(fmt.Sprintf("Failed to register service: %v", err))
+	}
+}
@@ -62,6 +62,7 @@ func init() {
 	// Non-interactive flags for code assistants
 	setupCmd.Flags().StringSliceVar(&setupComponents, "components", []string{}, "Components to configure (llm,database,cache,storage,etc)")
 	setupCmd.Flags().StringSliceVar(&setupModels, "models", []string{}, "AI models to download (llama3.2:3b,nomic-embed-text)")
+	setupCmd.Flags().IntVar(&modelContext, "context", 4096, "Context length in tokens the language model should support")
 	setupCmd.Flags().StringVar(&setupPreset, "preset", "", "Preset configuration (ai-dev,full-stack,minimal)")
 	setupCmd.Flags().BoolVarP(&setupYes, "yes", "y", false, "Accept all defaults (non-interactive mode)")
 }
@@ -247,6 +248,14 @@ func getConfiguredComponents(cfg *config.Config) []string {
 		components = append(components, "mongodb")
 	}
 
+	if cfg.Services.Qdrant.Type != "" {
+		components = append(components, "qdrant")
+	}
+
+	if cfg.Services.Chroma.Type != "" {
+		components = append(components, "chroma")
+	}
+
 	if cfg.Services.Storage.Type != "" {
 		components = append(components, "storage")
 	}
@@ -298,7 +307,7 @@ func runFullSetup(cfg *config.Config) error {
 // runModificationSetup allows adding/removing components from existing setup
 func runModificationSetup(cfg *config.Config, existingComponents []string) error {
 	// Create component options with existing ones pre-selected
-	allComponents := []string{"llm", "embedding", "database", "vector", "mongodb", "cache", "queue", "storage", "stt"}
+	allComponents := []string{"llm", "embedding", "database", "vector", "qdrant", "chroma", "mongodb", "cache", "queue", "storage", "stt"}
 
 	var options []string
 	var defaults []string
@@ -385,6 +394,12 @@ func runModificationSetup(cfg *config.Config, existingComponents []string) error
 		selectedModels := make(map[string]string)
 		manager := models.NewManager("http://localhost:11434")
 
+		// Models are sized against every component the project will have
+		planned := append([]string{}, cfg.Project.Components...)
+		for _, compID := range added {
+			planned = appendUnique(planned, compID)
+		}
+
 		for _, compID := range added {
 			if compID == "llm" || compID == "embedding" || compID == "stt" {
 				comp, err := components.GetComponent(compID)
@@ -396,7 +411,7 @@ func runModificationSetup(cfg *config.Config, existingComponents []string) error
 				if compID == "embedding" {
 					model, err = selectEmbeddingModel(manager)
 				} else {
-					model, err = selectComponentModel(comp, manager)
+					model, err = selectComponentModel(comp, manager, planned)
 				}
 
 				if err != nil {
@@ -549,6 +564,8 @@ func initializeProject(projectName, projectDir string, createDir bool) error {
 		gitignoreContent := `.localcloud/data/
 .localcloud/logs/
 .localcloud/tunnels/
+.localcloud/cache/
+.localcloud/*.pid
 .env.local
 *.log
 `
@@ -725,17 +742,21 @@ func updateCompleteConfig(cfg *config.Config, componentIDs []string) {
 
 	// Store AI models if they exist (to preserve them when updating AI services)
 	var existingAIModels []string
-	var existingAIDefault string
+	var existingAIDefault, existingAIBackend string
 	if cfg.Services.AI.Port > 0 {
 		existingAIModels = cfg.Services.AI.Models
 		existingAIDefault = cfg.Services.AI.Default
+		existingAIBackend = cfg.Services.AI.Backend
 	}
+	existingWhisper := cfg.Services.Whisper
 
 	// Clear ALL service configurations - start fresh
 	cfg.Services = config.ServicesConfig{
 		AI:       config.AIConfig{},
 		Database: config.DatabaseConfig{},
 		MongoDB:  config.MongoDBConfig{},
+		Qdrant:   config.QdrantConfig{},
+		Chroma:   config.ChromaConfig{},
 		Cache:    config.CacheConfig{},
 		Queue:    config.QueueConfig{},
 		Storage:  config.StorageConfig{},
@@ -752,6 +773,7 @@ func updateCompleteConfig(cfg *config.Config, componentIDs []string) {
 					Port:    11434,
 					Models:  []string{},
 					Default: "",
+					Backend: existingAIBackend,
 				}
 			}
 			// Restore existing models if they match the component type
@@ -812,6 +834,21 @@ func updateCompleteConfig(cfg *config.Config, componentIDs []string) {
 				AuthEnabled: true,
 			}
 
+		case "qdrant":
+			cfg.Services.Qdrant = config.QdrantConfig{
+				Type:     "qdrant",
+				Version:  "latest",
+				Port:     6333,
+				GRPCPort: 6334,
+			}
+
+		case "chroma":
+			cfg.Services.Chroma = config.ChromaConfig{
+				Type:    "chroma",
+				Version: "0.5.23",
+				Port:    8000,
+			}
+
 		case "cache":
 			cfg.Services.Cache = config.CacheConfig{
 				Type:            "redis",
@@ -838,6 +875,17 @@ func updateCompleteConfig(cfg *config.Config, componentIDs []string) {
 				Port:    9000,
 				Console: 9001,
 			}
+
+		case "stt":
+			// Keep the selected Whisper model
+			cfg.Services.Whisper = existingWhisper
+			if cfg.Services.Whisper.Type == "" {
+				cfg.Services.Whisper = config.WhisperConfig{
+					Type:  "whisper",
+					Port:  9100,
+					Model: components.DefaultWhisperModel(),
+				}
+			}
 		}
 	}
 }
@@ -914,6 +962,19 @@ func runNonInteractiveSetup(cmd *cobra.Command, projectName string) error {
 			cfg.Services.AI.Default = setupModels[0]
 		}
 		fmt.Printf("%s Configured models: %s\n", successColor("✓"), strings.Join(setupModels, ", "))
+		for _, model := range setupModels {
+			warnModelFit(model, setupComponentIDs())
+		}
+	}
+
+	// Without an explicit language model, use the one that fits this machine
+	if contains(setupComponentIDs(), "llm") && !hasLanguageModel(setupModels) {
+		if plan := planLLM(setupComponentIDs()); plan != nil {
+			cfg.Services.AI.Models = append(cfg.Services.AI.Models, plan.Tag)
+			cfg.Services.AI.Default = plan.Tag
+			setupModels = append(setupModels, plan.Tag)
+			printModelPlan(plan)
+		}
 	}
 
 	// Save configuration
@@ -932,18 +993,40 @@ func runNonInteractiveSetup(cmd *cobra.Command, projectName string) error {
 	return nil
 }
 
+// setupComponentIDs returns the components chosen with --preset and
+// --components
+func setupComponentIDs() []string {
+	ids := append([]string{}, setupPresets[setupPreset]...)
+	for _, id := range setupComponents {
+		ids = appendUnique(ids, id)
+	}
+	return ids
+}
+
+// hasLanguageModel reports whether any of the models is not an embedding model
+func hasLanguageModel(names []string) bool {
+	for _, name := range names {
+		if !models.IsEmbeddingModel(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// setupPresets are the component sets of --preset
+var setupPresets = map[string][]string{
+	"ai-dev":     {"llm", "embedding", "database", "vector"},
+	"full-stack": {"llm", "embedding", "database", "vector", "cache", "queue", "storage"},
+	"minimal":    {"llm"},
+}
+
 // applyPreset applies a preset configuration
 func applyPreset(cfg *config.Config, preset string) error {
-	switch preset {
-	case "ai-dev":
-		return configureComponents(cfg, []string{"llm", "embedding", "database", "vector"})
-	case "full-stack":
-		return configureComponents(cfg, []string{"llm", "embedding", "database", "vector", "cache", "queue", "storage"})
-	case "minimal":
-		return configureComponents(cfg, []string{"llm"})
-	default:
+	componentIDs, ok := setupPresets[preset]
+	if !ok {
 		return fmt.Errorf("unknown preset: %s. Available presets: ai-dev, full-stack, minimal", preset)
 	}
+	return configureComponents(cfg, componentIDs)
 }
 
 // configureComponents configures the specified components
@@ -993,8 +1076,27 @@ func configureComponents(cfg *config.Config, componentList []string) error {
 				Port:        27017,
 				AuthEnabled: true,
 			}
+		case "qdrant":
+			cfg.Services.Qdrant = config.QdrantConfig{
+				Type:     "qdrant",
+				Version:  "latest",
+				Port:     6333,
+				GRPCPort: 6334,
+			}
+		case "chroma":
+			cfg.Services.Chroma = config.ChromaConfig{
+				Type:    "chroma",
+				Version: "0.5.23",
+				Port:    8000,
+			}
+		case "stt":
+			cfg.Services.Whisper = config.WhisperConfig{
+				Type:  "whisper",
+				Port:  9100,
+				Model: components.DefaultWhisperModel(),
+			}
 		default:
-			return fmt.Errorf("unknown component: %s. Available: llm, embedding, database, vector, cache, queue, storage, mongodb", comp)
+			return fmt.Errorf("unknown component: %s. Available: llm, embedding, database, vector, qdrant, chroma, cache, queue, storage, mongodb, stt", comp)
 		}
 	}
 	return nil
@@ -80,6 +80,8 @@ func runStart(cmd *cobra.Command, args []string) error {
 		fmt.Println("  • llm        - Large language models for text generation")
 		fmt.Println("  • embedding  - Text embeddings for semantic search")
 		fmt.Println("  • vector     - Vector database (PostgreSQL + pgvector)")
+		fmt.Println("  • qdrant     - Vector database (Qdrant)")
+		fmt.Println("  • chroma     - Vector database (Chroma)")
 		fmt.Println("  • cache      - Redis cache for performance")
 		fmt.Println("  • queue      - Redis queue for job processing")
 		fmt.Println("  • storage    - Object storage (MinIO S3-compatible)")
@@ -207,6 +209,11 @@ finished:
 		return err
 	}
 
+	// A host Ollama is not a container, so record its endpoint for discovery
+	if startedServices["ai"] && cfg.Services.AI.HostBackend() && cfg.Services.AI.Mode != config.AIModeMock {
+		registerHostAI(cfg.Services.AI.Port)
+	}
+
 	// Print success message
 	fmt.Println()
 	if hasErrors {
@@ -214,6 +221,11 @@ finished:
 	} else {
 		printSuccess("Service startup complete!")
 
+		// Build model profiles that are new or changed since the last start
+		if startedServices["ai"] && len(cfg.Services.AI.Profiles) > 0 {
+			syncModelProfiles(cfg)
+		}
+
 		// Show component-specific information
 		if showInfo {
 			showStartedServicesInfo(cfg, startedServices)
@@ -373,6 +385,26 @@ func showStartedServicesInfo(cfg *config.Config, startedServices map[string]bool
 			fmt.Println("      --eval \"use localcloud; db.users.find().pretty()\"")
 			fmt.Println()
 
+		case "qdrant":
+			fmt.Println("✓ Vector Database (Qdrant)")
+			fmt.Printf("  REST API: http://localhost:%d\n", cfg.Services.Qdrant.Port)
+			fmt.Printf("  Dashboard: http://localhost:%d/dashboard\n", cfg.Services.Qdrant.Port)
+			fmt.Println("  Try:")
+			fmt.Printf("    curl -X PUT http://localhost:%d/collections/documents \\\n", cfg.Services.Qdrant.Port)
+			fmt.Println(`      -H 'Content-Type: application/json' -d '{"vectors":{"size":768,"distance":"Cosine"}}'`)
+			fmt.Printf("    curl http://localhost:%d/collections\n", cfg.Services.Qdrant.Port)
+			fmt.Println()
+
+		case "chroma":
+			fmt.Println("✓ Vector Database (Chroma)")
+			fmt.Printf("  HTTP API: http://localhost:%d\n", cfg.Services.Chroma.Port)
+			fmt.Println("  Try:")
+			fmt.Printf("    curl http://localhost:%d/api/v1/heartbeat\n", cfg.Services.Chroma.Port)
+			fmt.Printf("    curl http://localhost:%d/api/v1/collections\n", cfg.Services.Chroma.Port)
+			fmt.Println("  Python:")
+			fmt.Printf("    chromadb.HttpClient(host=\"localhost\", port=%d)\n", cfg.Services.Chroma.Port)
+			fmt.Println()
+
 		case "cache":
 			PrintRedisCacheInfo(cfg.Services.Cache.Port)
 
@@ -386,10 +418,14 @@ func showStartedServicesInfo(cfg *config.Config, startedServices map[string]bool
 			fmt.Println("  Credentials: see ~/.localcloud/minio-credentials")
 			fmt.Println()
 
-			//case "stt":
-			//	// Whisper info
-			//	fmt.Printf("✓ Speech-to-Text (Whisper)\n")
-			//	fmt.Printf("  URL: http://localhost:%d\n", cfg.Services.Whisper.Port)
+		case "stt":
+			fmt.Println("✓ Speech-to-Text (Whisper)")
+			fmt.Printf("  URL: http://localhost:%d\n", cfg.Services.Whisper.Port)
+			fmt.Printf("  Model: %s\n", cfg.Services.Whisper.Model)
+			fmt.Println("  Try:")
+			fmt.Println("    lc transcribe recording.mp3 --format srt")
+			fmt.Printf("    curl -F audio_file=@recording.mp3 \"http://localhost:%d/asr?output=json\"\n", cfg.Services.Whisper.Port)
+			fmt.Println()
 		}
 	}
 }
@@ -470,6 +506,17 @@ func showConnectionInfo(cfg *config.Config) {
 				}
 			}
 
+		case "qdrant":
+			if cfg.Services.Qdrant.Type != "" {
+				fmt.Printf("✓ Qdrant: http://localhost:%d\n", cfg.Services.Qdrant.Port)
+				fmt.Printf("  - gRPC: localhost:%d\n", cfg.Services.Qdrant.GRPCPort)
+			}
+
+		case "chroma":
+			if cfg.Services.Chroma.Type != "" {
+				fmt.Printf("✓ Chroma: http://localhost:%d\n", cfg.Services.Chroma.Port)
+			}
+
 		case "cache":
 			if cfg.Services.Cache.Type != "" {
 				fmt.Printf("✓ Redis Cache: redis://localhost:%d\n", cfg.Services.Cache.Port)
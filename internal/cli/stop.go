@@ -9,6 +9,7 @@ import (
 	"github.com/briandowns/spinner"
 	"github.com/localcloud-sh/localcloud/internal/config"
 	"github.com/localcloud-sh/localcloud/internal/docker"
+	"github.com/localcloud-sh/localcloud/internal/services"
 	"github.com/spf13/cobra"
 )
 
@@ -134,6 +135,13 @@ finished:
 		return err
 	}
 
+	// LocalCloud does not stop a host Ollama, it only forgets the endpoint
+	if cfg.Services.AI.HostBackend() {
+		if services.NewServiceRegistry(".").Unregister("ai") == nil {
+			printInfo("Host Ollama left running (services.ai.backend: host)")
+		}
+	}
+
 	fmt.Println()
 	printSuccess("All services stopped successfully!")
 
@@ -0,0 +1,119 @@
+// internal/cli/transcribe.go
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/services/whisper"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var transcribeCmd = &cobra.Command{
+	Use:   "transcribe <audio-file>",
+	Short: "Transcribe an audio file with the speech-to-text service",
+	Long: `Transcribe an audio or video file with the project's Whisper speech-to-text
+service and print the transcript, or write it to a file with --output.
+
+Formats:
+  text  Plain text (default)
+  srt   SubRip subtitles
+  vtt   WebVTT subtitles
+  json  Whisper's segments with timestamps, and the detected language
+
+The spoken language is detected unless --language is given. Requires the stt
+component: lc component add stt`,
+	Example: `  lc transcribe meeting.mp3
+  lc transcribe talk.mp4 --format srt --output talk.srt
+  lc transcribe interview.wav --language de --format vtt
+  lc transcribe podcast.m4a --translate`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTranscribe,
+}
+
+var (
+	transcribeFormat    string
+	transcribeLanguage  string
+	transcribeTranslate bool
+	transcribePrompt    string
+	transcribeOutput    string
+)
+
+func init() {
+	transcribeCmd.Flags().StringVar(&transcribeFormat, "format", whisper.FormatText, "Transcript format ("+strings.Join(whisper.Formats, ", ")+")")
+	transcribeCmd.Flags().StringVar(&transcribeLanguage, "language", "", "Spoken language code, e.g. en (default: detected)")
+	transcribeCmd.Flags().BoolVar(&transcribeTranslate, "translate", false, "Translate the speech to English")
+	transcribeCmd.Flags().StringVar(&transcribePrompt, "prompt", "", "Text that guides spelling and style, such as names and terms")
+	transcribeCmd.Flags().StringVarP(&transcribeOutput, "output", "o", "", "File to write the transcript to (default: stdout)")
+}
+
+func runTranscribe(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if cfg.Services.Whisper.Type == "" || cfg.Services.Whisper.Port == 0 {
+		return fmt.Errorf("speech-to-text is not enabled. Add it with: lc component add stt")
+	}
+
+	path := args[0]
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("cannot read audio file: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory, not an audio file", path)
+	}
+
+	opts := whisper.TranscribeOptions{
+		Format:    transcribeFormat,
+		Language:  transcribeLanguage,
+		Translate: transcribeTranslate,
+		Prompt:    transcribePrompt,
+	}
+
+	// The spinner goes to stderr so the transcript can be piped
+	var spin *spinner.Spinner
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		spin = spinner.New(spinner.CharSets[14], 100*time.Millisecond, spinner.WithWriter(os.Stderr))
+		spin.Suffix = fmt.Sprintf(" Transcribing %s (%s) with whisper %s...", path, FormatBytes(info.Size()), cfg.Services.Whisper.Model)
+		spin.Start()
+	}
+
+	client := whisper.NewClient(fmt.Sprintf("http://localhost:%d", cfg.Services.Whisper.Port))
+	start := time.Now()
+	transcript, err := client.TranscribeFile(cmd.Context(), path, opts)
+	if spin != nil {
+		spin.Stop()
+	}
+	if errors.Is(err, whisper.ErrUnavailable) {
+		return fmt.Errorf("speech-to-text service is not running on port %d. Start it with: lc start stt", cfg.Services.Whisper.Port)
+	}
+	if err != nil {
+		return err
+	}
+
+	if transcribeOutput == "" {
+		os.Stdout.Write(transcript)
+		if len(transcript) > 0 && transcript[len(transcript)-1] != '\n' {
+			fmt.Println()
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(transcribeOutput, transcript, 0644); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+	printSuccess(fmt.Sprintf("Transcribed %s in %s: %s", path, formatBenchDuration(time.Since(start)), transcribeOutput))
+	return nil
+}
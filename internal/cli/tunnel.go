@@ -464,12 +464,25 @@ func determineServicesToTunnel(cfg *config.Config) map[string]int {
 		for name, port := range detected {
 			services[name] = port
 		}
-		printInfo(fmt.Sprintf("Auto-detected %d running services", len(detected)))
+		if port := vectorAPIPort(); port > 0 {
+			services["vector"] = port
+		}
+		if port := hostServicePort(gatewayServiceName); port > 0 {
+			services["gateway"] = port
+		}
+		printInfo(fmt.Sprintf("Auto-detected %d running services", len(services)))
 	}
 
 	// If --all is specified, detect all running services
 	if tunnelAll {
-		return network.DetectRunningServices(cfg)
+		all := network.DetectRunningServices(cfg)
+		if port := vectorAPIPort(); port > 0 {
+			all["vector"] = port
+		}
+		if port := hostServicePort(gatewayServiceName); port > 0 {
+			all["gateway"] = port
+		}
+		return all
 	}
 
 	// Add services based on component flags
@@ -0,0 +1,695 @@
+// internal/cli/vector.go
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/api"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/providers"
+	"github.com/spf13/cobra"
+)
+
+var vectorCmd = &cobra.Command{
+	Use:     "vector",
+	Short:   "Manage the vector database",
+	Aliases: []string{"vectors"},
+	Long: `Manage stored embeddings in the project's vector database.
+
+The vector database is pgvector, Qdrant or Chroma depending on which
+component is enabled.`,
+}
+
+var vectorReembedCmd = &cobra.Command{
+	Use:   "reembed",
+	Short: "Re-embed stored content with a different embedding model",
+	Long: `Re-embed all stored content with a new embedding model.
+
+The model is pulled if needed, then every collection of every configured
+vector store is copied into a shadow collection sized for the new model's
+dimension. Content is embedded in batches
+through Ollama and progress is saved, so an interrupted run resumes where it
+stopped. Once a collection is complete, the shadow replaces the original.
+The configuration switches to the new model only after every store is done.`,
+	Example: `  lc vector reembed --model mxbai-embed-large
+  lc vector reembed --model all-minilm --collection documents
+  lc vector reembed --model nomic-embed-text --batch-size 64`,
+	RunE: runVectorReembed,
+}
+
+var vectorServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the vector database over a REST API",
+	Long: `Start an HTTP service that exposes the vector database as JSON endpoints
+for applications that cannot use the Go providers.
+
+Endpoints cover upserting documents and chunks, similarity and hybrid search,
+deleting documents, managing collections and statistics. The OpenAPI document
+is served at /openapi.json. Requests may send text instead of vectors; it is
+embedded with the configured embedding model.
+
+Browsers are refused unless their origin is allowed with --cors-origin, and
+listening on an address other than localhost requires an API key.
+
+The service is registered with the project, so 'lc info' lists it and
+'lc tunnel start --all' exposes it.`,
+	Example: `  lc vector serve
+  lc vector serve --port 9000 --api-key secret
+  lc vector serve --cors-origin http://localhost:3000
+  lc vector serve --host 0.0.0.0 --api-key secret --embedding-model mxbai-embed-large`,
+	RunE: runVectorServe,
+}
+
+var vectorCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Show embedding cache statistics",
+	Long: `Show the embedding cache used by 'lc rag ingest' and 'lc vector reembed'.
+
+Embeddings are cached per model name and digest, keyed by a hash of the
+content, so re-ingesting unchanged text does not call the model again. When a
+model is updated its digest changes and the old entries are no longer used.`,
+	RunE: runVectorCacheStats,
+}
+
+var vectorCachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove stale embedding cache entries",
+	Long: `Remove embedding cache entries for models that are no longer installed or
+whose digest changed, and optionally entries that have not been used recently.`,
+	Example: `  lc vector cache prune
+  lc vector cache prune --older-than 720h
+  lc vector cache prune --all`,
+	RunE: runVectorCachePrune,
+}
+
+var (
+	cachePruneOlderThan time.Duration
+	cachePruneAll       bool
+)
+
+// vectorAPIServiceName is the name lc vector serve registers under
+const vectorAPIServiceName = "vector-api"
+
+var (
+	vectorServeHost           string
+	vectorServePort           int
+	vectorServeAPIKey         string
+	vectorServeEmbeddingModel string
+	vectorServeCORSOrigins    []string
+)
+
+var (
+	reembedModel      string
+	reembedCollection string
+	reembedBatchSize  int
+)
+
+func init() {
+	vectorReembedCmd.Flags().StringVar(&reembedModel, "model", "", "Embedding model to switch to (required)")
+	vectorReembedCmd.Flags().StringVar(&reembedCollection, "collection", "", "Re-embed a single collection (default: all collections)")
+	vectorReembedCmd.Flags().IntVar(&reembedBatchSize, "batch-size", 32, "Number of chunks embedded per request")
+	vectorReembedCmd.MarkFlagRequired("model")
+
+	vectorServeCmd.Flags().StringVar(&vectorServeHost, "host", "localhost", "Address to listen on")
+	vectorServeCmd.Flags().IntVar(&vectorServePort, "port", api.DefaultPort, "Port to listen on")
+	vectorServeCmd.Flags().StringVar(&vectorServeAPIKey, "api-key", os.Getenv("LOCALCLOUD_VECTOR_API_KEY"), "Require this key on API requests (env: LOCALCLOUD_VECTOR_API_KEY)")
+	vectorServeCmd.Flags().StringVar(&vectorServeEmbeddingModel, "embedding-model", "", "Model for embedding text requests (default: configured embedding model)")
+	vectorServeCmd.Flags().StringSliceVar(&vectorServeCORSOrigins, "cors-origin", nil, "Browser origin allowed to call the API, e.g. http://localhost:3000 (repeatable; default: none)")
+
+	vectorCachePruneCmd.Flags().DurationVar(&cachePruneOlderThan, "older-than", 0, "Also remove entries unused for this long (e.g. 720h)")
+	vectorCachePruneCmd.Flags().BoolVar(&cachePruneAll, "all", false, "Remove the entire cache")
+	vectorCacheCmd.AddCommand(vectorCachePruneCmd)
+
+	vectorCmd.AddCommand(vectorReembedCmd)
+	vectorCmd.AddCommand(vectorServeCmd)
+	vectorCmd.AddCommand(vectorCacheCmd)
+}
+
+func runVectorCacheStats(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	stats, err := models.NewEmbeddingCache(models.DefaultEmbeddingCacheDir()).Stats()
+	if err != nil {
+		return fmt.Errorf("failed to read embedding cache: %w", err)
+	}
+
+	if len(stats) == 0 {
+		printInfo("Embedding cache is empty")
+		return nil
+	}
+
+	// Mark tables whose model was updated or removed
+	var installed map[string]string
+	cfg := config.Get()
+	if cfg != nil {
+		manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+		if manager.IsOllamaAvailable() {
+			installed, _ = manager.InstalledDigests()
+		}
+	}
+
+	fmt.Println("Embedding Cache:")
+	fmt.Printf("%-28s %-14s %10s %10s %10s %10s\n", "MODEL", "DIGEST", "ENTRIES", "HIT RATE", "LOOKUPS", "SIZE")
+	fmt.Println(strings.Repeat("-", 87))
+
+	var totalEntries int
+	var totalHits, totalMisses, totalSize int64
+	for _, s := range stats {
+		digest := strings.TrimPrefix(s.Digest, "sha256:")
+		if len(digest) > 12 {
+			digest = digest[:12]
+		}
+		if installed != nil {
+			if current, ok := installed[s.Model]; !ok || current != s.Digest {
+				digest += warningColor(" (stale)")
+			}
+		}
+
+		fmt.Printf("%-28s %-14s %10d %9.1f%% %10d %10s\n",
+			s.Model, digest, s.Entries, s.HitRate()*100, s.Hits+s.Misses, FormatBytes(s.Size))
+
+		totalEntries += s.Entries
+		totalHits += s.Hits
+		totalMisses += s.Misses
+		totalSize += s.Size
+	}
+
+	hitRate := 0.0
+	if totalHits+totalMisses > 0 {
+		hitRate = float64(totalHits) / float64(totalHits+totalMisses) * 100
+	}
+	fmt.Println()
+	fmt.Printf("Total: %d entries, %.1f%% hit rate, %s\n", totalEntries, hitRate, FormatBytes(totalSize))
+
+	return nil
+}
+
+func runVectorCachePrune(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	opts := models.CachePruneOptions{
+		All:       cachePruneAll,
+		OlderThan: cachePruneOlderThan,
+	}
+
+	if !cachePruneAll {
+		cfg := config.Get()
+		if cfg == nil {
+			return fmt.Errorf("failed to load configuration")
+		}
+
+		// Stale digests can only be detected while Ollama is running
+		manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+		if manager.IsOllamaAvailable() {
+			installed, err := manager.InstalledDigests()
+			if err != nil {
+				return fmt.Errorf("failed to list models: %w", err)
+			}
+			opts.Installed = installed
+		} else {
+			printWarning("Ollama is not running; entries for outdated models are kept")
+			if cachePruneOlderThan == 0 {
+				return nil
+			}
+		}
+	}
+
+	removed, err := models.NewEmbeddingCache(models.DefaultEmbeddingCacheDir()).Prune(opts)
+	if err != nil {
+		return fmt.Errorf("failed to prune embedding cache: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("Removed %d cached embedding(s)", removed))
+	return nil
+}
+
+func runVectorServe(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+
+	// Anyone on the network could otherwise read and change the vector store
+	if vectorServeAPIKey == "" && !isLoopbackHost(vectorServeHost) {
+		return fmt.Errorf("listening on %s needs an API key. Set --api-key or LOCALCLOUD_VECTOR_API_KEY", vectorServeHost)
+	}
+
+	provider := providers.DefaultProvider(cfg)
+	db, err := providers.Open(cfg, &vectordb.Config{Provider: string(provider)})
+	if err != nil {
+		return fmt.Errorf("failed to connect to vector database: %w", err)
+	}
+
+	opts := api.Options{
+		APIKey:         vectorServeAPIKey,
+		Provider:       string(provider),
+		AllowedOrigins: vectorServeCORSOrigins,
+	}
+
+	// Text requests need an embedding model; without one only vectors are accepted
+	embeddingModel := vectorServeEmbeddingModel
+	if embeddingModel == "" {
+		embeddingModel = configuredEmbeddingModel(cfg)
+	}
+	if embeddingModel != "" {
+		manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+		if manager.IsOllamaAvailable() {
+			opts.Embedder = manager
+			opts.EmbeddingModel = embeddingModel
+		} else {
+			printWarning("Ollama is not running; requests must include vectors")
+		}
+	}
+
+	addr := net.JoinHostPort(vectorServeHost, fmt.Sprintf("%d", vectorServePort))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	listener.Close()
+
+	// Register so lc info and tunnels can find the service
+	url := fmt.Sprintf("http://localhost:%d", vectorServePort)
+	unregister := registerHostService(vectorAPIServiceName, vectorServePort, "vector", map[string]interface{}{
+		"provider": string(provider),
+	})
+	defer unregister()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	printSuccess(fmt.Sprintf("Vector API (%s) listening on %s", provider, url))
+	fmt.Printf("  OpenAPI: %s/openapi.json\n", url)
+	if opts.Embedder != nil {
+		fmt.Printf("  Text embedding: %s\n", opts.EmbeddingModel)
+	}
+	if opts.APIKey != "" {
+		fmt.Println("  Authentication: API key required")
+	}
+	fmt.Println("\nPress Ctrl+C to stop")
+
+	if err := api.NewServer(db, opts).ListenAndServe(ctx, addr); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+
+	fmt.Println("\nVector API stopped")
+	return nil
+}
+
+// isLoopbackHost reports whether host only accepts local connections
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// vectorAPIPort returns the port of a running lc vector serve, or 0
+func vectorAPIPort() int {
+	return hostServicePort(vectorAPIServiceName)
+}
+
+// reembedState tracks progress so an interrupted re-embed can resume
+type reembedState struct {
+	Model     string    `json:"model"`
+	Dimension int       `json:"dimension"`
+	StartedAt time.Time `json:"started_at"`
+	// Stores maps a vector store provider to the progress of its collections
+	Stores map[string]map[string]*reembedCollectionState `json:"stores"`
+}
+
+type reembedCollectionState struct {
+	Shadow  string `json:"shadow"`
+	Done    int    `json:"done"`
+	Total   int    `json:"total"`
+	Swapped bool   `json:"swapped"`
+}
+
+func runVectorReembed(cmd *cobra.Command, args []string) error {
+	if !IsProjectInitialized() {
+		return fmt.Errorf("no LocalCloud project found. Run 'lc setup' first")
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("failed to load configuration")
+	}
+	if err := requireOllama(cfg, "lc vector reembed"); err != nil {
+		return err
+	}
+
+	if reembedBatchSize <= 0 {
+		return fmt.Errorf("batch size must be positive")
+	}
+
+	manager := models.NewManager(fmt.Sprintf("http://localhost:%d", cfg.Services.AI.Port))
+	if !manager.IsOllamaAvailable() {
+		return fmt.Errorf("Ollama service is not running. Start it with: lc start ai")
+	}
+
+	if !models.IsEmbeddingModel(reembedModel) && manager.CheckModelType(reembedModel) != "embedding" {
+		printWarning(fmt.Sprintf("%s does not look like an embedding model", reembedModel))
+	}
+
+	// Pull the model if needed
+	if err := ensureModelPulled(manager, reembedModel); err != nil {
+		return err
+	}
+
+	dimension, err := manager.EmbeddingDimension(reembedModel)
+	if err != nil {
+		return fmt.Errorf("failed to determine embedding dimension: %w", err)
+	}
+	printInfo(fmt.Sprintf("%s produces %d-dimensional embeddings", reembedModel, dimension))
+
+	// The embedding model is project-wide, so every configured store moves
+	// to it before the configuration changes
+	stores := providers.Configured(cfg)
+	if len(stores) == 0 {
+		return fmt.Errorf("no vector database configured. Add one with: lc component add vector")
+	}
+	migrators := make(map[vectordb.Provider]vectordb.Migrator)
+	for _, provider := range stores {
+		db, err := providers.Open(cfg, &vectordb.Config{Provider: string(provider), EmbeddingDim: dimension})
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", provider, err)
+		}
+		migrator, ok := db.(vectordb.Migrator)
+		if !ok {
+			return fmt.Errorf("%s does not support re-embedding", provider)
+		}
+		migrators[provider] = migrator
+	}
+
+	state, err := loadReembedState()
+	if err != nil {
+		return err
+	}
+	if state != nil && (state.Model != reembedModel || state.Dimension != dimension) {
+		printWarning(fmt.Sprintf("Discarding unfinished re-embed to %s", state.Model))
+		state = nil
+	}
+	if state == nil {
+		state = &reembedState{
+			Model:     reembedModel,
+			Dimension: dimension,
+			StartedAt: time.Now(),
+			Stores:    make(map[string]map[string]*reembedCollectionState),
+		}
+	} else {
+		printInfo("Resuming previous re-embed")
+	}
+	if vectordb.IsShadow(reembedCollection) {
+		return fmt.Errorf("%s was created by a re-embed and cannot be re-embedded itself", reembedCollection)
+	}
+
+	ctx := context.Background()
+
+	// Collect every store's work first so a missing --collection fails early
+	work := make(map[vectordb.Provider][]string)
+	total := 0
+	for _, provider := range stores {
+		if state.Stores[string(provider)] == nil {
+			state.Stores[string(provider)] = make(map[string]*reembedCollectionState)
+		}
+		collections, err := reembedCollections(ctx, migrators[provider], state.Stores[string(provider)])
+		if err != nil {
+			return fmt.Errorf("failed to list %s collections: %w", provider, err)
+		}
+		if reembedCollection != "" {
+			if !contains(collections, reembedCollection) {
+				continue
+			}
+			collections = []string{reembedCollection}
+		}
+		work[provider] = collections
+		total += len(collections)
+	}
+	if reembedCollection != "" && total == 0 {
+		return fmt.Errorf("collection %s not found in any vector store", reembedCollection)
+	}
+
+	// Content embedded by an earlier (possibly interrupted) run is reused
+	embedder := models.NewCachedEmbedder(manager, models.NewEmbeddingCache(models.DefaultEmbeddingCacheDir()))
+
+	for _, provider := range stores {
+		if len(stores) > 1 && len(work[provider]) > 0 {
+			fmt.Printf("Vector store: %s\n", provider)
+		}
+		for _, collection := range work[provider] {
+			err := reembedCollectionData(ctx, embedder, migrators[provider], state, state.Stores[string(provider)], collection)
+			if flushErr := embedder.Flush(); flushErr != nil {
+				printWarning(fmt.Sprintf("Failed to save embedding cache: %v", flushErr))
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", provider, err)
+			}
+		}
+	}
+
+	if err := os.Remove(reembedStatePath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Record the new embedding model in the configuration
+	newModels := []string{}
+	for _, model := range cfg.Services.AI.Models {
+		if !models.IsEmbeddingModel(model) {
+			newModels = append(newModels, model)
+		}
+	}
+	cfg.Services.AI.Models = append(newModels, reembedModel)
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("Re-embedded %d collection(s) in %d store(s) with %s", total, len(work), reembedModel))
+	if embedder.Hits > 0 {
+		printInfo(fmt.Sprintf("Embedding cache: %d hits, %d misses (%.0f%% hit rate)", embedder.Hits, embedder.Misses, embedder.HitRate()*100))
+	}
+	return nil
+}
+
+// reembedCollections returns a store's user collections, leaving out the
+// shadows of this and earlier re-embeds. done is the store's saved progress.
+func reembedCollections(ctx context.Context, migrator vectordb.Migrator, done map[string]*reembedCollectionState) ([]string, error) {
+	names, err := migrator.ListCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shadows := make(map[string]bool)
+	for _, progress := range done {
+		shadows[progress.Shadow] = true
+	}
+
+	var collections []string
+	for _, name := range names {
+		if vectordb.IsShadow(name) || shadows[name] {
+			continue
+		}
+		collections = append(collections, name)
+	}
+
+	// A collection whose swap was interrupted may be missing from the list
+	for name, progress := range done {
+		if !progress.Swapped && !contains(collections, name) {
+			collections = append(collections, name)
+		}
+	}
+	sort.Strings(collections)
+	return collections, nil
+}
+
+// reembedCollectionData copies one collection into its shadow with new
+// embeddings and swaps it into place. done is the store's saved progress.
+func reembedCollectionData(ctx context.Context, embedder *models.CachedEmbedder, migrator vectordb.Migrator, state *reembedState, done map[string]*reembedCollectionState, collection string) error {
+	progress, ok := done[collection]
+	if ok && progress.Swapped {
+		printInfo(fmt.Sprintf("Collection %s already re-embedded, skipping", collection))
+		return nil
+	}
+
+	// The shadow is complete; only the swap was interrupted, which may have
+	// left the collection itself missing
+	if ok && progress.Total > 0 && progress.Done == progress.Total {
+		return swapReembedded(ctx, migrator, state, progress, collection)
+	}
+
+	records, err := migrator.ListRecords(ctx, collection)
+	if err != nil {
+		return fmt.Errorf("failed to read collection %s: %w", collection, err)
+	}
+
+	// A stable order lets the saved offset identify what is already done
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].DocumentID != records[j].DocumentID {
+			return records[i].DocumentID < records[j].DocumentID
+		}
+		return records[i].ChunkIndex < records[j].ChunkIndex
+	})
+
+	if !ok || progress.Total != len(records) {
+		// The collection changed since the interrupted run, so its partly
+		// filled shadow is dropped and the copy starts over
+		if ok {
+			if err := migrator.DropShadow(ctx, progress.Shadow); err != nil {
+				return fmt.Errorf("failed to drop outdated shadow %s: %w", progress.Shadow, err)
+			}
+		}
+		progress = &reembedCollectionState{
+			Shadow: fmt.Sprintf("%s%s%d", collection, vectordb.ShadowMarker, time.Now().Unix()),
+			Total:  len(records),
+		}
+		done[collection] = progress
+	}
+
+	if err := migrator.CreateShadow(ctx, progress.Shadow, state.Dimension); err != nil {
+		return fmt.Errorf("failed to create shadow collection: %w", err)
+	}
+	if err := saveReembedState(state); err != nil {
+		return err
+	}
+
+	fmt.Printf("Re-embedding %s (%d chunks)\n", collection, len(records))
+
+	for progress.Done < len(records) {
+		end := progress.Done + reembedBatchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[progress.Done:end]
+
+		texts := make([]string, len(batch))
+		for i, record := range batch {
+			texts[i] = record.Content
+		}
+
+		embeddings, err := embedder.Embed(state.Model, texts)
+		if err != nil {
+			fmt.Println()
+			return fmt.Errorf("failed to embed batch (resume with the same command): %w", err)
+		}
+		for i := range batch {
+			batch[i].Vector = embeddings[i]
+		}
+
+		if err := migrator.StoreRecords(ctx, progress.Shadow, batch); err != nil {
+			fmt.Println()
+			return fmt.Errorf("failed to store batch (resume with the same command): %w", err)
+		}
+
+		progress.Done = end
+		if err := saveReembedState(state); err != nil {
+			return err
+		}
+
+		percentage := progress.Done * 100 / len(records)
+		fmt.Printf("\r  %d%% [%s] %d/%d", percentage, progressBar(percentage, 30), progress.Done, len(records))
+	}
+	if len(records) > 0 {
+		fmt.Println()
+	}
+
+	return swapReembedded(ctx, migrator, state, progress, collection)
+}
+
+// swapReembedded swaps a filled shadow into place and records it
+func swapReembedded(ctx context.Context, migrator vectordb.Migrator, state *reembedState, progress *reembedCollectionState, collection string) error {
+	if err := migrator.SwapCollection(ctx, progress.Shadow, collection); err != nil {
+		return fmt.Errorf("failed to swap collection %s: %w", collection, err)
+	}
+	progress.Swapped = true
+	if err := saveReembedState(state); err != nil {
+		return err
+	}
+
+	printSuccess(fmt.Sprintf("Collection %s now uses %s", collection, state.Model))
+	return nil
+}
+
+// ensureModelPulled pulls a model unless it is already installed
+func ensureModelPulled(manager *models.Manager, modelName string) error {
+	installed, err := manager.List()
+	if err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+	for _, model := range installed {
+		if model.Name == modelName || model.Model == modelName || model.Name == modelName+":latest" {
+			return nil
+		}
+	}
+
+	fmt.Printf("Pulling model: %s\n", modelName)
+	progress := make(chan models.PullProgress)
+	done := make(chan error, 1)
+	go func() {
+		done <- manager.Pull(modelName, progress)
+	}()
+
+	for p := range progress {
+		if p.Total > 0 {
+			percentage := int((p.Completed * 100) / p.Total)
+			fmt.Printf("\r%s: %d%% [%s]", p.Status, percentage, progressBar(percentage, 30))
+		}
+	}
+	fmt.Println()
+
+	if err := <-done; err != nil {
+		return fmt.Errorf("failed to pull model: %w", err)
+	}
+	return nil
+}
+
+func reembedStatePath() string {
+	return filepath.Join(".localcloud", "reembed.json")
+}
+
+// loadReembedState returns nil when no re-embed is in progress
+func loadReembedState() (*reembedState, error) {
+	data, err := os.ReadFile(reembedStatePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state reembedState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse re-embed state: %w", err)
+	}
+	if state.Stores == nil {
+		state.Stores = make(map[string]map[string]*reembedCollectionState)
+	}
+	return &state, nil
+}
+
+func saveReembedState(state *reembedState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(reembedStatePath(), data, 0644)
+}
@@ -4,6 +4,8 @@ package components
 
 import (
 	"fmt"
+
+	"github.com/localcloud-sh/localcloud/internal/catalog"
 )
 
 const (
@@ -22,6 +24,23 @@ type Model struct {
 	Family     string // Model family (e.g., "bert", "llama")
 }
 
+// catalogModels returns the recommended language or embedding models of the
+// model catalog as component model options
+func catalogModels(embedding bool) []Model {
+	var options []Model
+	for _, m := range catalog.Default().Recommended(embedding) {
+		options = append(options, Model{
+			Name:       m.Name,
+			Size:       m.Size.String(),
+			RAM:        int64(m.RAM),
+			Default:    m.Default,
+			Dimensions: m.Dimensions,
+			Family:     m.Family,
+		})
+	}
+	return options
+}
+
 // Component represents a LocalCloud component
 type Component struct {
 	ID          string
@@ -49,14 +68,8 @@ var Registry = map[string]Component{
 		Description: "Large language models for text generation, chat, and completion",
 		Category:    "ai",
 		Services:    []string{"ai"},
-		Models: []Model{
-			{Name: "qwen2.5:3b", Size: "2.3GB", RAM: 4 * GB, Default: true},
-			{Name: "llama3.2:3b", Size: "2.0GB", RAM: 4 * GB},
-			{Name: "deepseek-coder:1.3b", Size: "1.5GB", RAM: 3 * GB},
-			{Name: "phi3:mini", Size: "2.3GB", RAM: 4 * GB},
-			{Name: "gemma2:2b", Size: "1.6GB", RAM: 3 * GB},
-		},
-		MinRAM: 4 * GB,
+		Models:      catalogModels(false),
+		MinRAM:      4 * GB,
 	},
 	"embedding": {
 		ID:          "embedding",
@@ -64,13 +77,8 @@ var Registry = map[string]Component{
 		Description: "Text embeddings for semantic search and similarity",
 		Category:    "ai",
 		Services:    []string{"ai"},
-		Models: []Model{
-			{Name: "nomic-embed-text", Size: "274MB", RAM: 768 * MB, Dimensions: 768, Default: true},
-			{Name: "mxbai-embed-large", Size: "670MB", RAM: 1 * GB, Dimensions: 1024},
-			{Name: "all-minilm", Size: "46MB", RAM: 256 * MB, Dimensions: 384},
-			{Name: "bge-small", Size: "134MB", RAM: 512 * MB, Dimensions: 384},
-		},
-		MinRAM: 2 * GB,
+		Models:      catalogModels(true),
+		MinRAM:      2 * GB,
 	},
 	"database": {
 		ID:          "database",
@@ -92,6 +100,28 @@ var Registry = map[string]Component{
 			"depends_on": "database",
 		},
 	},
+	"qdrant": {
+		ID:          "qdrant",
+		Name:        "Vector Database (Qdrant)",
+		Description: "Dedicated vector search engine with payload filtering",
+		Category:    "database",
+		Services:    []string{"qdrant"},
+		MinRAM:      512 * MB,
+		Config: map[string]interface{}{
+			"provider": "qdrant",
+		},
+	},
+	"chroma": {
+		ID:          "chroma",
+		Name:        "Vector Database (Chroma)",
+		Description: "Open-source embedding database popular for Python prototyping",
+		Category:    "database",
+		Services:    []string{"chroma"},
+		MinRAM:      512 * MB,
+		Config: map[string]interface{}{
+			"provider": "chroma",
+		},
+	},
 	"mongodb": {
 		ID:          "mongodb",
 		Name:        "NoSQL Database (MongoDB)",
@@ -124,6 +154,36 @@ var Registry = map[string]Component{
 		Services:    []string{"minio"},
 		MinRAM:      1 * GB,
 	},
+	"stt": {
+		ID:          "stt",
+		Name:        "Speech-to-Text (Whisper)",
+		Description: "Transcribe audio to text and subtitles with OpenAI Whisper",
+		Category:    "ai",
+		Services:    []string{"whisper"},
+		Models:      whisperModels,
+		MinRAM:      1 * GB,
+	},
+}
+
+// whisperModels are the Whisper model sizes the speech-to-text service can
+// load. Sizes are downloads; RAM is what the model needs to transcribe.
+var whisperModels = []Model{
+	{Name: "tiny", Size: "75MB", RAM: 1 * GB, Family: "whisper"},
+	{Name: "base", Size: "142MB", RAM: 1 * GB, Default: true, Family: "whisper"},
+	{Name: "small", Size: "466MB", RAM: 2 * GB, Family: "whisper"},
+	{Name: "medium", Size: "1.5GB", RAM: 5 * GB, Family: "whisper"},
+	{Name: "turbo", Size: "1.6GB", RAM: 6 * GB, Family: "whisper"},
+	{Name: "large-v3", Size: "2.9GB", RAM: 10 * GB, Family: "whisper"},
+}
+
+// DefaultWhisperModel returns the recommended speech-to-text model
+func DefaultWhisperModel() string {
+	for _, m := range whisperModels {
+		if m.Default {
+			return m.Name
+		}
+	}
+	return "base"
 }
 
 // ProjectTemplates defines component sets for project types
@@ -186,7 +246,7 @@ func GetComponentsByCategory(category string) []Component {
 func GetAllComponents() []Component {
 	var components []Component
 	// Use a specific order
-	order := []string{"llm", "embedding", "database", "vector", "mongodb", "cache", "queue", "storage", "stt"}
+	order := []string{"llm", "embedding", "database", "vector", "qdrant", "chroma", "mongodb", "cache", "queue", "storage", "stt"}
 
 	for _, id := range order {
 		if comp, ok := Registry[id]; ok {
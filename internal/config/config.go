@@ -109,6 +109,35 @@ func syncToViper() {
 		viper.Set("services.ai.port", instance.Services.AI.Port)
 		viper.Set("services.ai.models", instance.Services.AI.Models)
 		viper.Set("services.ai.default", instance.Services.AI.Default)
+		// Set even when empty: WriteConfigAs would otherwise keep the value
+		// read from the file, and the last alias could never be removed
+		aliases := instance.Services.AI.Aliases
+		if aliases == nil {
+			aliases = []ModelAlias{}
+		}
+		viper.Set("services.ai.aliases", aliases)
+		viper.Set("services.ai.mode", instance.Services.AI.Mode)
+		if instance.Services.AI.Backend != "" {
+			viper.Set("services.ai.backend", instance.Services.AI.Backend)
+		}
+		viper.Set("services.ai.provider", instance.Services.AI.Provider)
+		if instance.Services.AI.ChatTemplate != "" {
+			viper.Set("services.ai.chat_template", instance.Services.AI.ChatTemplate)
+		}
+		if instance.Services.AI.ContextSize > 0 {
+			viper.Set("services.ai.context_size", instance.Services.AI.ContextSize)
+		}
+		viper.Set("services.ai.fixtures", instance.Services.AI.Fixtures)
+		profiles := instance.Services.AI.Profiles
+		if profiles == nil {
+			profiles = []ModelProfile{}
+		}
+		viper.Set("services.ai.profiles", profiles)
+		keepAlive := instance.Services.AI.KeepAlive
+		if keepAlive == nil {
+			keepAlive = []ModelKeepAlive{}
+		}
+		viper.Set("services.ai.keep_alive", keepAlive)
 	}
 
 	if instance.Services.Database.Type != "" {
@@ -126,12 +155,31 @@ func syncToViper() {
 		viper.Set("services.mongodb.auth_enabled", instance.Services.MongoDB.AuthEnabled)
 	}
 
+	if instance.Services.Qdrant.Type != "" {
+		viper.Set("services.qdrant.type", instance.Services.Qdrant.Type)
+		viper.Set("services.qdrant.version", instance.Services.Qdrant.Version)
+		viper.Set("services.qdrant.port", instance.Services.Qdrant.Port)
+		viper.Set("services.qdrant.grpc_port", instance.Services.Qdrant.GRPCPort)
+	}
+
+	if instance.Services.Chroma.Type != "" {
+		viper.Set("services.chroma.type", instance.Services.Chroma.Type)
+		viper.Set("services.chroma.version", instance.Services.Chroma.Version)
+		viper.Set("services.chroma.port", instance.Services.Chroma.Port)
+	}
+
 	if instance.Services.Cache.Type != "" {
 		viper.Set("services.cache.type", instance.Services.Cache.Type)
 		viper.Set("services.cache.port", instance.Services.Cache.Port)
 		viper.Set("services.cache.maxmemory", instance.Services.Cache.MaxMemory)
 		viper.Set("services.cache.maxmemory_policy", instance.Services.Cache.MaxMemoryPolicy)
 		viper.Set("services.cache.persistence", instance.Services.Cache.Persistence)
+		if rc := instance.Services.Cache.ResponseCache; rc != (ResponseCacheConfig{}) {
+			viper.Set("services.cache.response_cache.enabled", rc.Enabled)
+			viper.Set("services.cache.response_cache.ttl", rc.TTL)
+			viper.Set("services.cache.response_cache.similarity", rc.Similarity)
+			viper.Set("services.cache.response_cache.embedding_model", rc.EmbeddingModel)
+		}
 	}
 
 	if instance.Services.Queue.Type != "" {
@@ -208,6 +256,10 @@ func setDefaults() {
 	viper.SetDefault("services.ai.port", defaults.Services.AI.Port)
 	viper.SetDefault("services.ai.models", defaults.Services.AI.Models)
 	viper.SetDefault("services.ai.default", defaults.Services.AI.Default)
+	// Registered so LOCALCLOUD_SERVICES_AI_MODE=mock works without editing the config
+	viper.SetDefault("services.ai.mode", "")
+	viper.SetDefault("services.ai.fixtures", "")
+	viper.SetDefault("services.ai.backend", "")
 
 	// Database defaults
 	viper.SetDefault("services.database.type", defaults.Services.Database.Type)
@@ -242,6 +294,17 @@ func setDefaults() {
 	viper.SetDefault("services.mongodb.replica_set", defaults.Services.MongoDB.ReplicaSet)
 	viper.SetDefault("services.mongodb.auth_enabled", defaults.Services.MongoDB.AuthEnabled)
 
+	// Qdrant defaults
+	viper.SetDefault("services.qdrant.type", defaults.Services.Qdrant.Type)
+	viper.SetDefault("services.qdrant.version", defaults.Services.Qdrant.Version)
+	viper.SetDefault("services.qdrant.port", defaults.Services.Qdrant.Port)
+	viper.SetDefault("services.qdrant.grpc_port", defaults.Services.Qdrant.GRPCPort)
+
+	// Chroma defaults
+	viper.SetDefault("services.chroma.type", defaults.Services.Chroma.Type)
+	viper.SetDefault("services.chroma.version", defaults.Services.Chroma.Version)
+	viper.SetDefault("services.chroma.port", defaults.Services.Chroma.Port)
+
 	// Resource defaults
 	viper.SetDefault("resources.memory_limit", defaults.Resources.MemoryLimit)
 	viper.SetDefault("resources.cpu_limit", defaults.Resources.CPULimit)
@@ -23,6 +23,8 @@ type ServicesConfig struct {
 	AI       AIConfig       `yaml:"ai" json:"ai"`
 	Database DatabaseConfig `yaml:"database" json:"database"`
 	MongoDB  MongoDBConfig  `yaml:"mongodb" json:"mongodb"`
+	Qdrant   QdrantConfig   `yaml:"qdrant" json:"qdrant"`
+	Chroma   ChromaConfig   `yaml:"chroma" json:"chroma"`
 	Cache    CacheConfig    `yaml:"cache" json:"cache"`
 	Queue    QueueConfig    `yaml:"queue" json:"queue"`
 	Storage  StorageConfig  `yaml:"storage" json:"storage"`
@@ -31,9 +33,94 @@ type ServicesConfig struct {
 
 // AIConfig represents AI service configuration
 type AIConfig struct {
-	Port    int      `yaml:"port" json:"port"`
-	Models  []string `yaml:"models" json:"models"`
-	Default string   `yaml:"default" json:"default"`
+	Port     int            `yaml:"port" json:"port"`
+	Models   []string       `yaml:"models" json:"models"`
+	Default  string         `yaml:"default" json:"default"`
+	Aliases  []ModelAlias   `yaml:"aliases,omitempty" json:"aliases,omitempty"`   // Gateway model aliases
+	Mode     string         `yaml:"mode,omitempty" json:"mode,omitempty"`         // "ollama" (default) or "mock"
+	Backend  string         `yaml:"backend,omitempty" json:"backend,omitempty"`   // "container" (default) or "host"
+	Provider string         `yaml:"provider,omitempty" json:"provider,omitempty"` // "ollama" (default), "llamacpp" or "localai"
+	Fixtures string         `yaml:"fixtures,omitempty" json:"fixtures,omitempty"` // Scripted responses for mock mode
+	Profiles []ModelProfile `yaml:"profiles,omitempty" json:"profiles,omitempty"` // Custom models derived from a base model
+	// How long models stay loaded after their last request
+	KeepAlive []ModelKeepAlive `yaml:"keep_alive,omitempty" json:"keep_alive,omitempty" mapstructure:"keep_alive"`
+	// Chat template for GGUF models served by llama.cpp: a built-in template
+	// name such as chatml or a path to a Jinja template file
+	ChatTemplate string `yaml:"chat_template,omitempty" json:"chat_template,omitempty" mapstructure:"chat_template"`
+	// Context length llama.cpp allocates for the model (default 4096)
+	ContextSize int `yaml:"context_size,omitempty" json:"context_size,omitempty" mapstructure:"context_size"`
+}
+
+// AIModeMock selects the deterministic mock backend instead of Ollama
+const AIModeMock = "mock"
+
+// AI backends: where Ollama runs
+const (
+	AIBackendContainer = "container" // An Ollama container started by LocalCloud
+	AIBackendHost      = "host"      // An Ollama installed and run on the host
+)
+
+// HostBackend reports whether the project uses a host-installed Ollama
+func (c AIConfig) HostBackend() bool {
+	return c.Backend == AIBackendHost
+}
+
+// AI providers: the inference server that runs the models
+const (
+	AIProviderOllama   = "ollama"
+	AIProviderLlamaCpp = "llamacpp" // llama.cpp's llama-server serving one GGUF file
+	AIProviderLocalAI  = "localai"
+)
+
+// ProviderName returns the configured provider, defaulting to Ollama
+func (c AIConfig) ProviderName() string {
+	if c.Provider == "" {
+		return AIProviderOllama
+	}
+	return c.Provider
+}
+
+// ModelAlias maps a model name requested through the OpenAI-compatible
+// gateway (e.g. gpt-4o-mini) to a local model (e.g. qwen2.5:3b). It is a list
+// entry rather than a map key because model names may contain dots.
+type ModelAlias struct {
+	Name  string `yaml:"name" json:"name"`
+	Model string `yaml:"model" json:"model"`
+}
+
+// ModelProfile is a named model built from a base model with its own system
+// prompt and parameters, so applications share one definition instead of
+// hardcoding them
+type ModelProfile struct {
+	Name        string   `yaml:"name" json:"name"`
+	Base        string   `yaml:"base" json:"base"`
+	System      string   `yaml:"system,omitempty" json:"system,omitempty"`
+	Temperature *float64 `yaml:"temperature,omitempty" json:"temperature,omitempty"`
+	NumCtx      int      `yaml:"num_ctx,omitempty" json:"num_ctx,omitempty" mapstructure:"num_ctx"`
+	Stop        []string `yaml:"stop,omitempty" json:"stop,omitempty"`
+	Template    string   `yaml:"template,omitempty" json:"template,omitempty"`
+}
+
+// ModelKeepAlive sets how long a model stays loaded after its last request,
+// as an Ollama duration ("10m", "30s"; "0" unloads right away and "-1" keeps
+// the model loaded). Model "*" sets the default for every other model.
+type ModelKeepAlive struct {
+	Model    string `yaml:"model" json:"model"`
+	Duration string `yaml:"duration" json:"duration"`
+}
+
+// KeepAliveDefault is the Model of the keep_alive entry for all other models
+const KeepAliveDefault = "*"
+
+// DefaultKeepAlive returns the keep_alive duration for models without their
+// own entry, or "" when Ollama's default applies
+func (c AIConfig) DefaultKeepAlive() string {
+	for _, entry := range c.KeepAlive {
+		if entry.Model == KeepAliveDefault {
+			return entry.Duration
+		}
+	}
+	return ""
 }
 
 // DatabaseConfig represents database service configuration
@@ -51,6 +138,20 @@ type CacheConfig struct {
 	MaxMemory       string `yaml:"maxmemory" json:"maxmemory"`
 	MaxMemoryPolicy string `yaml:"maxmemory_policy" json:"maxmemory_policy"`
 	Persistence     bool   `yaml:"persistence" json:"persistence"`
+	// Caching of gateway LLM responses in this service
+	ResponseCache ResponseCacheConfig `yaml:"response_cache,omitempty" json:"response_cache,omitempty" mapstructure:"response_cache"`
+}
+
+// ResponseCacheConfig configures the gateway's response cache. Completions
+// are keyed by model, parameters and normalized prompt; a prompt whose
+// embedding is close enough to a cached one also hits.
+type ResponseCacheConfig struct {
+	Enabled    bool    `yaml:"enabled" json:"enabled"`
+	TTL        string  `yaml:"ttl,omitempty" json:"ttl,omitempty"`               // How long responses are kept (default 24h)
+	Similarity float64 `yaml:"similarity,omitempty" json:"similarity,omitempty"` // Minimum similarity of near-duplicate prompts (default 0.95, 1 for exact matches only)
+	// Model that embeds prompts for near-duplicate matching (default: the
+	// project's embedding model)
+	EmbeddingModel string `yaml:"embedding_model,omitempty" json:"embedding_model,omitempty" mapstructure:"embedding_model"`
 }
 
 // QueueConfig represents queue service configuration
@@ -80,6 +181,21 @@ type MongoDBConfig struct {
 	AuthEnabled bool   `yaml:"auth_enabled" json:"auth_enabled"`
 }
 
+// QdrantConfig represents Qdrant vector database configuration
+type QdrantConfig struct {
+	Type     string `yaml:"type" json:"type"`
+	Version  string `yaml:"version" json:"version"`
+	Port     int    `yaml:"port" json:"port"`
+	GRPCPort int    `yaml:"grpc_port" json:"grpc_port" mapstructure:"grpc_port"`
+}
+
+// ChromaConfig represents Chroma vector database configuration
+type ChromaConfig struct {
+	Type    string `yaml:"type" json:"type"`
+	Version string `yaml:"version" json:"version"`
+	Port    int    `yaml:"port" json:"port"`
+}
+
 // ResourcesConfig represents resource limits configuration
 type ResourcesConfig struct {
 	MemoryLimit string `yaml:"memory_limit" json:"memory_limit"`
@@ -0,0 +1,163 @@
+// internal/docker/ai_providers.go
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/models"
+)
+
+// Images of the alternative AI providers
+const (
+	llamaCppImage = "ghcr.io/ggml-org/llama.cpp:server"
+	localAIImage  = "localai/localai:latest-cpu"
+)
+
+// startLlamaCpp runs llama-server on the default model's GGUF file
+func (s *AIServiceStarter) startLlamaCpp() error {
+	cfg := s.manager.config.Services.AI
+	dir := models.GGUFDir()
+
+	model := cfg.Default
+	if model == "" {
+		return fmt.Errorf("llama.cpp serves one model: set services.ai.default to a GGUF model in %s", dir)
+	}
+	if _, err := os.Stat(models.GGUFPath(dir, model)); err != nil {
+		return fmt.Errorf("%s is not in %s; import it with 'lc models pull <url or path to %s.gguf>'", model, dir, model)
+	}
+
+	contextSize := cfg.ContextSize
+	if contextSize <= 0 {
+		contextSize = 4096
+	}
+	command := []string{
+		"-m", "/models/" + model + ".gguf",
+		"--alias", model,
+		"--host", "0.0.0.0",
+		"--port", "8080",
+		"-c", strconv.Itoa(contextSize),
+	}
+	if models.IsEmbeddingModel(model) {
+		command = append(command, "--embeddings")
+	}
+
+	volumes := []VolumeMount{{Type: "bind", Source: dir, Target: "/models", ReadOnly: true}}
+
+	// A chat template is either a file to mount or a built-in name
+	if template := cfg.ChatTemplate; template != "" {
+		if info, err := os.Stat(template); err == nil && !info.IsDir() {
+			abs, err := filepath.Abs(template)
+			if err != nil {
+				return err
+			}
+			volumes = append(volumes, VolumeMount{Type: "bind", Source: abs, Target: "/templates/" + filepath.Base(abs), ReadOnly: true})
+			command = append(command, "--jinja", "--chat-template-file", "/templates/"+filepath.Base(abs))
+		} else {
+			command = append(command, "--chat-template", template)
+		}
+	}
+
+	container := s.providerContainer(llamaCppImage, "http://localhost:8080/health")
+	container.Command = command
+	container.Volumes = volumes
+
+	fmt.Printf("ℹ Serving %s with llama.cpp (context %d)\n", model, contextSize)
+	return s.runProvider(container)
+}
+
+// startLocalAI runs LocalAI with the GGUF directory as its models directory
+func (s *AIServiceStarter) startLocalAI() error {
+	dir := models.GGUFDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create models directory: %w", err)
+	}
+
+	container := s.providerContainer(localAIImage, "http://localhost:8080/readyz")
+	container.Env["MODELS_PATH"] = "/models"
+	container.Volumes = []VolumeMount{{Type: "bind", Source: dir, Target: "/models"}}
+
+	fmt.Printf("ℹ Serving models from %s with LocalAI\n", dir)
+	return s.runProvider(container)
+}
+
+// providerContainer returns the container settings the alternative
+// providers share. Both listen on 8080 and take the AI service's name, so
+// stop, status and logs treat them like the Ollama container.
+func (s *AIServiceStarter) providerContainer(image, healthURL string) ContainerConfig {
+	cfg := s.manager.config
+	return ContainerConfig{
+		Name:  "localcloud-ai",
+		Image: image,
+		Env:   map[string]string{},
+		Ports: []PortBinding{
+			{
+				ContainerPort: "8080",
+				HostPort:      fmt.Sprintf("%d", cfg.Services.AI.Port),
+				Protocol:      "tcp",
+			},
+		},
+		Networks:      []string{fmt.Sprintf("localcloud_%s_default", cfg.Project.Name)},
+		RestartPolicy: "unless-stopped",
+		HealthCheck: &HealthCheckConfig{
+			Test:        []string{"CMD-SHELL", "curl -sf " + healthURL + " || exit 1"},
+			Interval:    60,
+			Timeout:     30,
+			Retries:     5,
+			StartPeriod: 120,
+		},
+		Labels: map[string]string{
+			"com.localcloud.project":  cfg.Project.Name,
+			"com.localcloud.service":  "ai",
+			"com.localcloud.provider": cfg.Services.AI.ProviderName(),
+		},
+	}
+}
+
+// runProvider pulls the image, starts the container and waits for the
+// provider to load its model
+func (s *AIServiceStarter) runProvider(container ContainerConfig) error {
+	if err := s.ensureImage(container.Image); err != nil {
+		return err
+	}
+
+	containerID, err := s.manager.container.Create(container)
+	if err != nil {
+		return err
+	}
+	if err := s.manager.container.Start(containerID); err != nil {
+		return err
+	}
+
+	if err := waitForProvider(s.manager.config.Services.AI, 5*time.Minute); err != nil {
+		return fmt.Errorf("%s failed to start (see lc logs ai): %w", s.manager.config.Services.AI.ProviderName(), err)
+	}
+	return nil
+}
+
+// waitForProvider polls the provider's health until it is ready
+func waitForProvider(ai config.AIConfig, timeout time.Duration) error {
+	backend, err := models.NewBackend(ai)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for {
+		err := backend.Health(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
@@ -93,9 +93,11 @@ func (g *ComposeGenerator) Generate() (*ComposeFile, error) {
 		Driver: "bridge",
 	}
 
-	// Generate AI service
-	if err := g.generateAIService(compose); err != nil {
-		return nil, err
+	// Generate AI service; a host Ollama runs outside compose
+	if !g.config.Services.AI.HostBackend() {
+		if err := g.generateAIService(compose); err != nil {
+			return nil, err
+		}
 	}
 
 	// Generate Database service
@@ -147,6 +149,11 @@ func (g *ComposeGenerator) generateAIService(compose *ComposeFile) error {
 		},
 	}
 
+	// Idle models unload after the project's default keep_alive
+	if keepAlive := g.config.Services.AI.DefaultKeepAlive(); keepAlive != "" {
+		service.Environment["OLLAMA_KEEP_ALIVE"] = keepAlive
+	}
+
 	// Add resource limits
 	if g.config.Resources.MemoryLimit != "" {
 		service.Deploy = &ComposeDeploy{
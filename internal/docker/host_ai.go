@@ -0,0 +1,103 @@
+// internal/docker/host_ai.go
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/system"
+)
+
+// startHost uses an AI server installed on the host (services.ai.backend:
+// host) instead of the container. LocalCloud does not start or stop it; it
+// only checks that the API answers on the AI port.
+func (s *AIServiceStarter) startHost() error {
+	cfg := s.manager.config
+	port := cfg.Services.AI.Port
+
+	if id, ok := s.runningAIContainer(); ok {
+		return fmt.Errorf("the AI container (%s) is running on port %d; stop it with 'lc stop ai' before switching to the host backend", id[:12], port)
+	}
+
+	// llama.cpp and LocalAI have no version endpoint; their health will do
+	if provider := cfg.Services.AI.ProviderName(); provider != config.AIProviderOllama {
+		if err := waitForProvider(cfg.Services.AI, 5*time.Second); err != nil {
+			return fmt.Errorf("%s is not answering on port %d: %w", provider, port, err)
+		}
+		fmt.Printf("ℹ Using host %s on port %d (services.ai.backend: host)\n", provider, port)
+		return nil
+	}
+
+	var version string
+	var err error
+	for i := 0; i < 5; i++ {
+		if version, err = HostAIVersion(port); err == nil {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	if err != nil {
+		if system.NewChecker(context.Background()).CheckOllama() {
+			return fmt.Errorf("Ollama is installed but not answering on port %d; start it with 'ollama serve' (set OLLAMA_HOST=127.0.0.1:%d for a non-default port)", port, port)
+		}
+		return fmt.Errorf("Ollama is not installed on this machine; install it from https://ollama.com or set services.ai.backend to container")
+	}
+
+	fmt.Printf("ℹ Using host Ollama %s on port %d (services.ai.backend: host)\n", version, port)
+	if keepAlive := cfg.Services.AI.DefaultKeepAlive(); keepAlive != "" {
+		fmt.Printf("ℹ Set OLLAMA_KEEP_ALIVE=%s where Ollama runs to apply the default keep_alive\n", keepAlive)
+	}
+
+	return s.postStart()
+}
+
+// runningAIContainer returns the ID of the project's running AI container
+func (s *AIServiceStarter) runningAIContainer() (string, bool) {
+	containers, err := s.manager.container.List(map[string]string{
+		"label": "com.localcloud.project=" + s.manager.config.Project.Name,
+	})
+	if err != nil {
+		return "", false
+	}
+	for _, c := range containers {
+		if getServiceFromContainer(c.Name) == "ai" && c.State == "running" {
+			return c.ID, true
+		}
+	}
+	return "", false
+}
+
+// HostAIReady reports whether the host AI server answers
+func HostAIReady(ai config.AIConfig) bool {
+	if ai.ProviderName() != config.AIProviderOllama {
+		return waitForProvider(ai, time.Second) == nil
+	}
+	_, err := HostAIVersion(ai.Port)
+	return err == nil
+}
+
+// HostAIVersion returns the version of the Ollama answering on the port
+func HostAIVersion(port int) (string, error) {
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://localhost:%d/api/version", port))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Version string `json:"version"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("not an Ollama API: %w", err)
+	}
+	return result.Version, nil
+}
@@ -0,0 +1,110 @@
+// internal/docker/mock_ai.go
+package docker
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mockAIPidFile records the process serving the mock AI backend
+var mockAIPidFile = filepath.Join(".localcloud", "mock-ai.pid")
+
+// startMock runs the deterministic mock backend (lc models mock) on the AI
+// port instead of the Ollama container. It runs as a detached host process so
+// it outlives lc start, like the container would.
+func (s *AIServiceStarter) startMock() error {
+	cfg := s.manager.config
+
+	if pid, ok := MockAIPid(); ok {
+		fmt.Printf("ℹ Mock AI backend already running (pid %d)\n", pid)
+		return nil
+	}
+
+	port := cfg.Services.AI.Port
+	if conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", port), time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("port %d is already in use; stop the Ollama container with 'lc stop' before switching to mock mode", port)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate lc executable: %w", err)
+	}
+
+	args := []string{"models", "mock", "--port", strconv.Itoa(port)}
+	if cfg.Services.AI.Fixtures != "" {
+		args = append(args, "--fixtures", cfg.Services.AI.Fixtures)
+	}
+
+	logDir := filepath.Join(".localcloud", "logs")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(filepath.Join(logDir, "mock-ai.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open mock AI log: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(exe, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	detachProcess(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start mock AI backend: %w", err)
+	}
+	pid := cmd.Process.Pid
+	cmd.Process.Release()
+
+	if err := os.WriteFile(mockAIPidFile, []byte(strconv.Itoa(pid)), 0644); err != nil {
+		return fmt.Errorf("failed to record mock AI pid: %w", err)
+	}
+
+	if err := s.waitForOllama(); err != nil {
+		StopMockAI()
+		return fmt.Errorf("mock AI backend failed to start (see .localcloud/logs/mock-ai.log): %w", err)
+	}
+
+	fmt.Println("ℹ Using mock AI backend (services.ai.mode: mock)")
+	return nil
+}
+
+// MockAIPid returns the pid of the running mock AI backend
+func MockAIPid() (int, bool) {
+	data, err := os.ReadFile(mockAIPidFile)
+	if err != nil {
+		return 0, false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || !processAlive(pid) {
+		os.Remove(mockAIPidFile)
+		return 0, false
+	}
+	return pid, true
+}
+
+// StopMockAI stops the mock AI backend if it is running
+func StopMockAI() error {
+	pid, ok := MockAIPid()
+	if !ok {
+		return nil
+	}
+
+	if err := terminateProcess(pid); err != nil {
+		return fmt.Errorf("failed to stop mock AI backend: %w", err)
+	}
+
+	for i := 0; i < 50 && processAlive(pid); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	os.Remove(mockAIPidFile)
+	return nil
+}
@@ -0,0 +1,79 @@
+// internal/docker/ollama_store.go
+package docker
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/docker/docker/api/types"
+)
+
+// ollamaModelsDir is the models directory inside the Ollama container
+const ollamaModelsDir = "/root/.ollama/models"
+
+// OllamaStore reads and writes the models directory of a running Ollama
+// container, whether it is a volume or a bind mount. It implements
+// models.Store.
+type OllamaStore struct {
+	client      *Client
+	containerID string
+}
+
+// NewOllamaStore returns a store for the Ollama container with the given ID
+func (c *Client) NewOllamaStore(containerID string) *OllamaStore {
+	return &OllamaStore{client: c, containerID: containerID}
+}
+
+// FindOllamaContainer returns the ID of the running localcloud-ai container
+func (c *Client) FindOllamaContainer() (string, error) {
+	exists, id, err := c.NewContainerManager().Exists("localcloud-ai")
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("AI container not found")
+	}
+
+	info, err := c.docker.ContainerInspect(c.ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if !info.State.Running {
+		return "", fmt.Errorf("AI container is not running")
+	}
+	return id, nil
+}
+
+// Open opens a file in the models directory. Docker returns files as a tar
+// stream, so the reader yields the content of its single entry.
+func (s *OllamaStore) Open(name string) (io.ReadCloser, int64, error) {
+	rc, _, err := s.client.docker.CopyFromContainer(s.client.ctx, s.containerID, path.Join(ollamaModelsDir, name))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tr := tar.NewReader(rc)
+	hdr, err := tr.Next()
+	if err != nil {
+		rc.Close()
+		return nil, 0, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{tr, rc}, hdr.Size, nil
+}
+
+// Exists reports whether a file exists in the models directory
+func (s *OllamaStore) Exists(name string) bool {
+	_, err := s.client.docker.ContainerStatPath(s.client.ctx, s.containerID, path.Join(ollamaModelsDir, name))
+	return err == nil
+}
+
+// Extract unpacks a tar stream into the models directory
+func (s *OllamaStore) Extract(r io.Reader) error {
+	return s.client.docker.CopyToContainer(s.client.ctx, s.containerID, ollamaModelsDir, r, types.CopyToContainerOptions{})
+}
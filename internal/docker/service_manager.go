@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
 )
 
 // ServiceManager handles service-specific operations
@@ -159,12 +161,18 @@ func (sm *ServiceManager) normalizeServiceName(name string) string {
 		return "postgres"
 	case "mongo", "mongodb", "document", "nosql":
 		return "mongodb"
+	case "qdrant":
+		return "qdrant"
+	case "chroma", "chromadb":
+		return "chroma"
 	case "cache", "redis-cache":
 		return "cache"
 	case "queue", "redis-queue":
 		return "queue"
 	case "storage", "minio", "s3":
 		return "minio"
+	case "whisper", "stt", "speech-to-text":
+		return "whisper"
 	default:
 		return name
 	}
@@ -178,12 +186,18 @@ func (sm *ServiceManager) getServiceStarter(service string) ServiceStarter {
 		return NewDatabaseServiceStarter(sm.manager)
 	case "mongodb":
 		return NewMongoDBServiceStarter(sm.manager)
+	case "qdrant":
+		return NewQdrantServiceStarter(sm.manager)
+	case "chroma":
+		return NewChromaServiceStarter(sm.manager)
 	case "cache":
 		return NewCacheServiceStarter(sm.manager)
 	case "queue":
 		return NewQueueServiceStarter(sm.manager)
 	case "minio":
 		return NewStorageServiceStarter(sm.manager)
+	case "whisper":
+		return NewWhisperServiceStarter(sm.manager)
 	default:
 		return nil
 	}
@@ -226,6 +240,16 @@ func (sm *ServiceManager) StopAll(progress chan<- ServiceProgress) error {
 		}
 	}
 
+	// The mock AI backend is a host process rather than a container
+	if _, ok := MockAIPid(); ok {
+		progress <- ServiceProgress{Service: "ai", Status: "stopping"}
+		if err := StopMockAI(); err != nil {
+			progress <- ServiceProgress{Service: "ai", Status: "failed", Error: err.Error()}
+		} else {
+			progress <- ServiceProgress{Service: "ai", Status: "stopped"}
+		}
+	}
+
 	return nil
 }
 
@@ -305,14 +329,18 @@ func (sm *ServiceManager) componentsToServices(componentIDs []string) []string {
 			serviceMap["postgres"] = true
 		case "mongodb", "document", "nosql":
 			serviceMap["mongodb"] = true
+		case "qdrant":
+			serviceMap["qdrant"] = true
+		case "chroma":
+			serviceMap["chroma"] = true
 		case "cache":
 			serviceMap["cache"] = true
 		case "queue":
 			serviceMap["queue"] = true
 		case "storage":
 			serviceMap["minio"] = true
-			//case "stt":
-			//	serviceMap["whisper"] = true
+		case "stt":
+			serviceMap["whisper"] = true
 		}
 	}
 
@@ -353,6 +381,12 @@ func getServiceFromContainer(containerName string) string {
 		return "postgres"
 	case "mongodb":
 		return "mongodb"
+	case "qdrant":
+		return "qdrant"
+	case "chroma":
+		return "chroma"
+	case "whisper":
+		return "whisper"
 	case "minio":
 		return "minio"
 	case "redis":
@@ -416,12 +450,18 @@ func (sm *ServiceManager) GetStatus() ([]ServiceStatus, error) {
 			port = fmt.Sprintf("%d", sm.manager.config.Services.Database.Port)
 		case "mongodb":
 			port = fmt.Sprintf("%d", sm.manager.config.Services.MongoDB.Port)
+		case "qdrant":
+			port = fmt.Sprintf("%d", sm.manager.config.Services.Qdrant.Port)
+		case "chroma":
+			port = fmt.Sprintf("%d", sm.manager.config.Services.Chroma.Port)
 		case "cache", "redis-cache":
 			port = fmt.Sprintf("%d", sm.manager.config.Services.Cache.Port)
 		case "queue", "redis-queue":
 			port = fmt.Sprintf("%d", sm.manager.config.Services.Queue.Port)
 		case "minio", "storage":
 			port = fmt.Sprintf("%d", sm.manager.config.Services.Storage.Port)
+		case "whisper":
+			port = fmt.Sprintf("%d", sm.manager.config.Services.Whisper.Port)
 		}
 
 		status := ServiceStatus{
@@ -443,5 +483,26 @@ func (sm *ServiceManager) GetStatus() ([]ServiceStatus, error) {
 		statuses = append(statuses, status)
 	}
 
+	if _, ok := MockAIPid(); ok {
+		statuses = append(statuses, ServiceStatus{
+			Name:   "ai",
+			Status: "running",
+			Health: "mock",
+			Port:   fmt.Sprintf("%d", sm.manager.config.Services.AI.Port),
+		})
+	}
+
+	// A host Ollama is reported while it answers; LocalCloud does not manage it
+	if cfg := sm.manager.config.Services.AI; cfg.HostBackend() && cfg.Mode != config.AIModeMock {
+		if HostAIReady(cfg) {
+			statuses = append(statuses, ServiceStatus{
+				Name:   "ai",
+				Status: "running",
+				Health: "host",
+				Port:   fmt.Sprintf("%d", cfg.Port),
+			})
+		}
+	}
+
 	return statuses, nil
 }
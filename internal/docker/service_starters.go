@@ -13,6 +13,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/localcloud-sh/localcloud/internal/config"
 	"github.com/localcloud-sh/localcloud/internal/models"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
@@ -37,6 +38,19 @@ func NewAIServiceStarter(m *Manager) ServiceStarter {
 
 // Start starts the AI service with proper volume mounting
 func (s *AIServiceStarter) Start() error {
+	if s.manager.config.Services.AI.Mode == config.AIModeMock {
+		return s.startMock()
+	}
+	if s.manager.config.Services.AI.HostBackend() {
+		return s.startHost()
+	}
+	switch s.manager.config.Services.AI.ProviderName() {
+	case config.AIProviderLlamaCpp:
+		return s.startLlamaCpp()
+	case config.AIProviderLocalAI:
+		return s.startLocalAI()
+	}
+
 	// Check and pull image
 	if err := s.ensureImage("ollama/ollama:latest"); err != nil {
 		return err
@@ -97,6 +111,11 @@ func (s *AIServiceStarter) Start() error {
 		},
 	}
 
+	// Idle models unload after the project's default keep_alive
+	if keepAlive := s.manager.config.Services.AI.DefaultKeepAlive(); keepAlive != "" {
+		config.Env["OLLAMA_KEEP_ALIVE"] = keepAlive
+	}
+
 	// Configure volume mounting
 	if useHostModels {
 		// Bind mount the host's Ollama directory to share models
@@ -933,3 +952,305 @@ func (s *MongoDBServiceStarter) ensureImage(image string) error {
 	starter := &AIServiceStarter{manager: s.manager}
 	return starter.ensureImage(image)
 }
+
+// QdrantServiceStarter handles Qdrant service startup
+type QdrantServiceStarter struct {
+	manager *Manager
+}
+
+// NewQdrantServiceStarter creates a new Qdrant service starter
+func NewQdrantServiceStarter(m *Manager) ServiceStarter {
+	return &QdrantServiceStarter{manager: m}
+}
+
+// Start starts the Qdrant service
+func (s *QdrantServiceStarter) Start() error {
+	if s.manager.config.Services.Qdrant.Type == "" {
+		return nil // Qdrant not configured
+	}
+
+	// Select image version
+	version := s.manager.config.Services.Qdrant.Version
+	if version == "" {
+		version = "latest"
+	}
+	image := fmt.Sprintf("qdrant/qdrant:%s", version)
+
+	// Check and pull image
+	if err := s.ensureImage(image); err != nil {
+		return err
+	}
+
+	grpcPort := s.manager.config.Services.Qdrant.GRPCPort
+	if grpcPort == 0 {
+		grpcPort = 6334
+	}
+
+	// Create container config WITHOUT health check (image ships without curl/wget)
+	config := ContainerConfig{
+		Name:  "localcloud-qdrant",
+		Image: image,
+		Ports: []PortBinding{
+			{
+				ContainerPort: "6333",
+				HostPort:      fmt.Sprintf("%d", s.manager.config.Services.Qdrant.Port),
+				Protocol:      "tcp",
+			},
+			{
+				ContainerPort: "6334",
+				HostPort:      fmt.Sprintf("%d", grpcPort),
+				Protocol:      "tcp",
+			},
+		},
+		Volumes: []VolumeMount{
+			{
+				Source: fmt.Sprintf("localcloud_%s_qdrant_data", s.manager.config.Project.Name),
+				Target: "/qdrant/storage",
+			},
+		},
+		Networks:      []string{fmt.Sprintf("localcloud_%s_default", s.manager.config.Project.Name)},
+		RestartPolicy: "unless-stopped",
+		Labels: map[string]string{
+			"com.localcloud.project": s.manager.config.Project.Name,
+			"com.localcloud.service": "qdrant",
+		},
+	}
+
+	// Create and start container
+	containerID, err := s.manager.container.Create(config)
+	if err != nil {
+		return err
+	}
+
+	if err := s.manager.container.Start(containerID); err != nil {
+		return err
+	}
+
+	return s.waitForQdrant()
+}
+
+// waitForQdrant waits for the Qdrant readiness endpoint
+func (s *QdrantServiceStarter) waitForQdrant() error {
+	endpoint := fmt.Sprintf("http://localhost:%d/readyz", s.manager.config.Services.Qdrant.Port)
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	maxAttempts := 30
+	for i := 0; i < maxAttempts; i++ {
+		resp, err := client.Get(endpoint)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(1 * time.Second)
+	}
+	return fmt.Errorf("qdrant failed to become ready after %d attempts", maxAttempts)
+}
+
+// ensureImage checks and pulls image if needed
+func (s *QdrantServiceStarter) ensureImage(image string) error {
+	starter := &AIServiceStarter{manager: s.manager}
+	return starter.ensureImage(image)
+}
+
+// ChromaServiceStarter handles Chroma service startup
+type ChromaServiceStarter struct {
+	manager *Manager
+}
+
+// NewChromaServiceStarter creates a new Chroma service starter
+func NewChromaServiceStarter(m *Manager) ServiceStarter {
+	return &ChromaServiceStarter{manager: m}
+}
+
+// Start starts the Chroma service
+func (s *ChromaServiceStarter) Start() error {
+	if s.manager.config.Services.Chroma.Type == "" {
+		return nil // Chroma not configured
+	}
+
+	// Select image version. The provider speaks the v1 HTTP API, so default
+	// to the last release line that serves it.
+	version := s.manager.config.Services.Chroma.Version
+	if version == "" {
+		version = "0.5.23"
+	}
+	image := fmt.Sprintf("chromadb/chroma:%s", version)
+
+	// Check and pull image
+	if err := s.ensureImage(image); err != nil {
+		return err
+	}
+
+	config := ContainerConfig{
+		Name:  "localcloud-chroma",
+		Image: image,
+		Env: map[string]string{
+			"IS_PERSISTENT":        "TRUE",
+			"PERSIST_DIRECTORY":    "/chroma/chroma",
+			"ANONYMIZED_TELEMETRY": "FALSE",
+		},
+		Ports: []PortBinding{
+			{
+				ContainerPort: "8000",
+				HostPort:      fmt.Sprintf("%d", s.manager.config.Services.Chroma.Port),
+				Protocol:      "tcp",
+			},
+		},
+		Volumes: []VolumeMount{
+			{
+				Source: fmt.Sprintf("localcloud_%s_chroma_data", s.manager.config.Project.Name),
+				Target: "/chroma/chroma",
+			},
+		},
+		Networks:      []string{fmt.Sprintf("localcloud_%s_default", s.manager.config.Project.Name)},
+		RestartPolicy: "unless-stopped",
+		Labels: map[string]string{
+			"com.localcloud.project": s.manager.config.Project.Name,
+			"com.localcloud.service": "chroma",
+		},
+	}
+
+	// Create and start container
+	containerID, err := s.manager.container.Create(config)
+	if err != nil {
+		return err
+	}
+
+	if err := s.manager.container.Start(containerID); err != nil {
+		return err
+	}
+
+	return s.waitForChroma()
+}
+
+// waitForChroma waits for the Chroma heartbeat endpoint
+func (s *ChromaServiceStarter) waitForChroma() error {
+	endpoint := fmt.Sprintf("http://localhost:%d/api/v1/heartbeat", s.manager.config.Services.Chroma.Port)
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	maxAttempts := 30
+	for i := 0; i < maxAttempts; i++ {
+		resp, err := client.Get(endpoint)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(1 * time.Second)
+	}
+	return fmt.Errorf("chroma failed to become ready after %d attempts", maxAttempts)
+}
+
+// ensureImage checks and pulls image if needed
+func (s *ChromaServiceStarter) ensureImage(image string) error {
+	starter := &AIServiceStarter{manager: s.manager}
+	return starter.ensureImage(image)
+}
+
+// WhisperServiceStarter handles speech-to-text service startup
+type WhisperServiceStarter struct {
+	manager *Manager
+}
+
+// NewWhisperServiceStarter creates a new Whisper service starter
+func NewWhisperServiceStarter(m *Manager) ServiceStarter {
+	return &WhisperServiceStarter{manager: m}
+}
+
+// Start starts the Whisper service
+func (s *WhisperServiceStarter) Start() error {
+	if s.manager.config.Services.Whisper.Type == "" {
+		return nil // Whisper not configured
+	}
+
+	image := "onerahmet/openai-whisper-asr-webservice:latest"
+
+	// Check and pull image
+	if err := s.ensureImage(image); err != nil {
+		return err
+	}
+
+	model := s.manager.config.Services.Whisper.Model
+	if model == "" {
+		model = "base"
+	}
+
+	config := ContainerConfig{
+		Name:  "localcloud-whisper",
+		Image: image,
+		Env: map[string]string{
+			"ASR_MODEL":  model,
+			"ASR_ENGINE": "openai_whisper",
+		},
+		Ports: []PortBinding{
+			{
+				ContainerPort: "9000",
+				HostPort:      fmt.Sprintf("%d", s.manager.config.Services.Whisper.Port),
+				Protocol:      "tcp",
+			},
+		},
+		Volumes: []VolumeMount{
+			{
+				// Downloaded models survive container restarts
+				Source: fmt.Sprintf("localcloud_%s_whisper_models", s.manager.config.Project.Name),
+				Target: "/root/.cache",
+			},
+		},
+		Networks:      []string{fmt.Sprintf("localcloud_%s_default", s.manager.config.Project.Name)},
+		RestartPolicy: "unless-stopped",
+		HealthCheck: &HealthCheckConfig{
+			// Probe with Python, which the image is built on
+			Test:        []string{"CMD", "python3", "-c", "import urllib.request; urllib.request.urlopen('http://localhost:9000/docs')"},
+			Interval:    30,
+			Timeout:     10,
+			Retries:     5,
+			StartPeriod: 300,
+		},
+		Labels: map[string]string{
+			"com.localcloud.project": s.manager.config.Project.Name,
+			"com.localcloud.service": "whisper",
+		},
+	}
+
+	// Create and start container
+	containerID, err := s.manager.container.Create(config)
+	if err != nil {
+		return err
+	}
+
+	if err := s.manager.container.Start(containerID); err != nil {
+		return err
+	}
+
+	return s.waitForWhisper()
+}
+
+// waitForWhisper waits for the ASR API. The service downloads and loads its
+// model before listening, which takes minutes for the larger models on first
+// start.
+func (s *WhisperServiceStarter) waitForWhisper() error {
+	endpoint := fmt.Sprintf("http://localhost:%d/docs", s.manager.config.Services.Whisper.Port)
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	maxAttempts := 300
+	for i := 0; i < maxAttempts; i++ {
+		resp, err := client.Get(endpoint)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(1 * time.Second)
+	}
+	return fmt.Errorf("whisper failed to become ready after %d attempts", maxAttempts)
+}
+
+// ensureImage checks and pulls image if needed
+func (s *WhisperServiceStarter) ensureImage(image string) error {
+	starter := &AIServiceStarter{manager: s.manager}
+	return starter.ensureImage(image)
+}
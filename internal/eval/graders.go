@@ -0,0 +1,175 @@
+// internal/eval/graders.go
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/localcloud-sh/localcloud/internal/models"
+)
+
+// Grade is the outcome of one grader on a response
+type Grade struct {
+	Type   string  `json:"type"`
+	Passed bool    `json:"passed"`
+	Score  float64 `json:"score,omitempty"`  // Similarity of the response to the expected output
+	Detail string  `json:"detail,omitempty"` // Why the response failed, or the judge's reasoning
+}
+
+// judgePrompt asks the judge model for a verdict on its first line
+const judgePrompt = `You are grading the response of an AI assistant.
+
+Conversation:
+%s
+
+Response to grade:
+%s
+
+%s
+Reply with PASS or FAIL on the first line, followed by a one-sentence reason.`
+
+// grade runs a grader on a response. Errors are failures of the grader
+// itself, such as an unreachable judge model, not of the response.
+func grade(ctx context.Context, backend models.Backend, g Grader, c Case, conversation []models.ChatMessage, response string) (Grade, error) {
+	expected := g.Expected
+	if expected == "" {
+		expected = c.Expected
+	}
+	result := Grade{Type: g.Type}
+
+	switch g.Type {
+	case GraderExact:
+		got, want := strings.TrimSpace(response), strings.TrimSpace(expected)
+		if g.IgnoreCase {
+			result.Passed = strings.EqualFold(got, want)
+		} else {
+			result.Passed = got == want
+		}
+		if !result.Passed {
+			result.Detail = fmt.Sprintf("expected %q", want)
+		}
+
+	case GraderRegex:
+		re, err := compilePattern(g)
+		if err != nil {
+			return result, err
+		}
+		result.Passed = re.MatchString(response)
+		if !result.Passed {
+			result.Detail = fmt.Sprintf("does not match %s", g.Pattern)
+		}
+
+	case GraderJSONSchema:
+		var value interface{}
+		if err := json.Unmarshal([]byte(extractJSON(response)), &value); err != nil {
+			result.Detail = fmt.Sprintf("invalid JSON: %v", err)
+			return result, nil
+		}
+		if err := validateSchema(value, g.Schema, ""); err != nil {
+			result.Detail = err.Error()
+			return result, nil
+		}
+		result.Passed = true
+
+	case GraderSimilarity:
+		if g.Model == "" {
+			return result, fmt.Errorf("similarity grader has no embedding model")
+		}
+		vectors, err := backend.Embed(ctx, g.Model, []string{response, expected})
+		if err != nil {
+			return result, fmt.Errorf("failed to embed with %s: %w", g.Model, err)
+		}
+		if len(vectors) != 2 {
+			return result, fmt.Errorf("%s returned %d embeddings for 2 texts", g.Model, len(vectors))
+		}
+		result.Score = cosineSimilarity(vectors[0], vectors[1])
+		result.Passed = result.Score >= g.Threshold
+		if !result.Passed {
+			result.Detail = fmt.Sprintf("similarity %.3f is below %.2f", result.Score, g.Threshold)
+		}
+
+	case GraderJudge:
+		if g.Model == "" {
+			return result, fmt.Errorf("judge grader has no model")
+		}
+		var instructions strings.Builder
+		if g.Criteria != "" {
+			fmt.Fprintf(&instructions, "The response passes if it meets these criteria:\n%s\n", g.Criteria)
+		}
+		if expected != "" {
+			fmt.Fprintf(&instructions, "A reference answer, which need not be matched word for word:\n%s\n", expected)
+		}
+		prompt := fmt.Sprintf(judgePrompt, formatConversation(conversation), response, instructions.String())
+
+		verdict, err := backend.Generate(ctx, g.Model, []models.ChatMessage{{Role: "user", Content: prompt}},
+			map[string]interface{}{"temperature": 0, "num_predict": 128}, nil)
+		if err != nil {
+			return result, fmt.Errorf("judge %s failed: %w", g.Model, err)
+		}
+		result.Passed, result.Detail = parseVerdict(verdict)
+	}
+	return result, nil
+}
+
+// compilePattern compiles a regex grader's pattern
+func compilePattern(g Grader) (*regexp.Regexp, error) {
+	pattern := g.Pattern
+	if g.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.Compile(pattern)
+}
+
+// parseVerdict reads PASS or FAIL from the start of the judge's reply and
+// the reason after it
+func parseVerdict(verdict string) (bool, string) {
+	text := strings.TrimSpace(verdict)
+	line, rest, _ := strings.Cut(text, "\n")
+	line = strings.TrimLeft(line, "*#> ")
+	word := strings.ToUpper(line)
+
+	var passed bool
+	switch {
+	case strings.HasPrefix(word, "PASS"):
+		passed = true
+	case strings.HasPrefix(word, "FAIL"):
+	default:
+		return false, "unclear verdict: " + text
+	}
+
+	reason := strings.TrimSpace(rest)
+	if reason == "" {
+		reason = strings.Trim(line[4:], "*:.-– ")
+	}
+	return passed, reason
+}
+
+// formatConversation renders messages for the judge prompt
+func formatConversation(messages []models.ChatMessage) string {
+	var sb strings.Builder
+	for _, m := range messages {
+		fmt.Fprintf(&sb, "%s: %s\n", m.Role, m.Content)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// cosineSimilarity returns the cosine of the angle between two vectors
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,131 @@
+// internal/eval/results.go
+package eval
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Dir returns where the current project keeps the runs of a suite
+func Dir(suite string) string {
+	slug := strings.Trim(regexp.MustCompile(`[^a-zA-Z0-9._-]+`).ReplaceAllString(suite, "-"), "-")
+	if slug == "" {
+		slug = "suite"
+	}
+	return filepath.Join(".localcloud", "eval", slug)
+}
+
+// SaveRun writes a run to dir and returns the file path
+func SaveRun(dir string, run *Run) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, run.Timestamp.Format("20060102-150405")+".json")
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, data, 0644)
+}
+
+// LoadRuns returns the saved runs in dir, oldest first
+func LoadRuns(dir string) ([]*Run, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []*Run
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var run Run
+		if json.Unmarshal(data, &run) != nil || run.Timestamp.IsZero() {
+			continue
+		}
+		runs = append(runs, &run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Timestamp.Before(runs[j].Timestamp) })
+	return runs, nil
+}
+
+// Change is a case whose outcome for a model differs from the model's
+// previous run
+type Change struct {
+	Model    string    `json:"model"`
+	Case     string    `json:"case"`
+	Passed   bool      `json:"passed"` // false for a regression, true for a fix
+	Previous time.Time `json:"previous"`
+}
+
+// Previous returns the latest of runs before run that included model
+func Previous(runs []*Run, run *Run, model string) *Run {
+	for i := len(runs) - 1; i >= 0; i-- {
+		r := runs[i]
+		if !r.Timestamp.Before(run.Timestamp) {
+			continue
+		}
+		for _, m := range r.Models {
+			if m == model {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// Compare returns the cases that changed outcome since each model's previous
+// run. Cases that are new or were removed from the suite are not compared.
+func Compare(runs []*Run, run *Run) []Change {
+	var changes []Change
+	for _, model := range run.Models {
+		previous := Previous(runs, run, model)
+		if previous == nil {
+			continue
+		}
+
+		before := make(map[string]bool)
+		for _, r := range previous.Results {
+			if r.Model == model {
+				before[r.Case] = r.Passed
+			}
+		}
+		for _, r := range run.Results {
+			if r.Model != model {
+				continue
+			}
+			if passed, ok := before[r.Case]; ok && passed != r.Passed {
+				changes = append(changes, Change{Model: model, Case: r.Case, Passed: r.Passed, Previous: previous.Timestamp})
+			}
+		}
+	}
+	return changes
+}
+
+// PreviousSummary returns a model's summary in its previous run
+func PreviousSummary(runs []*Run, run *Run, model string) (ModelSummary, bool) {
+	previous := Previous(runs, run, model)
+	if previous == nil {
+		return ModelSummary{}, false
+	}
+	for _, s := range previous.Summary() {
+		if s.Model == model {
+			return s, true
+		}
+	}
+	return ModelSummary{}, false
+}
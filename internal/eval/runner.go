@@ -0,0 +1,170 @@
+// internal/eval/runner.go
+package eval
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/models"
+)
+
+// Options configures a run
+type Options struct {
+	Concurrency int              // Cases evaluated at once (default 4)
+	Progress    func(CaseResult) // Called as each case finishes, one call at a time
+}
+
+// CaseResult is a model's response to a case and its grades
+type CaseResult struct {
+	Model    string        `json:"model"`
+	Case     string        `json:"case"`
+	Response string        `json:"response"`
+	Passed   bool          `json:"passed"`
+	Grades   []Grade       `json:"grades,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"` // The model or a grader failed
+}
+
+// Run is the result of running a suite against one or more models
+type Run struct {
+	Suite     string       `json:"suite"`
+	Timestamp time.Time    `json:"timestamp"`
+	Models    []string     `json:"models"`
+	Results   []CaseResult `json:"results"`
+}
+
+// Execute runs every case of a suite against every model concurrently.
+// Results are in model order, then case order. Cancelling ctx stops the run;
+// cases that did not finish are recorded with the context's error.
+func Execute(ctx context.Context, backend models.Backend, suite *Suite, modelNames []string, opts Options) *Run {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+
+	run := &Run{
+		Suite:     suite.Name,
+		Timestamp: time.Now().UTC(),
+		Models:    modelNames,
+		Results:   make([]CaseResult, len(modelNames)*len(suite.Cases)),
+	}
+
+	type job struct {
+		index int
+		model string
+		c     Case
+	}
+	jobs := make(chan job)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				result := evaluate(ctx, backend, suite, j.model, j.c)
+				mu.Lock()
+				run.Results[j.index] = result
+				if opts.Progress != nil {
+					opts.Progress(result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	index := 0
+	for _, model := range modelNames {
+		for _, c := range suite.Cases {
+			select {
+			case jobs <- job{index: index, model: model, c: c}:
+			case <-ctx.Done():
+				run.Results[index] = CaseResult{Model: model, Case: c.Name, Error: ctx.Err().Error()}
+			}
+			index++
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return run
+}
+
+// evaluate runs one case against one model and grades the response. A case
+// passes when every grader passes.
+func evaluate(ctx context.Context, backend models.Backend, suite *Suite, model string, c Case) CaseResult {
+	result := CaseResult{Model: model, Case: c.Name}
+	conversation := suite.Conversation(c)
+
+	start := time.Now()
+	response, err := backend.Generate(ctx, model, conversation, suite.CaseOptions(c), nil)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Response = response
+
+	result.Passed = true
+	for _, g := range c.Graders {
+		outcome, err := grade(ctx, backend, g, c, conversation, response)
+		if err != nil {
+			result.Passed = false
+			result.Error = err.Error()
+			return result
+		}
+		result.Grades = append(result.Grades, outcome)
+		if !outcome.Passed {
+			result.Passed = false
+		}
+	}
+	return result
+}
+
+// ModelSummary is a model's pass rate in a run
+type ModelSummary struct {
+	Model    string
+	Passed   int
+	Total    int
+	Errors   int
+	Duration time.Duration // Mean time to generate a response
+}
+
+// PassRate returns the share of cases passed, from 0 to 1
+func (s ModelSummary) PassRate() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Passed) / float64(s.Total)
+}
+
+// Summary returns the pass rate of each model in the run's model order
+func (r *Run) Summary() []ModelSummary {
+	summaries := make([]ModelSummary, len(r.Models))
+	for i, model := range r.Models {
+		s := ModelSummary{Model: model}
+		var generated int
+		for _, result := range r.Results {
+			if result.Model != model {
+				continue
+			}
+			s.Total++
+			if result.Passed {
+				s.Passed++
+			}
+			if result.Error != "" {
+				s.Errors++
+			}
+			if result.Duration > 0 {
+				s.Duration += result.Duration
+				generated++
+			}
+		}
+		if generated > 0 {
+			s.Duration /= time.Duration(generated)
+		}
+		summaries[i] = s
+	}
+	return summaries
+}
@@ -0,0 +1,260 @@
+// internal/eval/schema.go
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadSchema reads a JSON schema from a JSON or YAML file
+func loadSchema(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	var schema map[string]interface{}
+	if err := yaml.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %w", path, err)
+	}
+	return schema, nil
+}
+
+// extractJSON returns the JSON document in a response. Models often wrap it
+// in a Markdown code fence or surround it with prose.
+func extractJSON(response string) string {
+	text := strings.TrimSpace(response)
+	if start := strings.Index(text, "```"); start >= 0 {
+		body := text[start+3:]
+		if newline := strings.IndexByte(body, '\n'); newline >= 0 {
+			body = body[newline+1:]
+		}
+		if end := strings.Index(body, "```"); end >= 0 {
+			return strings.TrimSpace(body[:end])
+		}
+	}
+	if json.Valid([]byte(text)) {
+		return text
+	}
+
+	// The outermost object or array
+	start := strings.IndexAny(text, "{[")
+	if start < 0 {
+		return text
+	}
+	closing := "}"
+	if text[start] == '[' {
+		closing = "]"
+	}
+	if end := strings.LastIndex(text, closing); end > start {
+		return text[start : end+1]
+	}
+	return text
+}
+
+// validateSchema checks a decoded JSON value against the common JSON Schema
+// keywords: type, enum, const, properties, required, additionalProperties,
+// items, min/maxItems, min/maxLength, pattern, minimum/maximum, anyOf, oneOf
+// and allOf. It returns the first violation.
+func validateSchema(value interface{}, schema map[string]interface{}, path string) error {
+	if path == "" {
+		path = "$"
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(value, t) {
+		return fmt.Errorf("%s: expected %v, got %s", path, t, jsonType(value))
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if jsonEqual(value, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, value, enum)
+		}
+	}
+	if c, ok := schema["const"]; ok && !jsonEqual(value, c) {
+		return fmt.Errorf("%s: expected %v", path, c)
+	}
+
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		subs, ok := schema[key].([]interface{})
+		if !ok {
+			continue
+		}
+		passed := 0
+		var firstErr error
+		for _, sub := range subs {
+			subSchema, _ := sub.(map[string]interface{})
+			if err := validateSchema(value, subSchema, path); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+			} else {
+				passed++
+			}
+		}
+		switch {
+		case key == "allOf" && passed < len(subs):
+			return firstErr
+		case key == "anyOf" && passed == 0:
+			return fmt.Errorf("%s: matches none of anyOf (%v)", path, firstErr)
+		case key == "oneOf" && passed != 1:
+			return fmt.Errorf("%s: matches %d of oneOf, expected 1", path, passed)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, r := range required {
+				name, _ := r.(string)
+				if _, ok := v[name]; !ok {
+					return fmt.Errorf("%s: missing required property %q", path, name)
+				}
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if sub, ok := properties[key].(map[string]interface{}); ok {
+				if err := validateSchema(v[key], sub, path+"."+key); err != nil {
+					return err
+				}
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					return fmt.Errorf("%s: unexpected property %q", path, key)
+				}
+			case map[string]interface{}:
+				if err := validateSchema(v[key], additional, path+"."+key); err != nil {
+					return err
+				}
+			}
+		}
+
+	case []interface{}:
+		if n, ok := number(schema["minItems"]); ok && float64(len(v)) < n {
+			return fmt.Errorf("%s: expected at least %v items, got %d", path, n, len(v))
+		}
+		if n, ok := number(schema["maxItems"]); ok && float64(len(v)) > n {
+			return fmt.Errorf("%s: expected at most %v items, got %d", path, n, len(v))
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				if err := validateSchema(item, items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+
+	case string:
+		length := float64(len([]rune(v)))
+		if n, ok := number(schema["minLength"]); ok && length < n {
+			return fmt.Errorf("%s: shorter than %v characters", path, n)
+		}
+		if n, ok := number(schema["maxLength"]); ok && length > n {
+			return fmt.Errorf("%s: longer than %v characters", path, n)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("%s: invalid pattern %q in schema: %w", path, pattern, err)
+			}
+			if !re.MatchString(v) {
+				return fmt.Errorf("%s: %q does not match %s", path, v, pattern)
+			}
+		}
+
+	case float64:
+		if n, ok := number(schema["minimum"]); ok && v < n {
+			return fmt.Errorf("%s: %v is less than %v", path, v, n)
+		}
+		if n, ok := number(schema["maximum"]); ok && v > n {
+			return fmt.Errorf("%s: %v is greater than %v", path, v, n)
+		}
+	}
+	return nil
+}
+
+// matchesType reports whether value has the schema type, which is a name or
+// a list of names
+func matchesType(value interface{}, t interface{}) bool {
+	if list, ok := t.([]interface{}); ok {
+		for _, item := range list {
+			if matchesType(value, item) {
+				return true
+			}
+		}
+		return false
+	}
+
+	name, _ := t.(string)
+	actual := jsonType(value)
+	if name == "number" && actual == "integer" {
+		return true
+	}
+	return name == actual
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// number reads a numeric schema keyword, which YAML may decode as an int
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// jsonEqual compares a decoded JSON value with a schema value, which YAML
+// may have decoded with different numeric types
+func jsonEqual(a, b interface{}) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
@@ -0,0 +1,253 @@
+// internal/eval/suite.go
+package eval
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Grader types
+const (
+	GraderExact      = "exact"
+	GraderRegex      = "regex"
+	GraderJSONSchema = "json_schema"
+	GraderSimilarity = "similarity"
+	GraderJudge      = "judge"
+)
+
+// Suite is a set of eval cases loaded from YAML
+type Suite struct {
+	Name           string                 `yaml:"name" json:"name"`
+	Description    string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	System         string                 `yaml:"system,omitempty" json:"system,omitempty"`   // Default system prompt
+	Options        map[string]interface{} `yaml:"options,omitempty" json:"options,omitempty"` // Default model options
+	JudgeModel     string                 `yaml:"judge_model,omitempty" json:"judge_model,omitempty"`
+	EmbeddingModel string                 `yaml:"embedding_model,omitempty" json:"embedding_model,omitempty"`
+	Cases          []Case                 `yaml:"cases" json:"cases"`
+}
+
+// Case is a prompt or conversation and the graders its response must pass
+type Case struct {
+	Name     string                 `yaml:"name" json:"name"`
+	Prompt   string                 `yaml:"prompt,omitempty" json:"prompt,omitempty"`
+	Messages []models.ChatMessage   `yaml:"messages,omitempty" json:"messages,omitempty"`
+	System   string                 `yaml:"system,omitempty" json:"system,omitempty"`
+	Expected string                 `yaml:"expected,omitempty" json:"expected,omitempty"`
+	Options  map[string]interface{} `yaml:"options,omitempty" json:"options,omitempty"`
+	Graders  []Grader               `yaml:"graders,omitempty" json:"graders,omitempty"`
+}
+
+// Grader checks a response. Fields apply to the types that use them.
+type Grader struct {
+	Type       string                 `yaml:"type" json:"type"`
+	Expected   string                 `yaml:"expected,omitempty" json:"expected,omitempty"` // Overrides the case's expected output
+	IgnoreCase bool                   `yaml:"ignore_case,omitempty" json:"ignore_case,omitempty"`
+	Pattern    string                 `yaml:"pattern,omitempty" json:"pattern,omitempty"`         // regex
+	Schema     map[string]interface{} `yaml:"schema,omitempty" json:"schema,omitempty"`           // json_schema
+	SchemaFile string                 `yaml:"schema_file,omitempty" json:"schema_file,omitempty"` // json_schema, relative to the suite
+	Threshold  float64                `yaml:"threshold,omitempty" json:"threshold,omitempty"`     // similarity
+	Model      string                 `yaml:"model,omitempty" json:"model,omitempty"`             // similarity or judge model
+	Criteria   string                 `yaml:"criteria,omitempty" json:"criteria,omitempty"`       // judge
+}
+
+// LoadSuite reads and validates a suite. Schema files are resolved relative
+// to the suite file.
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suite: %w", err)
+	}
+
+	var suite Suite
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("invalid suite %s: %w", path, err)
+	}
+	if suite.Name == "" {
+		suite.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if len(suite.Cases) == 0 {
+		return nil, fmt.Errorf("no cases in %s", path)
+	}
+
+	seen := make(map[string]bool)
+	for i := range suite.Cases {
+		c := &suite.Cases[i]
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("case-%d", i+1)
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("case %s in %s is defined twice", c.Name, path)
+		}
+		seen[c.Name] = true
+
+		if err := suite.validate(c, filepath.Dir(path)); err != nil {
+			return nil, fmt.Errorf("case %s in %s: %w", c.Name, path, err)
+		}
+	}
+	return &suite, nil
+}
+
+// validate checks a case and fills in its defaults
+func (s *Suite) validate(c *Case, dir string) error {
+	if strings.TrimSpace(c.Prompt) == "" && len(c.Messages) == 0 {
+		return fmt.Errorf("needs a prompt or messages")
+	}
+	if c.Prompt != "" && len(c.Messages) > 0 {
+		return fmt.Errorf("has both a prompt and messages")
+	}
+	for _, m := range c.Messages {
+		if m.Role != "system" && m.Role != "user" && m.Role != "assistant" {
+			return fmt.Errorf("message role %q must be system, user or assistant", m.Role)
+		}
+	}
+
+	// A case with an expected output and no graders is an exact match
+	if len(c.Graders) == 0 {
+		if c.Expected == "" {
+			return fmt.Errorf("needs graders or an expected output")
+		}
+		c.Graders = []Grader{{Type: GraderExact}}
+	}
+
+	for i := range c.Graders {
+		g := &c.Graders[i]
+		expected := g.Expected
+		if expected == "" {
+			expected = c.Expected
+		}
+
+		switch g.Type {
+		case GraderExact:
+			if expected == "" {
+				return fmt.Errorf("exact grader needs an expected output")
+			}
+		case GraderRegex:
+			if g.Pattern == "" {
+				g.Pattern = expected
+			}
+			if g.Pattern == "" {
+				return fmt.Errorf("regex grader needs a pattern")
+			}
+			if _, err := compilePattern(*g); err != nil {
+				return fmt.Errorf("invalid regex %q: %w", g.Pattern, err)
+			}
+		case GraderJSONSchema:
+			if g.SchemaFile != "" {
+				if !filepath.IsAbs(g.SchemaFile) {
+					g.SchemaFile = filepath.Join(dir, g.SchemaFile)
+				}
+				schema, err := loadSchema(g.SchemaFile)
+				if err != nil {
+					return err
+				}
+				g.Schema = schema
+			}
+			if g.Schema == nil {
+				// Any valid JSON passes
+				g.Schema = map[string]interface{}{}
+			}
+		case GraderSimilarity:
+			if expected == "" {
+				return fmt.Errorf("similarity grader needs an expected output")
+			}
+			if g.Threshold == 0 {
+				g.Threshold = 0.8
+			}
+			if g.Threshold < 0 || g.Threshold > 1 {
+				return fmt.Errorf("similarity threshold must be between 0 and 1")
+			}
+			if g.Model == "" {
+				g.Model = s.EmbeddingModel
+			}
+		case GraderJudge:
+			if g.Criteria == "" && expected == "" {
+				return fmt.Errorf("judge grader needs criteria or an expected output")
+			}
+			if g.Model == "" {
+				g.Model = s.JudgeModel
+			}
+		case "":
+			return fmt.Errorf("grader %d has no type", i+1)
+		default:
+			return fmt.Errorf("unknown grader type %q (use exact, regex, json_schema, similarity or judge)", g.Type)
+		}
+	}
+	return nil
+}
+
+// Conversation returns the messages sent to a model for a case
+func (s *Suite) Conversation(c Case) []models.ChatMessage {
+	system := c.System
+	if system == "" {
+		system = s.System
+	}
+
+	var messages []models.ChatMessage
+	if system != "" && (len(c.Messages) == 0 || c.Messages[0].Role != "system") {
+		messages = append(messages, models.ChatMessage{Role: "system", Content: system})
+	}
+	if c.Prompt != "" {
+		return append(messages, models.ChatMessage{Role: "user", Content: c.Prompt})
+	}
+	return append(messages, c.Messages...)
+}
+
+// CaseOptions returns the model options for a case: the suite's defaults
+// overridden by the case's own
+func (s *Suite) CaseOptions(c Case) map[string]interface{} {
+	options := make(map[string]interface{}, len(s.Options)+len(c.Options))
+	for k, v := range s.Options {
+		options[k] = v
+	}
+	for k, v := range c.Options {
+		options[k] = v
+	}
+	return options
+}
+
+// GraderModels returns the judge and embedding models the suite's graders use
+func (s *Suite) GraderModels() []string {
+	var names []string
+	for _, c := range s.Cases {
+		for _, g := range c.Graders {
+			if (g.Type == GraderJudge || g.Type == GraderSimilarity) && g.Model != "" {
+				names = append(names, g.Model)
+			}
+		}
+	}
+	return models.UniqueModels(names)
+}
+
+// SetDefaultModels sets the model of judge and similarity graders that
+// name none in the suite
+func (s *Suite) SetDefaultModels(judge, embedding string) {
+	for i := range s.Cases {
+		for j := range s.Cases[i].Graders {
+			g := &s.Cases[i].Graders[j]
+			switch {
+			case g.Model != "":
+			case g.Type == GraderJudge:
+				g.Model = judge
+			case g.Type == GraderSimilarity:
+				g.Model = embedding
+			}
+		}
+	}
+}
+
+// NeedsModel returns the grader type that lacks a model, if any
+func (s *Suite) NeedsModel() string {
+	for _, c := range s.Cases {
+		for _, g := range c.Graders {
+			if (g.Type == GraderJudge || g.Type == GraderSimilarity) && g.Model == "" {
+				return g.Type
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,547 @@
+// internal/gateway/cache.go
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/services/redis"
+)
+
+// Headers that control and report caching. Requests send CacheHeader as
+// bypass (neither read nor write the cache), refresh (skip the cached
+// response and replace it) or no-store (read but do not write), and
+// CacheTTLHeader to keep their response for a different time. Responses
+// carry CacheHeader as HIT, MISS or BYPASS, and CacheSimilarityHeader on
+// hits.
+const (
+	CacheHeader           = "X-LocalCloud-Cache"
+	CacheTTLHeader        = "X-LocalCloud-Cache-TTL"
+	CacheSimilarityHeader = "X-LocalCloud-Cache-Similarity"
+)
+
+// Response cache defaults
+const (
+	DefaultCacheTTL        = 24 * time.Hour
+	DefaultCacheSimilarity = 0.95
+)
+
+// Redis keys of the response cache
+const (
+	cachePrefix      = "localcloud:gateway:cache:"
+	cacheEntryPrefix = cachePrefix + "entry:" // A cached response, by exact key
+	cacheScopePrefix = cachePrefix + "scope:" // Prompt embeddings of the responses in a scope
+	cacheStatsKey    = cachePrefix + "stats"
+)
+
+// Cache modes a request selects with CacheHeader
+const (
+	cacheBypass  = "bypass"
+	cacheRefresh = "refresh"
+	cacheNoStore = "no-store"
+)
+
+// CacheOptions configures a response cache
+type CacheOptions struct {
+	TTL            time.Duration // How long responses are kept (default 24h)
+	Similarity     float64       // Minimum similarity of near-duplicate prompts (default 0.95, 1 or more for exact matches only)
+	EmbeddingModel string        // Model that embeds prompts; empty for exact matches only
+}
+
+// ResponseCache keeps completions in Redis. A response is keyed by its
+// scope — the endpoint, model, parameters and earlier messages — and its
+// normalized prompt. A request whose exact key is missing still hits when
+// its prompt's embedding is similar enough to one cached in the same scope.
+type ResponseCache struct {
+	client *redis.Client
+	opts   CacheOptions
+}
+
+// NewResponseCache creates a response cache in the Redis server of client
+func NewResponseCache(client *redis.Client, opts CacheOptions) *ResponseCache {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultCacheTTL
+	}
+	if opts.Similarity <= 0 {
+		opts.Similarity = DefaultCacheSimilarity
+	}
+	return &ResponseCache{client: client, opts: opts}
+}
+
+// Options returns the cache's options with defaults applied
+func (c *ResponseCache) Options() CacheOptions {
+	return c.opts
+}
+
+// Ping checks that Redis is reachable
+func (c *ResponseCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx)
+}
+
+// semantic reports whether near-duplicate prompts are matched
+func (c *ResponseCache) semantic() bool {
+	return c.opts.EmbeddingModel != "" && c.opts.Similarity < 1
+}
+
+// CacheStats counts how the cache answered requests
+type CacheStats struct {
+	Hits         int64 `json:"hits"`          // Includes semantic hits
+	SemanticHits int64 `json:"semantic_hits"` // Hits on a near-duplicate prompt
+	Misses       int64 `json:"misses"`
+	Bypassed     int64 `json:"bypassed"` // Requests that skipped the cache
+	Stored       int64 `json:"stored"`
+	Errors       int64 `json:"errors"`   // Redis or embedding failures, served as misses
+	SavedMs      int64 `json:"saved_ms"` // Generation time hits avoided
+	Entries      int   `json:"entries"`  // Responses currently cached
+}
+
+// HitRate returns the share of cache lookups that hit, from 0 to 1
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Stats returns the counters since they were last reset and the number of
+// cached responses
+func (c *ResponseCache) Stats(ctx context.Context) (CacheStats, error) {
+	fields, err := c.client.HGetAll(ctx, cacheStatsKey)
+	if err != nil {
+		return CacheStats{}, err
+	}
+	counter := func(name string) int64 {
+		n, _ := strconv.ParseInt(fields[name], 10, 64)
+		return n
+	}
+	stats := CacheStats{
+		Hits:         counter("hits"),
+		SemanticHits: counter("semantic_hits"),
+		Misses:       counter("misses"),
+		Bypassed:     counter("bypassed"),
+		Stored:       counter("stored"),
+		Errors:       counter("errors"),
+		SavedMs:      counter("saved_ms"),
+	}
+
+	keys, err := c.client.Scan(ctx, cacheEntryPrefix+"*")
+	if err != nil {
+		return stats, err
+	}
+	stats.Entries = len(keys)
+	return stats, nil
+}
+
+// Clear deletes every cached response and returns how many there were.
+// resetStats also zeroes the counters.
+func (c *ResponseCache) Clear(ctx context.Context, resetStats bool) (int, error) {
+	entries, err := c.client.Scan(ctx, cacheEntryPrefix+"*")
+	if err != nil {
+		return 0, err
+	}
+	scopes, err := c.client.Scan(ctx, cacheScopePrefix+"*")
+	if err != nil {
+		return 0, err
+	}
+	keys := append(entries, scopes...)
+	if resetStats {
+		keys = append(keys, cacheStatsKey)
+	}
+	for start := 0; start < len(keys); start += 500 {
+		end := min(start+500, len(keys))
+		if _, err := c.client.Del(ctx, keys[start:end]...); err != nil {
+			return 0, err
+		}
+	}
+	return len(entries), nil
+}
+
+// count increments a stats counter, ignoring failures
+func (c *ResponseCache) count(ctx context.Context, counter string, n int64) {
+	c.client.HIncrBy(ctx, cacheStatsKey, counter, n)
+}
+
+// cachedResponse is a completion stored in the cache
+type cachedResponse struct {
+	Content          string    `json:"content"`
+	FinishReason     string    `json:"finish_reason"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	DurationMs       int64     `json:"duration_ms"` // Time the model took to respond
+	Prompt           string    `json:"prompt"`
+	Created          time.Time `json:"created"`
+}
+
+// response returns the cached completion as an Ollama chat or generate
+// response
+func (c cachedResponse) response() ollamaResponse {
+	return ollamaResponse{
+		Message:         ollamaMessage{Role: "assistant", Content: c.Content},
+		Response:        c.Content,
+		Done:            true,
+		DoneReason:      c.FinishReason,
+		PromptEvalCount: c.PromptTokens,
+		EvalCount:       c.CompletionTokens,
+	}
+}
+
+// cacheRequest is what identifies a completion request in the cache
+type cacheRequest struct {
+	Endpoint  string                 `json:"endpoint"`
+	Model     string                 `json:"model"`
+	Options   map[string]interface{} `json:"options"`
+	Format    interface{}            `json:"format,omitempty"`
+	Suffix    string                 `json:"suffix,omitempty"`
+	Messages  []ollamaMessage        `json:"messages,omitempty"` // Every chat message before the prompt
+	Role      string                 `json:"role,omitempty"`     // Role of the prompt message
+	Prompt    string                 `json:"-"`
+	Cacheable bool                   `json:"-"` // False for requests with images
+}
+
+// chatCacheRequest identifies a chat request: its last message is the prompt
+func chatCacheRequest(model string, messages []ollamaMessage, options map[string]interface{}, format interface{}) cacheRequest {
+	req := cacheRequest{Endpoint: "chat", Model: model, Options: options, Format: format, Cacheable: true}
+	for i, m := range messages {
+		if len(m.Images) > 0 {
+			req.Cacheable = false
+		}
+		m.Content = normalizePrompt(m.Content)
+		if i == len(messages)-1 {
+			req.Role, req.Prompt = m.Role, m.Content
+		} else {
+			req.Messages = append(req.Messages, m)
+		}
+	}
+	return req
+}
+
+// completionCacheRequest identifies a completions request
+func completionCacheRequest(model, prompt, suffix string, options map[string]interface{}) cacheRequest {
+	return cacheRequest{
+		Endpoint:  "completions",
+		Model:     model,
+		Options:   options,
+		Suffix:    suffix,
+		Prompt:    normalizePrompt(prompt),
+		Cacheable: true,
+	}
+}
+
+// normalizePrompt collapses whitespace so trivially different prompts share
+// a key. Case is kept, since it can change the answer ("US" and "us"); prompts
+// that differ only in case can still hit through the semantic lookup.
+func normalizePrompt(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// scope hashes everything but the prompt
+func (r cacheRequest) scope() string {
+	data, _ := json.Marshal(r)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// key hashes the scope and the prompt
+func (r cacheRequest) key(scope string) string {
+	sum := sha256.Sum256([]byte(scope + "\x00" + r.Prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheLookup is a request's use of the cache: where its response is
+// stored and how it was looked up
+type cacheLookup struct {
+	cache  *ResponseCache
+	mode   string
+	ttl    time.Duration
+	scope  string
+	key    string
+	prompt string
+	vector []float32 // Embedding of the prompt, once computed
+}
+
+// lookupCache finds a cached response for a request. It sets the cache
+// response headers and records the outcome in the request log. The returned
+// lookup stores the response of a miss; it is nil when the request does not
+// use the cache. Cache failures are counted and served as misses.
+func (g *Gateway) lookupCache(w http.ResponseWriter, r *http.Request, req cacheRequest) (*cacheLookup, *cachedResponse) {
+	cache := g.opts.Cache
+	if cache == nil {
+		return nil, nil
+	}
+	ctx := r.Context()
+	entry := requestLog(r)
+
+	mode := cacheMode(r.Header)
+	if mode == cacheBypass || !req.Cacheable {
+		w.Header().Set(CacheHeader, "BYPASS")
+		entry.Cache = "bypass"
+		cache.count(ctx, "bypassed", 1)
+		return nil, nil
+	}
+
+	lookup := &cacheLookup{cache: cache, mode: mode, ttl: cache.opts.TTL, prompt: req.Prompt}
+	if ttl := r.Header.Get(CacheTTLHeader); ttl != "" {
+		if d, err := parseCacheTTL(ttl); err == nil {
+			lookup.ttl = d
+		}
+	}
+	lookup.scope = req.scope()
+	lookup.key = req.key(lookup.scope)
+
+	w.Header().Set(CacheHeader, "MISS")
+	if mode == cacheRefresh {
+		entry.Cache = "refresh"
+		return lookup, nil
+	}
+	entry.Cache = "miss"
+
+	cached, similarity, err := g.findCached(ctx, lookup)
+	if err != nil {
+		cache.count(ctx, "errors", 1)
+	}
+	if cached == nil {
+		cache.count(ctx, "misses", 1)
+		return lookup, nil
+	}
+
+	w.Header().Set(CacheHeader, "HIT")
+	w.Header().Set(CacheSimilarityHeader, strconv.FormatFloat(similarity, 'f', 4, 64))
+	entry.Cache = "hit"
+	cache.count(ctx, "hits", 1)
+	if similarity < 1 {
+		cache.count(ctx, "semantic_hits", 1)
+	}
+	cache.count(ctx, "saved_ms", cached.DurationMs)
+	return lookup, cached
+}
+
+// cacheMode returns the cache mode a request selects with CacheHeader, or
+// failing that with a standard Cache-Control no-store or no-cache. It is
+// empty for a normal lookup.
+func cacheMode(header http.Header) string {
+	mode := strings.ToLower(strings.TrimSpace(header.Get(CacheHeader)))
+	if mode != "" {
+		return mode
+	}
+	cacheControl := strings.ToLower(header.Get("Cache-Control"))
+	switch {
+	case strings.Contains(cacheControl, "no-store"):
+		return cacheBypass
+	case strings.Contains(cacheControl, "no-cache"):
+		return cacheRefresh
+	}
+	return ""
+}
+
+// findCached returns the response cached under the lookup's key or, failing
+// that, the one whose prompt is most similar within the scope
+func (g *Gateway) findCached(ctx context.Context, lookup *cacheLookup) (*cachedResponse, float64, error) {
+	cache := lookup.cache
+	if cached, err := cache.get(ctx, lookup.key); cached != nil || err != nil {
+		return cached, 1, err
+	}
+	if !cache.semantic() {
+		return nil, 0, nil
+	}
+
+	vector, err := g.embedPrompt(ctx, lookup)
+	if err != nil {
+		return nil, 0, err
+	}
+	fields, err := cache.client.HGetAll(ctx, cacheScopePrefix+lookup.scope)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var best string
+	bestScore := cache.opts.Similarity
+	var expired []string
+	now := time.Now().Unix()
+	for key, value := range fields {
+		expires, candidate, ok := decodeScopeEntry(value)
+		if !ok || expires < now {
+			expired = append(expired, key)
+			continue
+		}
+		if score := cosineSimilarity(vector, candidate); score >= bestScore {
+			best, bestScore = key, score
+		}
+	}
+	cache.client.HDel(ctx, cacheScopePrefix+lookup.scope, expired...)
+	if best == "" {
+		return nil, 0, nil
+	}
+
+	cached, err := cache.get(ctx, best)
+	if cached == nil {
+		cache.client.HDel(ctx, cacheScopePrefix+lookup.scope, best)
+	}
+	return cached, bestScore, err
+}
+
+// get reads the response cached under key
+func (c *ResponseCache) get(ctx context.Context, key string) (*cachedResponse, error) {
+	value, found, err := c.client.Get(ctx, cacheEntryPrefix+key)
+	if err != nil || !found {
+		return nil, err
+	}
+	var cached cachedResponse
+	if err := json.Unmarshal([]byte(value), &cached); err != nil {
+		return nil, nil
+	}
+	return &cached, nil
+}
+
+// store caches the response to a miss. Lookups of no-store requests, and
+// nil lookups, store nothing.
+func (g *Gateway) storeCache(ctx context.Context, lookup *cacheLookup, result ollamaResponse, content string, duration time.Duration) {
+	if lookup == nil || lookup.mode == cacheNoStore {
+		return
+	}
+	cache := lookup.cache
+
+	data, err := json.Marshal(cachedResponse{
+		Content:          content,
+		FinishReason:     result.finishReason(),
+		PromptTokens:     result.PromptEvalCount,
+		CompletionTokens: result.EvalCount,
+		DurationMs:       duration.Milliseconds(),
+		Prompt:           lookup.prompt,
+		Created:          time.Now().UTC(),
+	})
+	if err != nil {
+		return
+	}
+	if err := cache.client.Set(ctx, cacheEntryPrefix+lookup.key, string(data), lookup.ttl); err != nil {
+		cache.count(ctx, "errors", 1)
+		return
+	}
+	cache.count(ctx, "stored", 1)
+
+	if !cache.semantic() {
+		return
+	}
+	vector, err := g.embedPrompt(ctx, lookup)
+	if err != nil {
+		cache.count(ctx, "errors", 1)
+		return
+	}
+	scopeKey := cacheScopePrefix + lookup.scope
+	expires := time.Now().Add(lookup.ttl).Unix()
+	err = cache.client.HSet(ctx, scopeKey, map[string]string{lookup.key: encodeScopeEntry(expires, vector)})
+	if err == nil {
+		// The scope lives as long as its newest response
+		err = cache.client.Expire(ctx, scopeKey, lookup.ttl)
+	}
+	if err != nil {
+		cache.count(ctx, "errors", 1)
+	}
+}
+
+// embedPrompt embeds the lookup's prompt with the cache's embedding model,
+// once per request
+func (g *Gateway) embedPrompt(ctx context.Context, lookup *cacheLookup) ([]float32, error) {
+	if lookup.vector != nil {
+		return lookup.vector, nil
+	}
+
+	model := lookup.cache.opts.EmbeddingModel
+	payload := map[string]interface{}{"model": model, "input": []string{lookup.prompt}}
+	g.setKeepAlive(payload, model)
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", g.opts.OllamaURL+"/api/embed", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Embeddings [][]float32 `json:"embeddings"`
+		Error      string      `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("failed to embed prompt with %s: %s", model, result.Error)
+	}
+	if len(result.Embeddings) == 0 || len(result.Embeddings[0]) == 0 {
+		return nil, fmt.Errorf("%s returned no embedding", model)
+	}
+	lookup.vector = result.Embeddings[0]
+	return lookup.vector, nil
+}
+
+// parseCacheTTL reads a TTL as a Go duration such as 10m, or as seconds
+func parseCacheTTL(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid cache TTL %q", value)
+	}
+	return d, nil
+}
+
+// encodeScopeEntry packs a prompt embedding with the Unix time its response
+// expires, as "<expires>:<base64 little-endian float32s>"
+func encodeScopeEntry(expires int64, vector []float32) string {
+	return strconv.FormatInt(expires, 10) + ":" + encodeBase64Vector(vector)
+}
+
+func decodeScopeEntry(value string) (int64, []float32, bool) {
+	expiresText, encoded, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, nil, false
+	}
+	expires, err := strconv.ParseInt(expiresText, 10, 64)
+	if err != nil {
+		return 0, nil, false
+	}
+	buf, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(buf)%4 != 0 {
+		return 0, nil, false
+	}
+	vector := make([]float32, len(buf)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	return expires, vector, true
+}
+
+// cosineSimilarity returns the cosine of the angle between two vectors
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,188 @@
+// internal/gateway/cache_test.go
+package gateway
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNormalizePrompt(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"hello", "hello"},
+		{"  hello   world \n", "hello world"},
+		{"a\tb\r\nc", "a b c"},
+		{"Keep CASE", "Keep CASE"},
+		{"   ", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizePrompt(tt.in); got != tt.want {
+			t.Errorf("normalizePrompt(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCacheRequestScopeAndKey(t *testing.T) {
+	base := func() cacheRequest {
+		return chatCacheRequest("llama3.2", []ollamaMessage{
+			{Role: "system", Content: "Be brief."},
+			{Role: "user", Content: "What is Go?"},
+		}, map[string]interface{}{"temperature": 0.0}, nil)
+	}
+	ref := base()
+	refScope := ref.scope()
+	refKey := ref.key(refScope)
+
+	tests := []struct {
+		name      string
+		req       cacheRequest
+		sameScope bool
+		sameKey   bool
+	}{
+		{"identical", base(), true, true},
+		{"whitespace in prompt", chatCacheRequest("llama3.2", []ollamaMessage{
+			{Role: "system", Content: "Be  brief."},
+			{Role: "user", Content: " What is\nGo? "},
+		}, map[string]interface{}{"temperature": 0.0}, nil), true, true},
+		{"case in prompt", chatCacheRequest("llama3.2", []ollamaMessage{
+			{Role: "system", Content: "Be brief."},
+			{Role: "user", Content: "what is go?"},
+		}, map[string]interface{}{"temperature": 0.0}, nil), true, false},
+		{"other model", func() cacheRequest { r := base(); r.Model = "mistral"; return r }(), false, false},
+		{"other options", func() cacheRequest {
+			r := base()
+			r.Options = map[string]interface{}{"temperature": 0.7}
+			return r
+		}(), false, false},
+		{"other history", chatCacheRequest("llama3.2", []ollamaMessage{
+			{Role: "system", Content: "Be verbose."},
+			{Role: "user", Content: "What is Go?"},
+		}, map[string]interface{}{"temperature": 0.0}, nil), false, false},
+		{"completion endpoint", completionCacheRequest("llama3.2", "What is Go?", "", map[string]interface{}{"temperature": 0.0}), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := tt.req.scope()
+			if (scope == refScope) != tt.sameScope {
+				t.Errorf("same scope = %v, want %v", scope == refScope, tt.sameScope)
+			}
+			if key := tt.req.key(scope); (key == refKey) != tt.sameKey {
+				t.Errorf("same key = %v, want %v", key == refKey, tt.sameKey)
+			}
+		})
+	}
+}
+
+func TestChatCacheRequestImages(t *testing.T) {
+	req := chatCacheRequest("llava", []ollamaMessage{
+		{Role: "user", Content: "What is this?", Images: []string{"aGk="}},
+	}, nil, nil)
+	if req.Cacheable {
+		t.Error("request with images is cacheable")
+	}
+	if req.Prompt != "What is this?" || req.Role != "user" || len(req.Messages) != 0 {
+		t.Errorf("prompt = %q, role = %q, %d earlier messages", req.Prompt, req.Role, len(req.Messages))
+	}
+}
+
+func TestParseCacheTTL(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"60", time.Minute, false},
+		{"10m", 10 * time.Minute, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"0", 0, true},
+		{"-5", 0, true},
+		{"-1m", 0, true},
+		{"soon", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseCacheTTL(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCacheTTL(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseCacheTTL(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestScopeEntryRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		expires int64
+		vector  []float32
+	}{
+		{"vector", 1700000000, []float32{0.5, -1.25, 3}},
+		{"empty vector", 42, nil},
+		{"negative expiry", -1, []float32{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expires, vector, ok := decodeScopeEntry(encodeScopeEntry(tt.expires, tt.vector))
+			if !ok {
+				t.Fatal("decode failed")
+			}
+			if expires != tt.expires {
+				t.Errorf("expires = %d, want %d", expires, tt.expires)
+			}
+			if len(vector) != len(tt.vector) {
+				t.Fatalf("vector has %d values, want %d", len(vector), len(tt.vector))
+			}
+			for i := range vector {
+				if vector[i] != tt.vector[i] {
+					t.Errorf("vector[%d] = %v, want %v", i, vector[i], tt.vector[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeScopeEntryInvalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"1700000000",
+		"soon:AAAAAA==",
+		"1700000000:not base64!",
+		"1700000000:AAA=", // 2 bytes, not a whole float32
+	} {
+		if _, _, ok := decodeScopeEntry(value); ok {
+			t.Errorf("decodeScopeEntry(%q) succeeded", value)
+		}
+	}
+}
+
+func TestCacheMode(t *testing.T) {
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   string
+	}{
+		{"none", nil, ""},
+		{"bypass", map[string]string{CacheHeader: "bypass"}, cacheBypass},
+		{"refresh, any case", map[string]string{CacheHeader: " Refresh "}, cacheRefresh},
+		{"no-store", map[string]string{CacheHeader: "no-store"}, cacheNoStore},
+		{"cache-control no-store", map[string]string{"Cache-Control": "no-store"}, cacheBypass},
+		{"cache-control no-cache", map[string]string{"Cache-Control": "max-age=0, No-Cache"}, cacheRefresh},
+		{"header wins over cache-control", map[string]string{CacheHeader: "no-store", "Cache-Control": "no-cache"}, cacheNoStore},
+		{"unrelated cache-control", map[string]string{"Cache-Control": "max-age=60"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.header {
+				header.Set(k, v)
+			}
+			if got := cacheMode(header); got != tt.want {
+				t.Errorf("cacheMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+// internal/gateway/gateway.go
+// Package gateway implements an OpenAI-compatible API in front of Ollama so
+// applications written against the OpenAI SDKs run locally by changing only
+// their base URL. It also passes Ollama's native API through, so every
+// request to the AI service can be logged and accounted in one place.
+package gateway
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/logging"
+	"github.com/localcloud-sh/localcloud/internal/models"
+)
+
+// DefaultPort is the port lc gateway serve listens on by default
+const DefaultPort = 8092
+
+// maxBodySize limits request bodies; chat requests may carry base64 images
+const maxBodySize = 64 << 20
+
+// Options configures the gateway
+type Options struct {
+	OllamaURL      string
+	Aliases        []config.ModelAlias
+	DefaultModel   string                  // Used when a request names no model
+	EmbeddingModel string                  // Used for embeddings when a request names no model
+	Keys           *KeyStore               // Nil or empty leaves the gateway open
+	KeepAlive      *models.KeepAlivePolicy // Per-model keep_alive sent to Ollama
+	Log            *logging.RotatingWriter // Optional request log
+	Cache          *ResponseCache          // Optional cache of completions
+	// Optional accounting of tokens and latency per model and caller
+	Metrics *logging.PerformanceMetrics
+}
+
+// RequestLog is one line of the gateway request log
+type RequestLog struct {
+	Time             time.Time `json:"time"`
+	Key              string    `json:"key,omitempty"`
+	Caller           string    `json:"caller,omitempty"` // Key, X-LocalCloud-App or client name
+	Method           string    `json:"method"`
+	Path             string    `json:"path"`
+	Model            string    `json:"model,omitempty"`
+	ResolvedModel    string    `json:"resolved_model,omitempty"`
+	Stream           bool      `json:"stream,omitempty"`
+	Status           int       `json:"status"`
+	DurationMs       int64     `json:"duration_ms"`
+	PromptTokens     int       `json:"prompt_tokens,omitempty"`
+	CompletionTokens int       `json:"completion_tokens,omitempty"`
+	GenerationMs     int64     `json:"generation_ms,omitempty"`
+	Cache            string    `json:"cache,omitempty"` // hit, miss, refresh or bypass when the response cache is on
+	Error            string    `json:"error,omitempty"`
+
+	embedding bool
+}
+
+// AppHeader names the application making a request, for usage accounting
+// when the gateway has no API keys
+const AppHeader = "X-LocalCloud-App"
+
+// recordTokens records the token counts of a finished Ollama response
+func (e *RequestLog) recordTokens(resp ollamaResponse) {
+	e.PromptTokens, e.CompletionTokens = resp.PromptEvalCount, resp.EvalCount
+	e.GenerationMs = time.Duration(resp.EvalDuration).Milliseconds()
+}
+
+// usage converts a request to the AI service into a usage record
+func (e *RequestLog) usage() logging.UsageRecord {
+	return logging.UsageRecord{
+		Time:             e.Time,
+		Caller:           e.Caller,
+		Model:            e.ResolvedModel,
+		Endpoint:         e.Path,
+		Status:           e.Status,
+		DurationMs:       e.DurationMs,
+		PromptTokens:     e.PromptTokens,
+		CompletionTokens: e.CompletionTokens,
+		GenerationMs:     e.GenerationMs,
+		Embedding:        e.embedding,
+		Cached:           e.Cache == "hit",
+		Error:            e.Error,
+	}
+}
+
+// Gateway serves the OpenAI-compatible API
+type Gateway struct {
+	opts   Options
+	client *http.Client
+	mux    *http.ServeMux
+
+	mu      sync.RWMutex
+	aliases map[string]string
+}
+
+// New creates a gateway
+func New(opts Options) *Gateway {
+	if opts.OllamaURL == "" {
+		opts.OllamaURL = "http://localhost:11434"
+	}
+	opts.OllamaURL = strings.TrimSuffix(opts.OllamaURL, "/")
+
+	g := &Gateway{
+		opts: opts,
+		// Generation has no fixed upper bound, so requests are only limited
+		// by the client's context
+		client:  &http.Client{Timeout: 0},
+		mux:     http.NewServeMux(),
+		aliases: make(map[string]string),
+	}
+	g.SetAliases(opts.Aliases)
+
+	g.mux.HandleFunc("GET /health", g.handleHealth)
+	g.mux.HandleFunc("GET /v1/models", g.handleModels)
+	g.mux.HandleFunc("GET /v1/models/{model...}", g.handleModel)
+	g.mux.HandleFunc("POST /v1/chat/completions", g.handleChatCompletions)
+	g.mux.HandleFunc("POST /v1/completions", g.handleCompletions)
+	g.mux.HandleFunc("POST /v1/embeddings", g.handleEmbeddings)
+	for path := range ollamaPaths {
+		g.mux.HandleFunc(path, g.handleOllama)
+	}
+
+	return g
+}
+
+// SetAliases replaces the model aliases
+func (g *Gateway) SetAliases(aliases []config.ModelAlias) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.aliases = make(map[string]string, len(aliases))
+	for _, alias := range aliases {
+		g.aliases[strings.ToLower(alias.Name)] = alias.Model
+	}
+}
+
+// resolve maps a requested model name to the Ollama model
+func (g *Gateway) resolve(model, fallback string) string {
+	if model == "" {
+		return fallback
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if target, ok := g.aliases[strings.ToLower(model)]; ok {
+		return target
+	}
+	return model
+}
+
+// ServeHTTP authenticates, logs and dispatches a request
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Ollama's own API keeps Ollama's origin policy, so a web page cannot use
+	// the gateway to reach it
+	if !strings.HasPrefix(r.URL.Path, "/api/") {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, "+AppHeader+", "+CacheHeader+", "+CacheTTLHeader)
+		w.Header().Set("Access-Control-Expose-Headers", CacheHeader+", "+CacheSimilarityHeader)
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	entry := &RequestLog{
+		Time:   time.Now(),
+		Method: r.Method,
+		Path:   r.URL.Path,
+	}
+	rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+
+	// Preflights carry no credentials; those for /api are Ollama's to answer
+	preflight := r.Method == http.MethodOptions
+	if r.URL.Path != "/health" && !preflight && g.opts.Keys != nil && !g.opts.Keys.Empty() {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		name, ok := g.opts.Keys.Authenticate(token)
+		if !ok {
+			writeError(rec, http.StatusUnauthorized, "invalid_api_key", "Incorrect API key provided")
+			g.logRequest(entry, rec)
+			return
+		}
+		entry.Key = name
+	}
+	entry.Caller = caller(r, entry.Key)
+
+	if g.opts.Metrics != nil && r.Method == http.MethodPost {
+		done := g.opts.Metrics.TrackRequest()
+		defer done()
+	}
+
+	r.Body = http.MaxBytesReader(rec, r.Body, maxBodySize)
+	ctx := context.WithValue(r.Context(), logKey{}, entry)
+	g.mux.ServeHTTP(rec, r.WithContext(ctx))
+
+	if r.URL.Path == "/health" {
+		return
+	}
+	g.logRequest(entry, rec)
+	// Requests that named a model reached it, or failed trying
+	if g.opts.Metrics != nil && entry.ResolvedModel != "" {
+		g.opts.Metrics.RecordUsage(entry.usage())
+	}
+}
+
+// caller identifies who made a request: the name of its API key, else the
+// application it names in AppHeader, else its client from the User-Agent
+func caller(r *http.Request, key string) string {
+	if key != "" {
+		return key
+	}
+	if app := strings.TrimSpace(r.Header.Get(AppHeader)); app != "" {
+		return app
+	}
+	if fields := strings.Fields(r.UserAgent()); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// ListenAndServe serves on addr until ctx is cancelled
+func (g *Gateway) ListenAndServe(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           g,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+func (g *Gateway) logRequest(entry *RequestLog, rec *recorder) {
+	entry.Status = rec.status
+	entry.DurationMs = time.Since(entry.Time).Milliseconds()
+	if g.opts.Log != nil {
+		g.opts.Log.WriteJSON(entry)
+	}
+}
+
+// logKey carries the request's log entry through the context so handlers
+// can record the model and token usage
+type logKey struct{}
+
+func requestLog(r *http.Request) *RequestLog {
+	if entry, ok := r.Context().Value(logKey{}).(*RequestLog); ok {
+		return entry
+	}
+	return &RequestLog{}
+}
+
+// recorder captures the response status for logging while still supporting
+// streaming
+type recorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (g *Gateway) handleHealth(w http.ResponseWriter, r *http.Request) {
+	status := "ok"
+	resp, err := (&http.Client{Timeout: 3 * time.Second}).Get(g.opts.OllamaURL + "/api/tags")
+	if err != nil {
+		status = "ollama unavailable"
+	} else {
+		resp.Body.Close()
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": status})
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in the OpenAI error format
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	errType := "invalid_request_error"
+	if status >= 500 {
+		errType = "api_error"
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"message": message,
+			"type":    errType,
+			"param":   nil,
+			"code":    code,
+		},
+	})
+}
+
+// newID returns an OpenAI-style object ID such as chatcmpl-1a2b3c...
+func newID(prefix string) string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return prefix + "-" + hex.EncodeToString(b)
+}
+
+// ollamaError extracts the message from an Ollama error response
+func ollamaError(body []byte) string {
+	var resp struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &resp) == nil && resp.Error != "" {
+		return resp.Error
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// statusForOllama maps an Ollama status to the one returned to the client
+func statusForOllama(status int) (int, string) {
+	switch status {
+	case http.StatusNotFound:
+		return http.StatusNotFound, "model_not_found"
+	case http.StatusBadRequest:
+		return http.StatusBadRequest, "invalid_request"
+	default:
+		return http.StatusBadGateway, "upstream_error"
+	}
+}
@@ -0,0 +1,220 @@
+// internal/gateway/keys.go
+package gateway
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// APIKey is a named gateway token. Only a hash of the token is stored.
+type APIKey struct {
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"`
+	Prefix    string    `json:"prefix"` // First characters of the token, for display
+	CreatedAt time.Time `json:"created_at"`
+	LastUsed  time.Time `json:"last_used,omitempty"`
+}
+
+// KeyStore manages gateway API keys in a project file. A long-running
+// gateway rereads the file when it changes, so keys created or revoked with
+// lc gateway keys apply without a restart.
+type KeyStore struct {
+	mu      sync.Mutex
+	path    string
+	modTime time.Time
+	size    int64
+	Keys    []APIKey `json:"keys"`
+}
+
+// KeysPath is where a project's gateway keys are kept
+func KeysPath() string {
+	return filepath.Join(".localcloud", "gateway-keys.json")
+}
+
+// LoadKeyStore reads the key store, returning an empty one if it does not exist
+func LoadKeyStore(path string) (*KeyStore, error) {
+	ks := &KeyStore{path: path}
+	if err := ks.load(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// load replaces the keys with the file's contents
+func (ks *KeyStore) load() error {
+	info, err := os.Stat(ks.path)
+	if os.IsNotExist(err) {
+		ks.Keys, ks.modTime, ks.size = nil, time.Time{}, 0
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(ks.path)
+	if err != nil {
+		return err
+	}
+	var file struct {
+		Keys []APIKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse gateway keys: %w", err)
+	}
+
+	ks.Keys, ks.modTime, ks.size = file.Keys, info.ModTime(), info.Size()
+	return nil
+}
+
+// refresh reloads the file if another process changed it. On failure the
+// previous keys stay in effect rather than leaving the gateway open.
+func (ks *KeyStore) refresh() {
+	info, err := os.Stat(ks.path)
+	switch {
+	case os.IsNotExist(err):
+		if !ks.modTime.IsZero() {
+			ks.load()
+		}
+	case err != nil:
+		return
+	case !info.ModTime().Equal(ks.modTime) || info.Size() != ks.size:
+		ks.load()
+	}
+}
+
+// Create generates a token for name and returns it. The token cannot be
+// recovered later.
+func (ks *KeyStore) Create(name string) (string, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.refresh()
+
+	for _, key := range ks.Keys {
+		if key.Name == name {
+			return "", fmt.Errorf("key %s already exists", name)
+		}
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := "lc-" + hex.EncodeToString(secret)
+
+	ks.Keys = append(ks.Keys, APIKey{
+		Name:      name,
+		Hash:      hashToken(token),
+		Prefix:    token[:9],
+		CreatedAt: time.Now(),
+	})
+	return token, ks.save()
+}
+
+// Revoke deletes the key called name
+func (ks *KeyStore) Revoke(name string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.refresh()
+
+	for i, key := range ks.Keys {
+		if key.Name == name {
+			ks.Keys = append(ks.Keys[:i], ks.Keys[i+1:]...)
+			return ks.save()
+		}
+	}
+	return fmt.Errorf("key %s not found", name)
+}
+
+// List returns the keys sorted by name
+func (ks *KeyStore) List() []APIKey {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.refresh()
+
+	keys := append([]APIKey(nil), ks.Keys...)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+	return keys
+}
+
+// Empty reports whether no keys exist, in which case the gateway is open
+func (ks *KeyStore) Empty() bool {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.refresh()
+	return len(ks.Keys) == 0
+}
+
+// Authenticate returns the name of the key matching token
+func (ks *KeyStore) Authenticate(token string) (string, bool) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.refresh()
+
+	hash := hashToken(token)
+	for _, key := range ks.Keys {
+		if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hash)) == 1 {
+			// Record usage at most once a minute to limit writes
+			if time.Since(key.LastUsed) > time.Minute {
+				ks.touch(hash)
+			}
+			return key.Name, true
+		}
+	}
+	return "", false
+}
+
+// touch records that the key with hash was used. The file is reread first so
+// keys created or revoked since the last refresh are kept as they are.
+func (ks *KeyStore) touch(hash string) {
+	if err := ks.load(); err != nil {
+		return
+	}
+	for i, key := range ks.Keys {
+		if key.Hash == hash {
+			ks.Keys[i].LastUsed = time.Now()
+			ks.save()
+			return
+		}
+	}
+}
+
+func (ks *KeyStore) save() error {
+	if err := os.MkdirAll(filepath.Dir(ks.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write and rename so a gateway reading the file never sees it half written
+	tmp := ks.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, ks.path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if info, err := os.Stat(ks.path); err == nil {
+		ks.modTime, ks.size = info.ModTime(), info.Size()
+	}
+	return nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
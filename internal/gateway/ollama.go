@@ -0,0 +1,152 @@
+// internal/gateway/ollama.go
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// accountedPaths are the Ollama API endpoints that run a model
+var accountedPaths = map[string]bool{
+	"/api/chat":       true,
+	"/api/generate":   true,
+	"/api/embed":      true,
+	"/api/embeddings": true,
+}
+
+// ollamaPaths are the Ollama API endpoints the gateway passes through, with
+// their method: those that run a model and read-only ones. Endpoints that
+// change the installed models, such as pull, create and delete, are not
+// reachable through the gateway.
+var ollamaPaths = map[string]string{
+	"/api/chat":       http.MethodPost,
+	"/api/generate":   http.MethodPost,
+	"/api/embed":      http.MethodPost,
+	"/api/embeddings": http.MethodPost,
+	"/api/show":       http.MethodPost,
+	"/api/tags":       http.MethodGet,
+	"/api/ps":         http.MethodGet,
+}
+
+// ollamaHeaders are the request headers passed to Ollama. Origin and the
+// preflight headers let Ollama apply its OLLAMA_ORIGINS policy to browsers.
+var ollamaHeaders = []string{
+	"Content-Type",
+	"Accept",
+	"Origin",
+	"Access-Control-Request-Method",
+	"Access-Control-Request-Headers",
+}
+
+// handleOllama passes a request to Ollama's native API unchanged, so clients
+// written against Ollama can use the gateway too. Requests that run a model
+// are logged and accounted with their token counts; aliases do not apply.
+// CORS is left to Ollama, which answers browsers by its own origin policy.
+func (g *Gateway) handleOllama(w http.ResponseWriter, r *http.Request) {
+	entry := requestLog(r)
+	if method := ollamaPaths[r.URL.Path]; r.Method != method && r.Method != http.MethodOptions {
+		w.Header().Set("Allow", method)
+		writeError(w, http.StatusMethodNotAllowed, "invalid_request", "Method not allowed")
+		return
+	}
+	accounted := r.Method == http.MethodPost && accountedPaths[r.URL.Path]
+
+	var body io.Reader = r.Body
+	if accounted {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", "Failed to read request body: "+err.Error())
+			return
+		}
+		var req struct {
+			Model  string `json:"model"`
+			Stream *bool  `json:"stream"`
+		}
+		json.Unmarshal(data, &req)
+		entry.Model, entry.ResolvedModel = req.Model, req.Model
+		switch r.URL.Path {
+		case "/api/chat", "/api/generate":
+			// Ollama streams unless told not to
+			entry.Stream = req.Stream == nil || *req.Stream
+		default:
+			entry.embedding = true
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, g.opts.OllamaURL+r.URL.RequestURI(), body)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err.Error())
+		return
+	}
+	for _, header := range ollamaHeaders {
+		if value := r.Header.Get(header); value != "" {
+			req.Header.Set(header, value)
+		}
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "upstream_unavailable", "Ollama is not reachable: "+err.Error())
+		entry.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+
+	for key, values := range resp.Header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(resp.StatusCode)
+	flusher, _ := w.(http.Flusher)
+
+	if !accounted {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := resp.Body.Read(buf)
+			if n > 0 {
+				if _, werr := w.Write(buf[:n]); werr != nil {
+					return
+				}
+				if flusher != nil {
+					flusher.Flush()
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	// Responses are one JSON object or, when streaming, one per line; the
+	// last carries the token counts
+	reader := bufio.NewReaderSize(resp.Body, 64*1024)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if _, werr := w.Write(line); werr != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+
+			var part ollamaResponse
+			if json.Unmarshal(line, &part) == nil {
+				switch {
+				case part.Error != "":
+					entry.Error = part.Error
+				case part.Done || part.PromptEvalCount > 0:
+					entry.recordTokens(part)
+				}
+			} else if resp.StatusCode != http.StatusOK {
+				entry.Error = ollamaError(line)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,759 @@
+// internal/gateway/openai.go
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// stringList accepts either a JSON string or an array of strings, as the
+// OpenAI API does for stop sequences and inputs
+type stringList []string
+
+func (s *stringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = stringList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("expected a string or an array of strings")
+	}
+	*s = list
+	return nil
+}
+
+// samplingParams are the generation parameters shared by chat and completions
+type samplingParams struct {
+	Temperature         *float64   `json:"temperature,omitempty"`
+	TopP                *float64   `json:"top_p,omitempty"`
+	MaxTokens           *int       `json:"max_tokens,omitempty"`
+	MaxCompletionTokens *int       `json:"max_completion_tokens,omitempty"`
+	Stop                stringList `json:"stop,omitempty"`
+	Seed                *int       `json:"seed,omitempty"`
+	FrequencyPenalty    *float64   `json:"frequency_penalty,omitempty"`
+	PresencePenalty     *float64   `json:"presence_penalty,omitempty"`
+}
+
+// options converts sampling parameters to Ollama model options
+func (p samplingParams) options() map[string]interface{} {
+	options := make(map[string]interface{})
+	if p.Temperature != nil {
+		options["temperature"] = *p.Temperature
+	}
+	if p.TopP != nil {
+		options["top_p"] = *p.TopP
+	}
+	if p.MaxCompletionTokens != nil {
+		options["num_predict"] = *p.MaxCompletionTokens
+	} else if p.MaxTokens != nil {
+		options["num_predict"] = *p.MaxTokens
+	}
+	if len(p.Stop) > 0 {
+		options["stop"] = []string(p.Stop)
+	}
+	if p.Seed != nil {
+		options["seed"] = *p.Seed
+	}
+	if p.FrequencyPenalty != nil {
+		options["frequency_penalty"] = *p.FrequencyPenalty
+	}
+	if p.PresencePenalty != nil {
+		options["presence_penalty"] = *p.PresencePenalty
+	}
+	return options
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type responseFormat struct {
+	Type       string `json:"type"` // "text", "json_object" or "json_schema"
+	JSONSchema *struct {
+		Schema json.RawMessage `json:"schema"`
+	} `json:"json_schema,omitempty"`
+}
+
+// format converts an OpenAI response_format to Ollama's format field
+func (f *responseFormat) format() interface{} {
+	if f == nil {
+		return nil
+	}
+	switch f.Type {
+	case "json_object":
+		return "json"
+	case "json_schema":
+		if f.JSONSchema != nil && len(f.JSONSchema.Schema) > 0 {
+			return f.JSONSchema.Schema
+		}
+		return "json"
+	}
+	return nil
+}
+
+type chatRequest struct {
+	Model          string          `json:"model"`
+	Messages       []chatMessage   `json:"messages"`
+	Stream         bool            `json:"stream"`
+	StreamOptions  *streamOptions  `json:"stream_options,omitempty"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+	samplingParams
+}
+
+type chatMessage struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+	Name    string          `json:"name,omitempty"`
+}
+
+// contentPart is one element of an array-form message content
+type contentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL *struct {
+		URL string `json:"url"`
+	} `json:"image_url,omitempty"`
+}
+
+type ollamaMessage struct {
+	Role    string   `json:"role"`
+	Content string   `json:"content"`
+	Images  []string `json:"images,omitempty"`
+}
+
+// toOllama converts a message, turning content parts into text and images.
+// Images must be base64 data URLs; Ollama cannot fetch remote URLs.
+func (m chatMessage) toOllama() (ollamaMessage, error) {
+	msg := ollamaMessage{Role: m.Role}
+	if m.Role == "developer" {
+		msg.Role = "system"
+	}
+	if len(m.Content) == 0 || string(m.Content) == "null" {
+		return msg, nil
+	}
+
+	if err := json.Unmarshal(m.Content, &msg.Content); err == nil {
+		return msg, nil
+	}
+
+	var parts []contentPart
+	if err := json.Unmarshal(m.Content, &parts); err != nil {
+		return msg, fmt.Errorf("message content must be a string or an array of content parts")
+	}
+
+	var text []string
+	for _, part := range parts {
+		switch part.Type {
+		case "text":
+			text = append(text, part.Text)
+		case "image_url":
+			if part.ImageURL == nil {
+				continue
+			}
+			url := part.ImageURL.URL
+			idx := strings.Index(url, ";base64,")
+			if !strings.HasPrefix(url, "data:") || idx < 0 {
+				return msg, fmt.Errorf("only base64 data URLs are supported for images")
+			}
+			msg.Images = append(msg.Images, url[idx+len(";base64,"):])
+		default:
+			return msg, fmt.Errorf("unsupported content part type: %s", part.Type)
+		}
+	}
+	msg.Content = strings.Join(text, "\n")
+	return msg, nil
+}
+
+// ollamaResponse covers the fields of Ollama chat and generate responses
+type ollamaResponse struct {
+	Message         ollamaMessage `json:"message"`
+	Response        string        `json:"response"`
+	Done            bool          `json:"done"`
+	DoneReason      string        `json:"done_reason"`
+	PromptEvalCount int           `json:"prompt_eval_count"`
+	EvalCount       int           `json:"eval_count"`
+	EvalDuration    int64         `json:"eval_duration"` // Nanoseconds spent generating
+	Error           string        `json:"error"`
+}
+
+func (r ollamaResponse) finishReason() string {
+	if r.DoneReason == "length" {
+		return "length"
+	}
+	return "stop"
+}
+
+func (r ollamaResponse) usage() map[string]int {
+	return map[string]int{
+		"prompt_tokens":     r.PromptEvalCount,
+		"completion_tokens": r.EvalCount,
+		"total_tokens":      r.PromptEvalCount + r.EvalCount,
+	}
+}
+
+func (g *Gateway) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	var req chatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid request body: "+err.Error())
+		return
+	}
+	if len(req.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request", "messages must not be empty")
+		return
+	}
+
+	messages := make([]ollamaMessage, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		msg, err := m.toOllama()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+		messages = append(messages, msg)
+	}
+
+	model := g.resolve(req.Model, g.opts.DefaultModel)
+	entry := requestLog(r)
+	entry.Model, entry.ResolvedModel, entry.Stream = req.Model, model, req.Stream
+
+	options := req.options()
+	format := req.ResponseFormat.format()
+	payload := map[string]interface{}{
+		"model":    model,
+		"messages": messages,
+		"stream":   req.Stream,
+		"options":  options,
+	}
+	if format != nil {
+		payload["format"] = format
+	}
+	g.setKeepAlive(payload, model)
+
+	id := newID("chatcmpl")
+	created := time.Now().Unix()
+	responseModel := req.Model
+	if responseModel == "" {
+		responseModel = model
+	}
+
+	reply := func(result ollamaResponse) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":      id,
+			"object":  "chat.completion",
+			"created": created,
+			"model":   responseModel,
+			"choices": []map[string]interface{}{{
+				"index":         0,
+				"message":       map[string]string{"role": "assistant", "content": result.Message.Content},
+				"finish_reason": result.finishReason(),
+				"logprobs":      nil,
+			}},
+			"usage": result.usage(),
+		})
+	}
+
+	chunk := func(delta map[string]string, finish interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"id":      id,
+			"object":  "chat.completion.chunk",
+			"created": created,
+			"model":   responseModel,
+			"choices": []map[string]interface{}{{
+				"index":         0,
+				"delta":         delta,
+				"finish_reason": finish,
+				"logprobs":      nil,
+			}},
+		}
+	}
+
+	var sse *eventStream
+	send := func(part ollamaResponse) {
+		if part.Message.Content != "" {
+			sse.send(chunk(map[string]string{"content": part.Message.Content}, nil))
+		}
+		if part.Done {
+			sse.send(chunk(map[string]string{}, part.finishReason()))
+			if req.StreamOptions != nil && req.StreamOptions.IncludeUsage {
+				sse.send(map[string]interface{}{
+					"id":      id,
+					"object":  "chat.completion.chunk",
+					"created": created,
+					"model":   responseModel,
+					"choices": []interface{}{},
+					"usage":   part.usage(),
+				})
+			}
+		}
+	}
+
+	lookup, cached := g.lookupCache(w, r, chatCacheRequest(model, messages, options, format))
+	if cached != nil {
+		if !req.Stream {
+			reply(cached.response())
+			return
+		}
+		sse = newEventStream(w)
+		sse.send(chunk(map[string]string{"role": "assistant", "content": ""}, nil))
+		send(cached.response())
+		sse.done()
+		return
+	}
+
+	resp, ok := g.forward(w, r, "/api/chat", payload)
+	if !ok {
+		return
+	}
+	defer resp.Body.Close()
+
+	if !req.Stream {
+		var result ollamaResponse
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			writeError(w, http.StatusBadGateway, "upstream_error", "Invalid response from Ollama: "+err.Error())
+			return
+		}
+		entry.recordTokens(result)
+		g.storeCache(r.Context(), lookup, result, result.Message.Content, time.Since(entry.Time))
+		reply(result)
+		return
+	}
+
+	sse = newEventStream(w)
+	sse.send(chunk(map[string]string{"role": "assistant", "content": ""}, nil))
+
+	var content strings.Builder
+	g.stream(resp.Body, sse, func(part ollamaResponse) {
+		content.WriteString(part.Message.Content)
+		send(part)
+		if part.Done {
+			entry.recordTokens(part)
+			g.storeCache(r.Context(), lookup, part, content.String(), time.Since(entry.Time))
+		}
+	})
+}
+
+type completionRequest struct {
+	Model  string         `json:"model"`
+	Prompt stringList     `json:"prompt"`
+	Suffix string         `json:"suffix,omitempty"`
+	Stream bool           `json:"stream"`
+	Echo   bool           `json:"echo,omitempty"`
+	Opts   *streamOptions `json:"stream_options,omitempty"`
+	samplingParams
+}
+
+func (g *Gateway) handleCompletions(w http.ResponseWriter, r *http.Request) {
+	var req completionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid request body: "+err.Error())
+		return
+	}
+	if len(req.Prompt) > 1 {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Only a single prompt per request is supported")
+		return
+	}
+	prompt := ""
+	if len(req.Prompt) == 1 {
+		prompt = req.Prompt[0]
+	}
+
+	model := g.resolve(req.Model, g.opts.DefaultModel)
+	entry := requestLog(r)
+	entry.Model, entry.ResolvedModel, entry.Stream = req.Model, model, req.Stream
+
+	options := req.options()
+	payload := map[string]interface{}{
+		"model":   model,
+		"prompt":  prompt,
+		"stream":  req.Stream,
+		"options": options,
+	}
+	if req.Suffix != "" {
+		payload["suffix"] = req.Suffix
+	}
+	g.setKeepAlive(payload, model)
+
+	id := newID("cmpl")
+	created := time.Now().Unix()
+	responseModel := req.Model
+	if responseModel == "" {
+		responseModel = model
+	}
+
+	choice := func(text string, finish interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"id":      id,
+			"object":  "text_completion",
+			"created": created,
+			"model":   responseModel,
+			"choices": []map[string]interface{}{{
+				"index":         0,
+				"text":          text,
+				"finish_reason": finish,
+				"logprobs":      nil,
+			}},
+		}
+	}
+
+	reply := func(result ollamaResponse) {
+		text := result.Response
+		if req.Echo {
+			text = prompt + text
+		}
+		body := choice(text, result.finishReason())
+		body["usage"] = result.usage()
+		writeJSON(w, http.StatusOK, body)
+	}
+
+	var sse *eventStream
+	startStream := func() {
+		sse = newEventStream(w)
+		if req.Echo && prompt != "" {
+			sse.send(choice(prompt, nil))
+		}
+	}
+	send := func(part ollamaResponse) {
+		if part.Response != "" {
+			sse.send(choice(part.Response, nil))
+		}
+		if part.Done {
+			final := choice("", part.finishReason())
+			if req.Opts != nil && req.Opts.IncludeUsage {
+				final["usage"] = part.usage()
+			}
+			sse.send(final)
+		}
+	}
+
+	lookup, cached := g.lookupCache(w, r, completionCacheRequest(model, prompt, req.Suffix, options))
+	if cached != nil {
+		if !req.Stream {
+			reply(cached.response())
+			return
+		}
+		startStream()
+		send(cached.response())
+		sse.done()
+		return
+	}
+
+	resp, ok := g.forward(w, r, "/api/generate", payload)
+	if !ok {
+		return
+	}
+	defer resp.Body.Close()
+
+	if !req.Stream {
+		var result ollamaResponse
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			writeError(w, http.StatusBadGateway, "upstream_error", "Invalid response from Ollama: "+err.Error())
+			return
+		}
+		entry.recordTokens(result)
+		g.storeCache(r.Context(), lookup, result, result.Response, time.Since(entry.Time))
+		reply(result)
+		return
+	}
+
+	startStream()
+	var text strings.Builder
+	g.stream(resp.Body, sse, func(part ollamaResponse) {
+		text.WriteString(part.Response)
+		send(part)
+		if part.Done {
+			entry.recordTokens(part)
+			g.storeCache(r.Context(), lookup, part, text.String(), time.Since(entry.Time))
+		}
+	})
+}
+
+type embeddingRequest struct {
+	Model          string     `json:"model"`
+	Input          stringList `json:"input"`
+	EncodingFormat string     `json:"encoding_format,omitempty"` // "float" (default) or "base64"
+	Dimensions     int        `json:"dimensions,omitempty"`
+}
+
+func (g *Gateway) handleEmbeddings(w http.ResponseWriter, r *http.Request) {
+	var req embeddingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "Invalid request body (token array inputs are not supported): "+err.Error())
+		return
+	}
+	if len(req.Input) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request", "input must not be empty")
+		return
+	}
+
+	model := g.resolve(req.Model, g.opts.EmbeddingModel)
+	entry := requestLog(r)
+	entry.Model, entry.ResolvedModel, entry.embedding = req.Model, model, true
+
+	payload := map[string]interface{}{
+		"model": model,
+		"input": []string(req.Input),
+	}
+	if req.Dimensions > 0 {
+		payload["dimensions"] = req.Dimensions
+	}
+	g.setKeepAlive(payload, model)
+
+	resp, ok := g.forward(w, r, "/api/embed", payload)
+	if !ok {
+		return
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Embeddings      [][]float32 `json:"embeddings"`
+		PromptEvalCount int         `json:"prompt_eval_count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		writeError(w, http.StatusBadGateway, "upstream_error", "Invalid response from Ollama: "+err.Error())
+		return
+	}
+	entry.PromptTokens = result.PromptEvalCount
+
+	data := make([]map[string]interface{}, len(result.Embeddings))
+	for i, vector := range result.Embeddings {
+		var embedding interface{} = vector
+		if req.EncodingFormat == "base64" {
+			embedding = encodeBase64Vector(vector)
+		}
+		data[i] = map[string]interface{}{
+			"object":    "embedding",
+			"index":     i,
+			"embedding": embedding,
+		}
+	}
+
+	responseModel := req.Model
+	if responseModel == "" {
+		responseModel = model
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"object": "list",
+		"data":   data,
+		"model":  responseModel,
+		"usage": map[string]int{
+			"prompt_tokens": result.PromptEvalCount,
+			"total_tokens":  result.PromptEvalCount,
+		},
+	})
+}
+
+// modelObject is an entry of GET /v1/models
+type modelObject struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+// listModels returns installed Ollama models followed by configured aliases
+func (g *Gateway) listModels(r *http.Request) ([]modelObject, error) {
+	req, err := http.NewRequestWithContext(r.Context(), "GET", g.opts.OllamaURL+"/api/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tags struct {
+		Models []struct {
+			Name       string    `json:"name"`
+			ModifiedAt time.Time `json:"modified_at"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, err
+	}
+
+	var models []modelObject
+	for _, m := range tags.Models {
+		var created int64
+		if !m.ModifiedAt.IsZero() {
+			created = m.ModifiedAt.Unix()
+		}
+		models = append(models, modelObject{
+			ID:      m.Name,
+			Object:  "model",
+			Created: created,
+			OwnedBy: "library",
+		})
+	}
+
+	g.mu.RLock()
+	aliases := make([]string, 0, len(g.aliases))
+	for alias := range g.aliases {
+		aliases = append(aliases, alias)
+	}
+	g.mu.RUnlock()
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		models = append(models, modelObject{
+			ID:      alias,
+			Object:  "model",
+			Created: time.Now().Unix(),
+			OwnedBy: "localcloud",
+		})
+	}
+
+	return models, nil
+}
+
+func (g *Gateway) handleModels(w http.ResponseWriter, r *http.Request) {
+	models, err := g.listModels(r)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "upstream_error", "Failed to list models: "+err.Error())
+		return
+	}
+	if models == nil {
+		models = []modelObject{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"object": "list",
+		"data":   models,
+	})
+}
+
+func (g *Gateway) handleModel(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("model")
+	models, err := g.listModels(r)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "upstream_error", "Failed to list models: "+err.Error())
+		return
+	}
+	for _, m := range models {
+		if m.ID == id || m.ID == id+":latest" || strings.EqualFold(m.ID, id) {
+			writeJSON(w, http.StatusOK, m)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "model_not_found", fmt.Sprintf("The model '%s' does not exist", id))
+}
+
+// setKeepAlive adds the model's configured keep_alive to an Ollama request
+func (g *Gateway) setKeepAlive(payload map[string]interface{}, model string) {
+	if value, ok := g.opts.KeepAlive.Value(model); ok {
+		payload["keep_alive"] = value
+	}
+}
+
+// forward posts payload to Ollama and writes an OpenAI-style error if the
+// request fails
+func (g *Gateway) forward(w http.ResponseWriter, r *http.Request, path string, payload interface{}) (*http.Response, bool) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err.Error())
+		return nil, false
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), "POST", g.opts.OllamaURL+path, bytes.NewReader(data))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err.Error())
+		return nil, false
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "upstream_unavailable", "Ollama is not reachable: "+err.Error())
+		requestLog(r).Error = err.Error()
+		return nil, false
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		status, code := statusForOllama(resp.StatusCode)
+		message := ollamaError(body)
+		requestLog(r).Error = message
+		writeError(w, status, code, message)
+		return nil, false
+	}
+
+	return resp, true
+}
+
+// stream decodes Ollama's newline-delimited JSON and ends the event stream
+func (g *Gateway) stream(body io.Reader, sse *eventStream, handle func(ollamaResponse)) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var part ollamaResponse
+		if err := json.Unmarshal(scanner.Bytes(), &part); err != nil {
+			continue
+		}
+		if part.Error != "" {
+			sse.send(map[string]interface{}{
+				"error": map[string]interface{}{"message": part.Error, "type": "api_error"},
+			})
+			break
+		}
+		handle(part)
+		if part.Done {
+			break
+		}
+	}
+	sse.done()
+}
+
+// eventStream writes server-sent events in the format OpenAI clients expect
+type eventStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func newEventStream(w http.ResponseWriter) *eventStream {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	return &eventStream{w: w, flusher: flusher}
+}
+
+func (s *eventStream) send(v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(s.w, "data: %s\n\n", data)
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+}
+
+func (s *eventStream) done() {
+	fmt.Fprint(s.w, "data: [DONE]\n\n")
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+}
+
+// encodeBase64Vector encodes a vector as little-endian float32 bytes, the
+// format OpenAI SDKs request by default
+func encodeBase64Vector(vector []float32) string {
+	buf := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(v))
+	}
+	return base64.StdEncoding.EncodeToString(buf)
+}
@@ -17,6 +17,13 @@ type PerformanceMetrics struct {
 	mu      sync.RWMutex
 	metrics map[string]*ServiceMetrics
 	storage *MetricsStorage
+	done    chan struct{}
+
+	// AI service accounting
+	promptTokens     uint64
+	completionTokens uint64
+	activeRequests   int
+	recentUsage      []UsageRecord
 }
 
 // ServiceMetrics represents metrics for a specific service
@@ -51,11 +58,12 @@ type MetricsStorage struct {
 	path   string
 	mu     sync.Mutex
 	writer *RotatingWriter
+	usage  *RotatingWriter // Opened on the first usage record
 }
 
 // NewPerformanceMetrics creates a new performance metrics tracker
 func NewPerformanceMetrics() (*PerformanceMetrics, error) {
-	metricsDir := filepath.Join(".localcloud", "metrics")
+	metricsDir := MetricsDir()
 	if err := os.MkdirAll(metricsDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create metrics directory: %w", err)
 	}
@@ -68,6 +76,7 @@ func NewPerformanceMetrics() (*PerformanceMetrics, error) {
 	pm := &PerformanceMetrics{
 		metrics: make(map[string]*ServiceMetrics),
 		storage: storage,
+		done:    make(chan struct{}),
 	}
 
 	// Start metrics aggregation
@@ -217,12 +226,25 @@ func (pm *PerformanceMetrics) aggregateLoop() {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		pm.aggregate()
-		pm.persist()
+	for {
+		select {
+		case <-ticker.C:
+			pm.aggregate()
+			pm.persist()
+		case <-pm.done:
+			return
+		}
 	}
 }
 
+// Close stops aggregation, persists the current metrics and closes storage
+func (pm *PerformanceMetrics) Close() error {
+	close(pm.done)
+	pm.aggregate()
+	pm.persist()
+	return pm.storage.Close()
+}
+
 // aggregate calculates aggregate metrics
 func (pm *PerformanceMetrics) aggregate() {
 	pm.mu.Lock()
@@ -278,6 +300,17 @@ func (ms *MetricsStorage) Write(metrics *ServiceMetrics) error {
 	return ms.writer.WriteJSON(metrics)
 }
 
+// Close closes the storage files
+func (ms *MetricsStorage) Close() error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if ms.usage != nil {
+		ms.usage.Close()
+	}
+	return ms.writer.Close()
+}
+
 // StartHTTPCollector starts an HTTP metrics collector for the AI service
 func StartHTTPCollector(port int, metrics *PerformanceMetrics) {
 	// Create a middleware that tracks response times
@@ -372,9 +405,10 @@ func CalculatePerformanceStats(metrics *ServiceMetrics) map[string]interface{} {
 	return stats
 }
 
-// AI Service specific metrics
-func CollectAIMetrics(ctx context.Context, metrics *PerformanceMetrics) {
-	// Example of collecting AI-specific metrics
+// CollectAIMetrics periodically records the models installed in Ollama at
+// ollamaURL and the inference speed, token totals and active requests
+// measured by RecordUsage
+func CollectAIMetrics(ctx context.Context, ollamaURL string, metrics *PerformanceMetrics) {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 
@@ -384,7 +418,7 @@ func CollectAIMetrics(ctx context.Context, metrics *PerformanceMetrics) {
 		select {
 		case <-ticker.C:
 			// Query Ollama API for model info
-			resp, err := httpClient.Get("http://localhost:11434/api/tags")
+			resp, err := httpClient.Get(ollamaURL + "/api/tags")
 			if err == nil {
 				var data struct {
 					Models []struct {
@@ -405,10 +439,15 @@ func CollectAIMetrics(ctx context.Context, metrics *PerformanceMetrics) {
 				resp.Body.Close()
 			}
 
-			// Simulate inference metrics
-			metrics.SetCustomMetric("ai", "inference_speed_tokens_per_sec", 450)
-			metrics.SetCustomMetric("ai", "active_sessions", 2)
-			metrics.SetCustomMetric("ai", "queue_size", 0)
+			metrics.mu.RLock()
+			promptTokens, completionTokens := metrics.promptTokens, metrics.completionTokens
+			active := metrics.activeRequests
+			metrics.mu.RUnlock()
+
+			metrics.SetCustomMetric("ai", "inference_speed_tokens_per_sec", metrics.TokensPerSecond())
+			metrics.SetCustomMetric("ai", "active_sessions", active)
+			metrics.SetCustomMetric("ai", "prompt_tokens_total", promptTokens)
+			metrics.SetCustomMetric("ai", "completion_tokens_total", completionTokens)
 
 		case <-ctx.Done():
 			return
@@ -0,0 +1,156 @@
+// internal/logging/usage.go
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// usageFile is where MetricsStorage appends usage records
+const usageFile = "usage.jsonl"
+
+// UsageRecord is one request to the AI service: who made it, the tokens it
+// used and how long it took
+type UsageRecord struct {
+	Time             time.Time `json:"time"`
+	Caller           string    `json:"caller,omitempty"` // Gateway key, application or client name
+	Model            string    `json:"model,omitempty"`
+	Endpoint         string    `json:"endpoint"`
+	Status           int       `json:"status"`
+	DurationMs       int64     `json:"duration_ms"`
+	PromptTokens     int       `json:"prompt_tokens,omitempty"`
+	CompletionTokens int       `json:"completion_tokens,omitempty"`
+	GenerationMs     int64     `json:"generation_ms,omitempty"` // Time the model spent generating completion tokens
+	Embedding        bool      `json:"embedding,omitempty"`
+	Cached           bool      `json:"cached,omitempty"` // Served from the response cache
+	Error            string    `json:"error,omitempty"`
+}
+
+// Success reports whether the request succeeded
+func (r UsageRecord) Success() bool {
+	return r.Status < 400 && r.Error == ""
+}
+
+// MetricsDir returns where the current project keeps metrics
+func MetricsDir() string {
+	return filepath.Join(".localcloud", "metrics")
+}
+
+// WriteUsage appends a usage record
+func (ms *MetricsStorage) WriteUsage(record UsageRecord) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if ms.usage == nil {
+		writer, err := NewRotatingWriter(filepath.Join(filepath.Dir(ms.path), usageFile), 50*1024*1024) // 50MB
+		if err != nil {
+			return err
+		}
+		ms.usage = writer
+	}
+	return ms.usage.WriteJSON(record)
+}
+
+// LoadUsage reads the usage records in dir made at or after since, oldest
+// first. Rotated files are included.
+func LoadUsage(dir string, since time.Time) ([]UsageRecord, error) {
+	ext := filepath.Ext(usageFile)
+	base := usageFile[:len(usageFile)-len(ext)]
+	rotated, err := filepath.Glob(filepath.Join(dir, base+"-*"+ext))
+	if err != nil {
+		return nil, err
+	}
+	paths := append(rotated, filepath.Join(dir, usageFile))
+
+	var records []UsageRecord
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Before(since) {
+			// A file last written before since holds no newer records
+			continue
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var record UsageRecord
+			if json.Unmarshal(scanner.Bytes(), &record) != nil || record.Time.Before(since) {
+				continue
+			}
+			records = append(records, record)
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+	return records, nil
+}
+
+// usageWindow is how many recent requests the inference speed is averaged
+// over
+const usageWindow = 100
+
+// RecordUsage records a request to the AI service: its response time in the
+// "ai" service metrics, its tokens in the running totals and the record
+// itself in storage
+func (pm *PerformanceMetrics) RecordUsage(record UsageRecord) {
+	pm.RecordResponseTime("ai", record.Endpoint, "POST", time.Duration(record.DurationMs)*time.Millisecond, record.Success())
+	pm.UpdateThroughput("ai", 1, 0)
+
+	pm.mu.Lock()
+	pm.promptTokens += uint64(record.PromptTokens)
+	pm.completionTokens += uint64(record.CompletionTokens)
+	if record.CompletionTokens > 0 && record.GenerationMs > 0 {
+		pm.recentUsage = append(pm.recentUsage, record)
+		if len(pm.recentUsage) > usageWindow {
+			pm.recentUsage = pm.recentUsage[len(pm.recentUsage)-usageWindow:]
+		}
+	}
+	pm.mu.Unlock()
+
+	if pm.storage != nil {
+		pm.storage.WriteUsage(record)
+	}
+}
+
+// TrackRequest counts a request to the AI service as active until the
+// returned function is called
+func (pm *PerformanceMetrics) TrackRequest() func() {
+	pm.mu.Lock()
+	pm.activeRequests++
+	pm.mu.Unlock()
+
+	return func() {
+		pm.mu.Lock()
+		pm.activeRequests--
+		pm.mu.Unlock()
+	}
+}
+
+// TokensPerSecond returns the generation speed over recent requests, or 0
+// before any completion was recorded
+func (pm *PerformanceMetrics) TokensPerSecond() float64 {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	var tokens, ms int64
+	for _, record := range pm.recentUsage {
+		tokens += int64(record.CompletionTokens)
+		ms += record.GenerationMs
+	}
+	if ms == 0 {
+		return 0
+	}
+	return float64(tokens) / (float64(ms) / 1000)
+}
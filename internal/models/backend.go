@@ -0,0 +1,92 @@
+// internal/models/backend.go
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+)
+
+// Backend is an inference server that runs models. Ollama is the default;
+// llama.cpp and LocalAI serve GGUF files from GGUFDir.
+type Backend interface {
+	// Provider names the inference server
+	Provider() Provider
+
+	// Health returns nil when the server answers and its model is loaded
+	Health(ctx context.Context) error
+
+	// List returns the installed models
+	List(ctx context.Context) ([]Model, error)
+
+	// Pull downloads a model by name or imports a GGUF file from a URL,
+	// hf://owner/repo/file.gguf or a local path, where the server supports
+	// it. progress is closed when Pull returns.
+	Pull(ctx context.Context, name string, progress chan<- PullProgress) error
+
+	// Generate returns the model's reply to a conversation. When onToken is
+	// set the reply is streamed and onToken receives each piece as it
+	// arrives. Options use Ollama's names (num_predict, temperature, ...).
+	Generate(ctx context.Context, model string, messages []ChatMessage, options map[string]interface{}, onToken func(string)) (string, error)
+
+	// Embed returns one embedding per text
+	Embed(ctx context.Context, model string, texts []string) ([][]float32, error)
+}
+
+// NewBackend returns the backend for services.ai.provider on the AI port.
+// The mock backend speaks Ollama's API whatever the provider.
+func NewBackend(cfg config.AIConfig) (Backend, error) {
+	endpoint := fmt.Sprintf("http://localhost:%d", cfg.Port)
+	if cfg.Mode == config.AIModeMock {
+		return NewOllamaBackend(NewManager(endpoint)), nil
+	}
+
+	switch cfg.ProviderName() {
+	case config.AIProviderOllama:
+		return NewOllamaBackend(NewManager(endpoint)), nil
+	case config.AIProviderLlamaCpp:
+		return NewLlamaCppBackend(endpoint, GGUFDir()), nil
+	case config.AIProviderLocalAI:
+		return NewLocalAIBackend(endpoint, GGUFDir()), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider: %s (use ollama, llamacpp or localai)", cfg.Provider)
+	}
+}
+
+// ollamaBackend adapts Manager to Backend
+type ollamaBackend struct {
+	manager *Manager
+}
+
+// NewOllamaBackend returns a Backend for the Ollama behind manager
+func NewOllamaBackend(manager *Manager) Backend {
+	return &ollamaBackend{manager: manager}
+}
+
+func (b *ollamaBackend) Provider() Provider {
+	return ProviderOllama
+}
+
+func (b *ollamaBackend) Health(ctx context.Context) error {
+	if !b.manager.IsOllamaAvailable() {
+		return fmt.Errorf("Ollama is not answering at %s", b.manager.ollamaEndpoint)
+	}
+	return nil
+}
+
+func (b *ollamaBackend) List(ctx context.Context) ([]Model, error) {
+	return b.manager.List()
+}
+
+func (b *ollamaBackend) Pull(ctx context.Context, name string, progress chan<- PullProgress) error {
+	return b.manager.PullContext(ctx, name, progress)
+}
+
+func (b *ollamaBackend) Generate(ctx context.Context, model string, messages []ChatMessage, options map[string]interface{}, onToken func(string)) (string, error) {
+	return b.manager.ChatContext(ctx, model, messages, options, onToken)
+}
+
+func (b *ollamaBackend) Embed(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	return b.manager.Embed(model, texts)
+}
@@ -0,0 +1,79 @@
+// internal/models/backend_llamacpp.go
+package models
+
+import (
+	"context"
+	"fmt"
+)
+
+// llamaCppBackend is llama.cpp's llama-server. It serves the single GGUF file
+// it was started with, named by its --alias; the other files in dir are
+// installed but not loaded.
+type llamaCppBackend struct {
+	client openAIClient
+	dir    string
+}
+
+// NewLlamaCppBackend returns a Backend for the llama-server at endpoint
+// serving models from dir
+func NewLlamaCppBackend(endpoint, dir string) Backend {
+	return &llamaCppBackend{client: newOpenAIClient(endpoint, "llama.cpp"), dir: dir}
+}
+
+func (b *llamaCppBackend) Provider() Provider {
+	return ProviderLlamaCpp
+}
+
+// Health uses /health, which answers 503 while the model is loading
+func (b *llamaCppBackend) Health(ctx context.Context) error {
+	return b.client.get(ctx, "/health")
+}
+
+func (b *llamaCppBackend) List(ctx context.Context) ([]Model, error) {
+	return ListGGUF(b.dir)
+}
+
+// Pull imports a GGUF file; llama.cpp has no registry to pull names from
+func (b *llamaCppBackend) Pull(ctx context.Context, name string, progress chan<- PullProgress) error {
+	if !IsGGUFSource(name) {
+		close(progress)
+		return fmt.Errorf("llama.cpp loads GGUF files: give a URL, hf://owner/repo/file.gguf or a path to a .gguf file")
+	}
+	_, err := ImportGGUF(ctx, b.dir, name, progress)
+	return err
+}
+
+func (b *llamaCppBackend) Generate(ctx context.Context, model string, messages []ChatMessage, options map[string]interface{}, onToken func(string)) (string, error) {
+	if err := b.serving(ctx, model); err != nil {
+		return "", err
+	}
+	return b.client.chat(ctx, model, messages, options, onToken)
+}
+
+func (b *llamaCppBackend) Embed(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	if err := b.serving(ctx, model); err != nil {
+		return nil, err
+	}
+	return b.client.embed(ctx, model, texts)
+}
+
+// serving checks that model is the one llama-server loaded, since it answers
+// requests for any name with its own model
+func (b *llamaCppBackend) serving(ctx context.Context, model string) error {
+	if model == "" {
+		return nil
+	}
+	ids, err := b.client.models(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if id == model || GGUFModelName(id) == model {
+			return nil
+		}
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("llama.cpp has no model loaded")
+	}
+	return fmt.Errorf("llama.cpp is serving %s, not %s; set it as the default model and restart the AI service", ids[0], model)
+}
@@ -0,0 +1,137 @@
+// internal/models/backend_localai.go
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// localAIBackend is LocalAI. It loads models on demand from its models
+// directory, which is dir mounted into the container, and installs others
+// from its gallery.
+type localAIBackend struct {
+	client openAIClient
+	dir    string
+}
+
+// NewLocalAIBackend returns a Backend for the LocalAI at endpoint whose
+// models directory is dir
+func NewLocalAIBackend(endpoint, dir string) Backend {
+	return &localAIBackend{client: newOpenAIClient(endpoint, "LocalAI"), dir: dir}
+}
+
+func (b *localAIBackend) Provider() Provider {
+	return ProviderLocalAI
+}
+
+func (b *localAIBackend) Health(ctx context.Context) error {
+	return b.client.get(ctx, "/readyz")
+}
+
+// List returns the models LocalAI reports, with the size of their GGUF file
+// when it is in dir
+func (b *localAIBackend) List(ctx context.Context) ([]Model, error) {
+	ids, err := b.client.models(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files, _ := ListGGUF(b.dir)
+
+	list := make([]Model, 0, len(ids))
+	for _, id := range ids {
+		m := Model{Name: id, Model: id}
+		for _, f := range files {
+			if f.Name == id || f.Model == id {
+				m.Size, m.ModifiedAt = f.Size, f.ModifiedAt
+			}
+		}
+		list = append(list, m)
+	}
+	return list, nil
+}
+
+// Pull imports GGUF files into the models directory and installs other
+// names from the LocalAI gallery
+func (b *localAIBackend) Pull(ctx context.Context, name string, progress chan<- PullProgress) error {
+	if IsGGUFSource(name) {
+		_, err := ImportGGUF(ctx, b.dir, name, progress)
+		return err
+	}
+	defer close(progress)
+
+	var job struct {
+		UUID string `json:"uuid"`
+	}
+	if err := b.client.post(ctx, "/models/apply", map[string]string{"id": name}, &job); err != nil {
+		return fmt.Errorf("failed to install %s: %w", name, err)
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		status, err := b.jobStatus(ctx, job.UUID)
+		if err != nil {
+			return err
+		}
+		if status.Error != nil {
+			return fmt.Errorf("failed to install %s: %v", name, status.Error)
+		}
+		if status.Processed {
+			progress <- PullProgress{Status: "success", Percentage: 100}
+			return nil
+		}
+		select {
+		case progress <- PullProgress{Status: status.Message, Percentage: int(status.Progress)}:
+		default:
+		}
+	}
+}
+
+// galleryJob is the state of a LocalAI gallery install
+type galleryJob struct {
+	Error     interface{} `json:"error"`
+	Processed bool        `json:"processed"`
+	Message   string      `json:"message"`
+	Progress  float64     `json:"progress"`
+}
+
+func (b *localAIBackend) jobStatus(ctx context.Context, uuid string) (*galleryJob, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", b.client.endpoint+"/models/jobs/"+uuid, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.client.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to LocalAI: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("LocalAI: %s", openAIError(body, resp.Status))
+	}
+
+	var status galleryJob
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &status, nil
+}
+
+func (b *localAIBackend) Generate(ctx context.Context, model string, messages []ChatMessage, options map[string]interface{}, onToken func(string)) (string, error) {
+	return b.client.chat(ctx, model, messages, options, onToken)
+}
+
+func (b *localAIBackend) Embed(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	return b.client.embed(ctx, model, texts)
+}
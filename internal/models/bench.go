@@ -0,0 +1,331 @@
+// internal/models/bench.go
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BenchPrompt is one prompt of a benchmark
+type BenchPrompt struct {
+	Name   string `yaml:"name" json:"name"`
+	Prompt string `yaml:"prompt" json:"prompt"`
+}
+
+// DefaultBenchPrompts is the standard prompt set: a short answer, a longer
+// explanation, code and summarisation of a longer input
+var DefaultBenchPrompts = []BenchPrompt{
+	{Name: "short", Prompt: "What is the capital of France? Answer in one sentence."},
+	{Name: "explain", Prompt: "Explain how a hash map works, including how collisions are handled."},
+	{Name: "code", Prompt: "Write a Go function that returns the n-th Fibonacci number iteratively."},
+	{Name: "summarize", Prompt: "Summarize the following text in three bullet points:\n\n" +
+		"Local development environments often differ from production in subtle ways. " +
+		"Containers narrow that gap by packaging services with their dependencies, " +
+		"but running AI models locally adds new concerns: model downloads are large, " +
+		"memory use depends on the model size and quantization, and inference speed " +
+		"varies widely between CPUs and GPUs. Measuring these numbers on the machine " +
+		"that will run the workload is the only reliable way to choose a model."},
+}
+
+// LoadBenchPrompts reads a prompt set from a YAML file, either a list of
+// prompts or a document with a prompts key
+func LoadBenchPrompts(path string) ([]BenchPrompt, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompts: %w", err)
+	}
+
+	var prompts []BenchPrompt
+	if err := yaml.Unmarshal(data, &prompts); err != nil {
+		var doc struct {
+			Prompts []BenchPrompt `yaml:"prompts"`
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid prompts file %s: %w", path, err)
+		}
+		prompts = doc.Prompts
+	}
+
+	for i := range prompts {
+		if strings.TrimSpace(prompts[i].Prompt) == "" {
+			return nil, fmt.Errorf("prompt %d in %s is empty", i+1, path)
+		}
+		if prompts[i].Name == "" {
+			prompts[i].Name = fmt.Sprintf("prompt-%d", i+1)
+		}
+	}
+	if len(prompts) == 0 {
+		return nil, fmt.Errorf("no prompts in %s", path)
+	}
+	return prompts, nil
+}
+
+// BenchOptions configures a benchmark
+type BenchOptions struct {
+	Runs       int // Repetitions of each prompt (default 1)
+	NumPredict int // Maximum tokens generated per prompt (default 128)
+}
+
+// BenchRun is the measurement of one prompt
+type BenchRun struct {
+	Prompt          string        `json:"prompt"`
+	TTFT            time.Duration `json:"ttft"`
+	PromptTokens    int           `json:"prompt_tokens"`
+	PromptTokPerSec float64       `json:"prompt_tokens_per_sec"`
+	EvalTokens      int           `json:"eval_tokens"`
+	EvalTokPerSec   float64       `json:"eval_tokens_per_sec"`
+	Total           time.Duration `json:"total"`
+}
+
+// BenchResult summarises a model's benchmark
+type BenchResult struct {
+	Model           string        `json:"model"`
+	Timestamp       time.Time     `json:"timestamp"`
+	LoadTime        time.Duration `json:"load_time"`
+	TTFT            time.Duration `json:"ttft"`               // Mean over runs
+	PromptTokPerSec float64       `json:"prompt_tok_per_sec"` // Mean over runs
+	EvalTokPerSec   float64       `json:"eval_tok_per_sec"`   // Mean over runs
+	PeakMemory      uint64        `json:"peak_memory,omitempty"`
+	Runs            []BenchRun    `json:"runs"`
+}
+
+// benchResponse is the part of a streamed generate response the benchmark reads
+type benchResponse struct {
+	Response           string `json:"response"`
+	Done               bool   `json:"done"`
+	Error              string `json:"error"`
+	LoadDuration       int64  `json:"load_duration"`
+	PromptEvalCount    int    `json:"prompt_eval_count"`
+	PromptEvalDuration int64  `json:"prompt_eval_duration"`
+	EvalCount          int    `json:"eval_count"`
+	EvalDuration       int64  `json:"eval_duration"`
+}
+
+// Bench measures a model: it is unloaded first so the load time is a cold
+// load, then each prompt is run and timed. Token rates come from Ollama's own
+// counters; time to first token is measured from the request.
+func (m *Manager) Bench(ctx context.Context, model string, prompts []BenchPrompt, opts BenchOptions) (*BenchResult, error) {
+	if opts.Runs <= 0 {
+		opts.Runs = 1
+	}
+	if opts.NumPredict <= 0 {
+		opts.NumPredict = 128
+	}
+
+	result := &BenchResult{Model: model, Timestamp: time.Now().UTC()}
+
+	// Unload, then load with an empty prompt to time a cold load
+	if _, err := m.benchGenerate(ctx, map[string]interface{}{"model": model, "keep_alive": 0}); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	load, err := m.benchGenerate(ctx, map[string]interface{}{"model": model})
+	if err != nil {
+		return nil, err
+	}
+	result.LoadTime = time.Duration(load.LoadDuration)
+	if result.LoadTime == 0 {
+		result.LoadTime = time.Since(start)
+	}
+
+	for run := 0; run < opts.Runs; run++ {
+		for _, prompt := range prompts {
+			r, err := m.benchPrompt(ctx, model, prompt, opts.NumPredict)
+			if err != nil {
+				return nil, fmt.Errorf("prompt %s: %w", prompt.Name, err)
+			}
+			result.Runs = append(result.Runs, *r)
+		}
+	}
+
+	for _, r := range result.Runs {
+		result.TTFT += r.TTFT
+		result.PromptTokPerSec += r.PromptTokPerSec
+		result.EvalTokPerSec += r.EvalTokPerSec
+	}
+	n := len(result.Runs)
+	result.TTFT /= time.Duration(n)
+	result.PromptTokPerSec /= float64(n)
+	result.EvalTokPerSec /= float64(n)
+
+	return result, nil
+}
+
+// benchPrompt streams one generation and times it
+func (m *Manager) benchPrompt(ctx context.Context, model string, prompt BenchPrompt, numPredict int) (*BenchRun, error) {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"model":   model,
+		"prompt":  prompt.Prompt,
+		"stream":  true,
+		"options": map[string]interface{}{"num_predict": numPredict, "temperature": 0},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", m.ollamaEndpoint+"/api/generate", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 0}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("generate failed: %s", ollamaError(body))
+	}
+
+	run := &BenchRun{Prompt: prompt.Name}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var chunk benchResponse
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			return nil, err
+		}
+		if chunk.Error != "" {
+			return nil, fmt.Errorf("generate failed: %s", chunk.Error)
+		}
+		if run.TTFT == 0 && chunk.Response != "" {
+			run.TTFT = time.Since(start)
+		}
+		if chunk.Done {
+			run.Total = time.Since(start)
+			run.PromptTokens = chunk.PromptEvalCount
+			run.EvalTokens = chunk.EvalCount
+			run.PromptTokPerSec = tokensPerSec(chunk.PromptEvalCount, chunk.PromptEvalDuration)
+			run.EvalTokPerSec = tokensPerSec(chunk.EvalCount, chunk.EvalDuration)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if run.Total == 0 {
+		return nil, fmt.Errorf("generate ended without a final response")
+	}
+	if run.TTFT == 0 {
+		run.TTFT = run.Total
+	}
+	return run, nil
+}
+
+// benchGenerate sends a non-streaming generate request
+func (m *Manager) benchGenerate(ctx context.Context, payload map[string]interface{}) (*benchResponse, error) {
+	payload["stream"] = false
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", m.ollamaEndpoint+"/api/generate", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Loading a large model can take minutes
+	client := &http.Client{Timeout: 0}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to load %v: %s", payload["model"], ollamaError(body))
+	}
+
+	var out benchResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func tokensPerSec(count int, duration int64) float64 {
+	if count == 0 || duration <= 0 {
+		return 0
+	}
+	return float64(count) / time.Duration(duration).Seconds()
+}
+
+// BenchDir returns where the current project keeps benchmark results
+func BenchDir() string {
+	return filepath.Join(".localcloud", "bench")
+}
+
+// SaveBenchResult writes a result to dir and returns the file path
+func SaveBenchResult(dir string, result *BenchResult) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	name := strings.NewReplacer(":", "_", "/", "_").Replace(result.Model)
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.json", name, result.Timestamp.Format("20060102-150405")))
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, data, 0644)
+}
+
+// LatestBenchResults returns the newest saved result of each model, sorted
+// by model name
+func LatestBenchResults(dir string) ([]*BenchResult, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]*BenchResult)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var result BenchResult
+		if json.Unmarshal(data, &result) != nil || result.Model == "" {
+			continue
+		}
+		if prev, ok := latest[result.Model]; !ok || result.Timestamp.After(prev.Timestamp) {
+			latest[result.Model] = &result
+		}
+	}
+
+	results := make([]*BenchResult, 0, len(latest))
+	for _, result := range latest {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Model < results[j].Model })
+	return results, nil
+}
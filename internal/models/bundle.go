@@ -0,0 +1,511 @@
+// internal/models/bundle.go
+package models
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Store gives access to an Ollama models directory (OLLAMA_MODELS), which
+// holds manifests/<registry>/<namespace>/<model>/<tag> and
+// blobs/sha256-<hex>. Paths are slash-separated and relative to it.
+type Store interface {
+	// Open opens a file and returns its size
+	Open(path string) (io.ReadCloser, int64, error)
+	// Exists reports whether a file exists
+	Exists(path string) bool
+	// Extract unpacks a tar stream into the directory
+	Extract(r io.Reader) error
+}
+
+// Manifest is an Ollama model manifest
+type Manifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Config        ManifestLayer   `json:"config"`
+	Layers        []ManifestLayer `json:"layers"`
+}
+
+// ManifestLayer references a blob
+type ManifestLayer struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// blobs returns the config and layer blobs
+func (m *Manifest) blobs() []ManifestLayer {
+	return append([]ManifestLayer{m.Config}, m.Layers...)
+}
+
+// BundleIndex is the first entry of a model bundle
+type BundleIndex struct {
+	Format    int       `json:"format"`
+	Name      string    `json:"name"`
+	Manifest  string    `json:"manifest"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	bundleIndexName = "localcloud-bundle.json"
+	bundleFormat    = 1
+)
+
+// BundleProgress reports progress of a bundle operation. stage is
+// "exporting", "verifying" or "importing".
+type BundleProgress func(stage string, done, total int64)
+
+// DefaultModelsDir returns the models directory of a host Ollama install
+func DefaultModelsDir() string {
+	if dir := os.Getenv("OLLAMA_MODELS"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".ollama", "models")
+}
+
+// ManifestPath returns the manifest path of a model name such as
+// "llama3.2:3b", "user/model" or "registry.example.com/ns/model:tag"
+func ManifestPath(name string) (string, error) {
+	repo, tag := name, "latest"
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		repo, tag = name[:i], name[i+1:]
+	}
+
+	parts := strings.Split(repo, "/")
+	switch len(parts) {
+	case 1:
+		parts = []string{"registry.ollama.ai", "library", parts[0]}
+	case 2:
+		parts = append([]string{"registry.ollama.ai"}, parts...)
+	case 3:
+	default:
+		return "", fmt.Errorf("invalid model name %q", name)
+	}
+
+	parts = append(parts, tag)
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `\`) {
+			return "", fmt.Errorf("invalid model name %q", name)
+		}
+	}
+
+	return path.Join(append([]string{"manifests"}, parts...)...), nil
+}
+
+// blobPath returns the path of a blob by digest
+func blobPath(digest string) (string, error) {
+	hexDigest := strings.TrimPrefix(digest, "sha256:")
+	if len(hexDigest) != 64 || hexDigest == digest {
+		return "", fmt.Errorf("invalid blob digest %q", digest)
+	}
+	if _, err := hex.DecodeString(hexDigest); err != nil {
+		return "", fmt.Errorf("invalid blob digest %q", digest)
+	}
+	return "blobs/sha256-" + hexDigest, nil
+}
+
+// ReadManifest reads the manifest of an installed model
+func ReadManifest(store Store, name string) (*Manifest, []byte, error) {
+	manifestPath, err := ManifestPath(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, _, err := store.Open(manifestPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("model %s not found in the Ollama store", name)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid manifest for %s: %w", name, err)
+	}
+	return &manifest, data, nil
+}
+
+// ExportModel writes a model's manifest and blobs from store to w as a tar
+// bundle that ImportModel loads on another machine
+func ExportModel(store Store, name string, w io.Writer, progress BundleProgress) (*BundleIndex, error) {
+	manifestPath, err := ManifestPath(name)
+	if err != nil {
+		return nil, err
+	}
+	manifest, manifestData, err := ReadManifest(store, name)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &BundleIndex{
+		Format:    bundleFormat,
+		Name:      name,
+		Manifest:  manifestPath,
+		CreatedAt: time.Now().UTC(),
+	}
+	for _, blob := range manifest.blobs() {
+		index.Size += blob.Size
+	}
+
+	indexData, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeTarFile(tw, bundleIndexName, indexData); err != nil {
+		return nil, err
+	}
+	if err := writeTarFile(tw, manifestPath, manifestData); err != nil {
+		return nil, err
+	}
+
+	var done int64
+	for _, blob := range manifest.blobs() {
+		blobFile, err := blobPath(blob.Digest)
+		if err != nil {
+			return nil, err
+		}
+
+		r, size, err := store.Open(blobFile)
+		if err != nil {
+			return nil, fmt.Errorf("blob %s of %s is missing: %w", blob.Digest, name, err)
+		}
+
+		hdr := &tar.Header{Name: blobFile, Mode: 0644, Size: size, ModTime: time.Now()}
+		if err := tw.WriteHeader(hdr); err != nil {
+			r.Close()
+			return nil, err
+		}
+
+		base := done
+		_, err = io.Copy(tw, &progressReader{r: r, progress: func(n, _ int64) {
+			if progress != nil {
+				progress("exporting", base+n, index.Size)
+			}
+		}})
+		r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to export blob %s: %w", blob.Digest, err)
+		}
+		done += size
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// ImportModel loads a bundle written by ExportModel into store. The bundle is
+// read twice: every blob is checked against its digest before anything is
+// written, so a damaged copy cannot corrupt the store. Blobs the store already
+// has are skipped. If name is not empty the model is imported under it.
+func ImportModel(store Store, bundlePath, name string, progress BundleProgress) (*BundleIndex, error) {
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	index, manifestData, err := verifyBundle(store, file, info.Size(), progress)
+	if err != nil {
+		return nil, err
+	}
+
+	// The manifest path is derived from the name rather than taken from the
+	// bundle, so a crafted bundle cannot write elsewhere in the store
+	if name != "" {
+		index.Name = name
+	}
+	targetPath, err := ManifestPath(index.Name)
+	if err != nil {
+		return nil, err
+	}
+	index.Manifest = targetPath
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	// Stream blobs the store lacks into it, then the manifest, so the model
+	// only appears once its blobs are in place
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(copyBundle(store, file, pw, info.Size(), progress))
+	}()
+	if err := store.Extract(pr); err != nil {
+		pr.CloseWithError(err)
+		return nil, fmt.Errorf("failed to write blobs: %w", err)
+	}
+	if _, err := io.Copy(io.Discard, pr); err != nil {
+		return nil, err
+	}
+
+	pr, pw = io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := writeTarFile(tw, targetPath, manifestData)
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	if err := store.Extract(pr); err != nil {
+		pr.CloseWithError(err)
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	io.Copy(io.Discard, pr)
+
+	return index, nil
+}
+
+// verifyBundle checks a bundle's index, manifest and blob digests
+func verifyBundle(store Store, r io.Reader, total int64, progress BundleProgress) (*BundleIndex, []byte, error) {
+	counter := &progressReader{r: r, total: total, progress: func(done, total int64) {
+		if progress != nil {
+			progress("verifying", done, total)
+		}
+	}}
+	tr := tar.NewReader(counter)
+
+	var index *BundleIndex
+	var manifestData []byte
+	verified := make(map[string]bool)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("not a model bundle: %w", err)
+		}
+
+		switch {
+		case hdr.Name == bundleIndexName:
+			index = &BundleIndex{}
+			if err := json.NewDecoder(tr).Decode(index); err != nil {
+				return nil, nil, fmt.Errorf("invalid bundle index: %w", err)
+			}
+			if index.Format != bundleFormat {
+				return nil, nil, fmt.Errorf("unsupported bundle format %d", index.Format)
+			}
+
+		case strings.HasPrefix(hdr.Name, "manifests/"):
+			if manifestData, err = io.ReadAll(tr); err != nil {
+				return nil, nil, err
+			}
+
+		case strings.HasPrefix(hdr.Name, "blobs/sha256-"):
+			hash := sha256.New()
+			if _, err := io.Copy(hash, tr); err != nil {
+				return nil, nil, err
+			}
+			digest := "sha256:" + strings.TrimPrefix(hdr.Name, "blobs/sha256-")
+			if got := "sha256:" + hex.EncodeToString(hash.Sum(nil)); got != digest {
+				return nil, nil, fmt.Errorf("blob %s is corrupt (content hashes to %s); copy the bundle again", digest, got)
+			}
+			verified[digest] = true
+		}
+	}
+
+	if index == nil || manifestData == nil {
+		return nil, nil, fmt.Errorf("not a model bundle: index or manifest missing")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid manifest in bundle: %w", err)
+	}
+	for _, blob := range manifest.blobs() {
+		blobFile, err := blobPath(blob.Digest)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !verified[blob.Digest] && !store.Exists(blobFile) {
+			return nil, nil, fmt.Errorf("bundle is missing blob %s", blob.Digest)
+		}
+	}
+
+	return index, manifestData, nil
+}
+
+// copyBundle writes the blobs of a bundle the store does not have to w as a
+// tar stream
+func copyBundle(store Store, r io.Reader, w io.Writer, total int64, progress BundleProgress) error {
+	counter := &progressReader{r: r, total: total, progress: func(done, total int64) {
+		if progress != nil {
+			progress("importing", done, total)
+		}
+	}}
+	tr := tar.NewReader(counter)
+	tw := tar.NewWriter(w)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(hdr.Name, "blobs/sha256-") || store.Exists(hdr.Name) {
+			continue
+		}
+
+		if err := tw.WriteHeader(&tar.Header{Name: hdr.Name, Mode: 0644, Size: hdr.Size, ModTime: time.Now()}); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+// writeTarFile writes a small file with its parent directories
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	dirs := strings.Split(path.Dir(name), "/")
+	for i := range dirs {
+		if dirs[i] == "." {
+			continue
+		}
+		dir := strings.Join(dirs[:i+1], "/") + "/"
+		if err := tw.WriteHeader(&tar.Header{Name: dir, Typeflag: tar.TypeDir, Mode: 0755, ModTime: time.Now()}); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Now()}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// DirStore is a Store backed by a local directory, such as the models
+// directory of a host Ollama install
+type DirStore struct {
+	Root string
+}
+
+// NewDirStore creates a store for a models directory
+func NewDirStore(root string) *DirStore {
+	return &DirStore{Root: root}
+}
+
+func (s *DirStore) path(name string) (string, error) {
+	clean := path.Clean(name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("invalid path %q", name)
+	}
+	return filepath.Join(s.Root, filepath.FromSlash(clean)), nil
+}
+
+// Open opens a file in the directory
+func (s *DirStore) Open(name string) (io.ReadCloser, int64, error) {
+	p, err := s.path(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	file, err := os.Open(p)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	return file, info.Size(), nil
+}
+
+// Exists reports whether a file exists in the directory
+func (s *DirStore) Exists(name string) bool {
+	p, err := s.path(name)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(p)
+	return err == nil
+}
+
+// Extract unpacks a tar stream into the directory. Files are written to a
+// temporary name and renamed, so an interrupted import leaves no partial blob.
+func (s *DirStore) Extract(r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := s.path(hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := writeFileAtomic(target, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeFileAtomic(target string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".import-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
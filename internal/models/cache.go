@@ -0,0 +1,430 @@
+// internal/models/cache.go
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EmbeddingCache stores embeddings keyed by model name, model digest and
+// content hash so unchanged text is never embedded twice. Each model digest
+// has its own table file; a new digest (an updated model) starts empty.
+type EmbeddingCache struct {
+	dir    string
+	mu     sync.Mutex
+	tables map[string]*cacheTable
+}
+
+// cacheTable holds the embeddings for one model digest
+type cacheTable struct {
+	Model   string
+	Digest  string
+	Vectors map[string]*cacheEntry
+	Hits    int64
+	Misses  int64
+
+	dirty bool
+}
+
+type cacheEntry struct {
+	Vector   []float32
+	LastUsed time.Time
+}
+
+// CacheStats summarizes one cache table
+type CacheStats struct {
+	Model   string
+	Digest  string
+	Entries int
+	Hits    int64
+	Misses  int64
+	Size    int64
+}
+
+// HitRate returns the fraction of lookups served from the cache
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// CachePruneOptions selects which cache entries to remove
+type CachePruneOptions struct {
+	All       bool              // Remove everything
+	OlderThan time.Duration     // Remove entries unused for this long (0 keeps all)
+	Installed map[string]string // Model name -> installed digest; tables for other digests are removed
+}
+
+// DefaultEmbeddingCacheDir is where a project keeps its embedding cache
+func DefaultEmbeddingCacheDir() string {
+	return filepath.Join(".localcloud", "cache", "embeddings")
+}
+
+// NewEmbeddingCache opens the cache in dir
+func NewEmbeddingCache(dir string) *EmbeddingCache {
+	return &EmbeddingCache{
+		dir:    dir,
+		tables: make(map[string]*cacheTable),
+	}
+}
+
+// ContentHash returns the cache key for a piece of text
+func ContentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached vector for text embedded by model at digest
+func (c *EmbeddingCache) Get(model, digest, hash string) ([]float32, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	table := c.table(model, digest)
+	entry, ok := table.Vectors[hash]
+	if !ok {
+		table.Misses++
+		table.dirty = true
+		return nil, false
+	}
+
+	table.Hits++
+	entry.LastUsed = time.Now()
+	table.dirty = true
+	return entry.Vector, true
+}
+
+// Put stores a vector
+func (c *EmbeddingCache) Put(model, digest, hash string, vector []float32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	table := c.table(model, digest)
+	table.Vectors[hash] = &cacheEntry{Vector: vector, LastUsed: time.Now()}
+	table.dirty = true
+}
+
+// Flush writes modified tables to disk
+func (c *EmbeddingCache) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, table := range c.tables {
+		if !table.dirty {
+			continue
+		}
+		if err := c.write(table); err != nil {
+			return err
+		}
+		table.dirty = false
+	}
+	return nil
+}
+
+// Stats returns statistics for every table on disk
+func (c *EmbeddingCache) Stats() ([]CacheStats, error) {
+	if err := c.Flush(); err != nil {
+		return nil, err
+	}
+
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []CacheStats
+	for _, path := range files {
+		table, err := readCacheTable(path)
+		if err != nil {
+			continue
+		}
+		info, _ := os.Stat(path)
+		s := CacheStats{
+			Model:   table.Model,
+			Digest:  table.Digest,
+			Entries: len(table.Vectors),
+			Hits:    table.Hits,
+			Misses:  table.Misses,
+		}
+		if info != nil {
+			s.Size = info.Size()
+		}
+		stats = append(stats, s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Model < stats[j].Model
+	})
+	return stats, nil
+}
+
+// Prune removes entries selected by opts and returns how many were removed
+func (c *EmbeddingCache) Prune(opts CachePruneOptions) (int, error) {
+	if err := c.Flush(); err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tables = make(map[string]*cacheTable)
+
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	cutoff := time.Now().Add(-opts.OlderThan)
+	for _, path := range files {
+		table, err := readCacheTable(path)
+		if err != nil {
+			// Unreadable tables are useless; drop them
+			os.Remove(path)
+			continue
+		}
+
+		stale := opts.All
+		if digest, ok := opts.Installed[table.Model]; opts.Installed != nil && (!ok || digest != table.Digest) {
+			stale = true
+		}
+		if stale {
+			removed += len(table.Vectors)
+			if err := os.Remove(path); err != nil {
+				return removed, err
+			}
+			continue
+		}
+
+		if opts.OlderThan <= 0 {
+			continue
+		}
+		before := len(table.Vectors)
+		for hash, entry := range table.Vectors {
+			if entry.LastUsed.Before(cutoff) {
+				delete(table.Vectors, hash)
+			}
+		}
+		if len(table.Vectors) == before {
+			continue
+		}
+		removed += before - len(table.Vectors)
+		if len(table.Vectors) == 0 {
+			if err := os.Remove(path); err != nil {
+				return removed, err
+			}
+			continue
+		}
+		if err := c.write(table); err != nil {
+			return removed, err
+		}
+	}
+
+	return removed, nil
+}
+
+// table returns the in-memory table for a model digest, loading it on first use
+func (c *EmbeddingCache) table(model, digest string) *cacheTable {
+	path := c.path(model, digest)
+	if table, ok := c.tables[path]; ok {
+		return table
+	}
+
+	table, err := readCacheTable(path)
+	if err != nil || table.Model != model || table.Digest != digest {
+		table = &cacheTable{
+			Model:   model,
+			Digest:  digest,
+			Vectors: make(map[string]*cacheEntry),
+		}
+	}
+	c.tables[path] = table
+	return table
+}
+
+func (c *EmbeddingCache) path(model, digest string) string {
+	name := strings.NewReplacer("/", "_", ":", "_", "\\", "_").Replace(model)
+	digest = strings.TrimPrefix(digest, "sha256:")
+	if len(digest) > 12 {
+		digest = digest[:12]
+	}
+	if digest == "" {
+		digest = "unknown"
+	}
+	return filepath.Join(c.dir, fmt.Sprintf("%s-%s.gob", name, digest))
+}
+
+func (c *EmbeddingCache) files() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.gob"))
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// write saves a table atomically so an interrupted run cannot corrupt it
+func (c *EmbeddingCache) write(table *cacheTable) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+
+	path := c.path(table.Model, table.Digest)
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(table); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write embedding cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func readCacheTable(path string) (*cacheTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var table cacheTable
+	if err := gob.NewDecoder(f).Decode(&table); err != nil {
+		return nil, err
+	}
+	if table.Vectors == nil {
+		table.Vectors = make(map[string]*cacheEntry)
+	}
+	return &table, nil
+}
+
+// CachedEmbedder embeds through a Manager, serving repeated texts from an
+// EmbeddingCache
+type CachedEmbedder struct {
+	manager *Manager
+	cache   *EmbeddingCache
+	digests map[string]string
+
+	Hits   int
+	Misses int
+}
+
+// NewCachedEmbedder wraps manager with cache
+func NewCachedEmbedder(manager *Manager, cache *EmbeddingCache) *CachedEmbedder {
+	return &CachedEmbedder{
+		manager: manager,
+		cache:   cache,
+		digests: make(map[string]string),
+	}
+}
+
+// Embed returns embeddings for texts, only sending uncached texts to Ollama
+func (e *CachedEmbedder) Embed(modelName string, texts []string) ([][]float32, error) {
+	digest, err := e.digest(modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(texts))
+	hashes := make([]string, len(texts))
+	var missing []int
+	for i, text := range texts {
+		hashes[i] = ContentHash(text)
+		if vector, ok := e.cache.Get(modelName, digest, hashes[i]); ok {
+			vectors[i] = vector
+			e.Hits++
+		} else {
+			missing = append(missing, i)
+			e.Misses++
+		}
+	}
+
+	if len(missing) == 0 {
+		return vectors, nil
+	}
+
+	batch := make([]string, len(missing))
+	for j, i := range missing {
+		batch[j] = texts[i]
+	}
+	embedded, err := e.manager.Embed(modelName, batch)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range missing {
+		vectors[i] = embedded[j]
+		e.cache.Put(modelName, digest, hashes[i], embedded[j])
+	}
+
+	return vectors, nil
+}
+
+// HitRate returns the fraction of texts served from the cache so far
+func (e *CachedEmbedder) HitRate() float64 {
+	if e.Hits+e.Misses == 0 {
+		return 0
+	}
+	return float64(e.Hits) / float64(e.Hits+e.Misses)
+}
+
+// Flush persists the cache
+func (e *CachedEmbedder) Flush() error {
+	return e.cache.Flush()
+}
+
+// digest looks up the installed digest of a model once per run
+func (e *CachedEmbedder) digest(modelName string) (string, error) {
+	if digest, ok := e.digests[modelName]; ok {
+		return digest, nil
+	}
+
+	digest, err := e.manager.ModelDigest(modelName)
+	if err != nil {
+		return "", err
+	}
+	e.digests[modelName] = digest
+	return digest, nil
+}
+
+// ModelDigest returns the digest of an installed model
+func (m *Manager) ModelDigest(modelName string) (string, error) {
+	installed, err := m.List()
+	if err != nil {
+		return "", fmt.Errorf("failed to list models: %w", err)
+	}
+	for _, model := range installed {
+		if model.Name == modelName || model.Model == modelName || model.Name == modelName+":latest" {
+			return model.Digest, nil
+		}
+	}
+	return "", fmt.Errorf("model %s is not installed", modelName)
+}
+
+// InstalledDigests maps installed model names (with and without the
+// ":latest" tag) to their digests
+func (m *Manager) InstalledDigests() (map[string]string, error) {
+	installed, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	digests := make(map[string]string)
+	for _, model := range installed {
+		digests[model.Name] = model.Digest
+		if strings.HasSuffix(model.Name, ":latest") {
+			digests[strings.TrimSuffix(model.Name, ":latest")] = model.Digest
+		}
+	}
+	return digests, nil
+}
@@ -0,0 +1,141 @@
+// internal/models/chat.go
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ChatMessage is a single message in a chat conversation
+type ChatMessage struct {
+	Role    string `json:"role"` // "system", "user" or "assistant"
+	Content string `json:"content"`
+}
+
+// Chat sends a conversation to Ollama's chat API and returns the reply.
+// When onToken is set the reply is streamed and onToken receives each piece
+// as it arrives. Options are passed through as Ollama model options
+// (num_ctx, temperature, ...).
+func (m *Manager) Chat(modelName string, messages []ChatMessage, options map[string]interface{}, onToken func(string)) (string, error) {
+	return m.ChatContext(context.Background(), modelName, messages, options, onToken)
+}
+
+// ChatContext is Chat with a context that cancels the request, keeping the
+// part of the reply received so far
+func (m *Manager) ChatContext(ctx context.Context, modelName string, messages []ChatMessage, options map[string]interface{}, onToken func(string)) (string, error) {
+	payload := map[string]interface{}{
+		"model":    modelName,
+		"messages": messages,
+		"stream":   onToken != nil,
+	}
+	if len(options) > 0 {
+		payload["options"] = options
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	// Generation time depends on the model and prompt, so there is no timeout
+	client := &http.Client{Timeout: 0}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", m.ollamaEndpoint+"/api/chat", bytes.NewReader(jsonData))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to Ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("chat request failed: %s", strings.TrimSpace(string(body)))
+	}
+
+	type chatResponse struct {
+		Message ChatMessage `json:"message"`
+		Done    bool        `json:"done"`
+		Error   string      `json:"error,omitempty"`
+	}
+
+	if onToken == nil {
+		var result chatResponse
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return "", fmt.Errorf("failed to decode response: %w", err)
+		}
+		if result.Error != "" {
+			return "", fmt.Errorf("chat request failed: %s", result.Error)
+		}
+		return result.Message.Content, nil
+	}
+
+	var reply strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var chunk chatResponse
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			continue
+		}
+		if chunk.Error != "" {
+			return reply.String(), fmt.Errorf("chat request failed: %s", chunk.Error)
+		}
+		if chunk.Message.Content != "" {
+			reply.WriteString(chunk.Message.Content)
+			onToken(chunk.Message.Content)
+		}
+		if chunk.Done {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return reply.String(), err
+	}
+
+	return reply.String(), nil
+}
+
+// ContextLength returns the context window a model was trained with, as
+// reported by Ollama, or 0 if it is unknown
+func (m *Manager) ContextLength(modelName string) (int, error) {
+	details, err := m.GetModelDetails(modelName)
+	if err != nil {
+		return 0, err
+	}
+
+	// An explicit num_ctx parameter (e.g. from a Modelfile) wins
+	if params, ok := details["parameters"].(string); ok {
+		for _, line := range strings.Split(params, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 2 && fields[0] == "num_ctx" {
+				if n, err := strconv.Atoi(fields[1]); err == nil {
+					return n, nil
+				}
+			}
+		}
+	}
+
+	if info, ok := details["model_info"].(map[string]interface{}); ok {
+		for key, value := range info {
+			if strings.HasSuffix(key, ".context_length") {
+				if n, ok := value.(float64); ok {
+					return int(n), nil
+				}
+			}
+		}
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,164 @@
+// internal/models/create.go
+package models
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// CreateRequest describes a model for Ollama's create API. Either From names
+// an existing model to derive from, or Files maps file names to blobs
+// uploaded with PushBlob.
+type CreateRequest struct {
+	Model      string                 `json:"model"`
+	From       string                 `json:"from,omitempty"`
+	Files      map[string]string      `json:"files,omitempty"`
+	Template   string                 `json:"template,omitempty"`
+	System     string                 `json:"system,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// Create creates a model, sending status updates on progress until done
+func (m *Manager) Create(ctx context.Context, req CreateRequest, progress chan<- PullProgress) error {
+	defer close(progress)
+
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", m.ollamaEndpoint+"/api/create", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	// Creating from large files takes a while, so no timeout
+	client := &http.Client{Timeout: 0}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to create model: %s", ollamaError(body))
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var update map[string]interface{}
+		if err := decoder.Decode(&update); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if msg := getString(update, "error"); msg != "" {
+			return fmt.Errorf("failed to create model: %s", msg)
+		}
+
+		select {
+		case progress <- PullProgress{Status: getString(update, "status")}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// PushBlob uploads a local file to Ollama's blob store and returns its
+// digest. Files Ollama already has are not uploaded again. progress, if not
+// nil, is called as bytes are hashed and sent.
+func (m *Manager) PushBlob(ctx context.Context, path string, progress func(done, total int64)) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, &progressReader{r: file, total: info.Size(), progress: progress}); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	digest := "sha256:" + hex.EncodeToString(hash.Sum(nil))
+
+	headReq, err := http.NewRequestWithContext(ctx, "HEAD", m.ollamaEndpoint+"/api/blobs/"+digest, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := m.httpClient.Do(headReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to Ollama: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return digest, nil
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", m.ollamaEndpoint+"/api/blobs/"+digest, &progressReader{r: file, total: info.Size(), progress: progress})
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = info.Size()
+
+	client := &http.Client{Timeout: 0}
+	resp, err = client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload %s: %w", filepath.Base(path), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to upload %s: %s", filepath.Base(path), ollamaError(body))
+	}
+
+	return digest, nil
+}
+
+// ollamaError extracts the message from an Ollama error body
+func ollamaError(body []byte) string {
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+		return apiErr.Error
+	}
+	return string(body)
+}
+
+// progressReader reports how much of a reader has been consumed
+type progressReader struct {
+	r        io.Reader
+	done     int64
+	total    int64
+	progress func(done, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.done += int64(n)
+	if p.progress != nil && n > 0 {
+		p.progress(p.done, p.total)
+	}
+	return n, err
+}
@@ -2,10 +2,15 @@
 package models
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/catalog"
 )
 
 // EmbeddingModel represents an embedding model
@@ -16,59 +21,25 @@ type EmbeddingModel struct {
 	Family     string
 }
 
-// PredefinedEmbeddingModels contains known embedding models
-var PredefinedEmbeddingModels = []EmbeddingModel{
-	{
-		Name:       "nomic-embed-text",
-		Size:       "274MB",
-		Dimensions: 768,
-		Family:     "bert",
-	},
-	{
-		Name:       "mxbai-embed-large",
-		Size:       "670MB",
-		Dimensions: 1024,
-		Family:     "bert",
-	},
-	{
-		Name:       "all-minilm",
-		Size:       "46MB",
-		Dimensions: 384,
-		Family:     "bert",
-	},
-	{
-		Name:       "bge-base",
-		Size:       "420MB",
-		Dimensions: 768,
-		Family:     "bert",
-	},
-	{
-		Name:       "bge-large",
-		Size:       "1.3GB",
-		Dimensions: 1024,
-		Family:     "bert",
-	},
-	{
-		Name:       "e5-base",
-		Size:       "438MB",
-		Dimensions: 768,
-		Family:     "bert",
-	},
-	{
-		Name:       "e5-large",
-		Size:       "1.3GB",
-		Dimensions: 1024,
-		Family:     "bert",
-	},
+// EmbeddingModels returns the embedding models in the model catalog
+func EmbeddingModels() []EmbeddingModel {
+	var list []EmbeddingModel
+	for _, m := range catalog.Default().Embedding() {
+		list = append(list, EmbeddingModel{
+			Name:       m.Name,
+			Size:       m.Size.String(),
+			Dimensions: m.Dimensions,
+			Family:     m.Family,
+		})
+	}
+	return list
 }
 
 // IsEmbeddingModel checks if a model is an embedding model
 func IsEmbeddingModel(modelName string) bool {
-	// 1. Check predefined list
-	for _, em := range PredefinedEmbeddingModels {
-		if em.Name == modelName {
-			return true
-		}
+	// 1. Check the model catalog
+	if m := catalog.Default().Lookup(modelName); m != nil {
+		return m.IsEmbedding()
 	}
 
 	// 2. Check name patterns
@@ -95,9 +66,9 @@ func IsEmbeddingModel(modelName string) bool {
 
 // GetEmbeddingModelInfo returns information about an embedding model
 func GetEmbeddingModelInfo(modelName string) *EmbeddingModel {
-	// Check predefined models
-	for _, em := range PredefinedEmbeddingModels {
-		if em.Name == modelName {
+	// Check the model catalog
+	for _, em := range EmbeddingModels() {
+		if em.Name == strings.TrimSuffix(modelName, ":latest") {
 			return &em
 		}
 	}
@@ -205,7 +176,7 @@ func (m *Manager) ListEmbeddingModels() (installed []Model, available []Embeddin
 		installedMap[model.Name] = true
 	}
 
-	for _, predefined := range PredefinedEmbeddingModels {
+	for _, predefined := range EmbeddingModels() {
 		if !installedMap[predefined.Name] {
 			available = append(available, predefined)
 		}
@@ -213,3 +184,56 @@ func (m *Manager) ListEmbeddingModels() (installed []Model, available []Embeddin
 
 	return installed, available, nil
 }
+
+// Embed generates embeddings for a batch of texts using Ollama's embed API
+func (m *Manager) Embed(modelName string, texts []string) ([][]float32, error) {
+	payload := map[string]interface{}{
+		"model": modelName,
+		"input": texts,
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Loading the model on first use can exceed the default timeout
+	client := &http.Client{Timeout: 5 * time.Minute}
+
+	resp, err := client.Post(
+		m.ollamaEndpoint+"/api/embed",
+		"application/json",
+		bytes.NewReader(jsonData),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to generate embeddings: %s", strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(result.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(result.Embeddings))
+	}
+
+	return result.Embeddings, nil
+}
+
+// EmbeddingDimension returns the vector size produced by a model
+func (m *Manager) EmbeddingDimension(modelName string) (int, error) {
+	embeddings, err := m.Embed(modelName, []string{"dimension probe"})
+	if err != nil {
+		return 0, err
+	}
+	return len(embeddings[0]), nil
+}
@@ -0,0 +1,255 @@
+// internal/models/gguf.go
+package models
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ggufMagic starts every GGUF file
+var ggufMagic = []byte("GGUF")
+
+// GGUFDir is where GGUF models served by llama.cpp and LocalAI are kept:
+// $LOCALCLOUD_GGUF_DIR or ~/.localcloud/models
+func GGUFDir() string {
+	if dir := os.Getenv("LOCALCLOUD_GGUF_DIR"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".localcloud", "models")
+	}
+	return filepath.Join(home, ".localcloud", "models")
+}
+
+// IsGGUFSource reports whether name refers to a GGUF file to import rather
+// than a model to pull from a registry
+func IsGGUFSource(name string) bool {
+	return strings.HasPrefix(name, "hf://") ||
+		strings.HasPrefix(name, "http://") ||
+		strings.HasPrefix(name, "https://") ||
+		strings.HasSuffix(strings.ToLower(name), ".gguf")
+}
+
+// GGUFModelName returns the model name an imported GGUF file gets: its file
+// name without the extension
+func GGUFModelName(source string) string {
+	base := source
+	if i := strings.LastIndexAny(base, "/\\"); i >= 0 {
+		base = base[i+1:]
+	}
+	if i := strings.IndexAny(base, "?#"); i >= 0 {
+		base = base[:i]
+	}
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// GGUFPath returns the file of a model in dir
+func GGUFPath(dir, name string) string {
+	return filepath.Join(dir, name+".gguf")
+}
+
+// ggufURL resolves hf://owner/repo/path/file.gguf to its download URL
+func ggufURL(source string) string {
+	if rest, ok := strings.CutPrefix(source, "hf://"); ok {
+		parts := strings.SplitN(rest, "/", 3)
+		if len(parts) == 3 {
+			return fmt.Sprintf("https://huggingface.co/%s/%s/resolve/main/%s", parts[0], parts[1], parts[2])
+		}
+	}
+	return source
+}
+
+// ImportGGUF copies a GGUF file from a URL, an hf:// reference or a local
+// path into dir and returns the model name. Interrupted downloads resume
+// from the partial file. progress is closed when ImportGGUF returns.
+func ImportGGUF(ctx context.Context, dir, source string, progress chan<- PullProgress) (string, error) {
+	defer close(progress)
+
+	name := GGUFModelName(source)
+	if name == "" {
+		return "", fmt.Errorf("cannot derive a model name from %s", source)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	dest := GGUFPath(dir, name)
+	partial := dest + ".part"
+
+	report := func(done, total int64) {
+		p := PullProgress{Status: "downloading", Total: total, Completed: done}
+		if total > 0 {
+			p.Percentage = int(done * 100 / total)
+		}
+		select {
+		case progress <- p:
+		default:
+		}
+	}
+
+	var err error
+	if strings.Contains(source, "://") {
+		err = downloadGGUF(ctx, ggufURL(source), partial, report)
+	} else {
+		err = copyGGUF(source, partial, report)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkGGUF(partial); err != nil {
+		os.Remove(partial)
+		return "", err
+	}
+	if err := os.Rename(partial, dest); err != nil {
+		return "", err
+	}
+
+	progress <- PullProgress{Status: "success", Percentage: 100}
+	return name, nil
+}
+
+// downloadGGUF downloads url to path, resuming a partial file
+func downloadGGUF(ctx context.Context, url, path string, report func(done, total int64)) error {
+	var offset int64
+	if info, err := os.Stat(path); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	if token := os.Getenv("HF_TOKEN"); token != "" && strings.Contains(url, "huggingface.co") {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	// Downloads take as long as they take; ctx cancels them
+	resp, err := (&http.Client{}).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		offset = 0
+		flags |= os.O_TRUNC
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is already complete
+		return nil
+	default:
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	file, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	reader := &progressReader{r: resp.Body, done: offset, total: total, progress: report}
+	if _, err := io.Copy(file, reader); err != nil {
+		return fmt.Errorf("download interrupted: %w", err)
+	}
+	return file.Close()
+}
+
+// copyGGUF copies a local file to path
+func copyGGUF(source, path string, report func(done, total int64)) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	reader := &progressReader{r: in, total: info.Size(), progress: report}
+	if _, err := io.Copy(out, reader); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// checkGGUF verifies the file starts with the GGUF magic number
+func checkGGUF(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	header := make([]byte, len(ggufMagic))
+	if _, err := io.ReadFull(file, header); err != nil || !bytes.Equal(header, ggufMagic) {
+		return fmt.Errorf("not a GGUF file")
+	}
+	return nil
+}
+
+// ListGGUF returns the GGUF models in dir
+func ListGGUF(dir string) ([]Model, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Model
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".gguf") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		list = append(list, Model{
+			Name:       strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+			Model:      entry.Name(),
+			Size:       info.Size(),
+			ModifiedAt: info.ModTime(),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// RemoveGGUF deletes a GGUF model from dir
+func RemoveGGUF(dir, name string) error {
+	if err := os.Remove(GGUFPath(dir, strings.TrimSuffix(name, ".gguf"))); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("model %s not found in %s", name, dir)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+// internal/models/keepalive.go
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+)
+
+// DefaultKeepAlive is how long Ollama keeps a model loaded when neither the
+// request nor OLLAMA_KEEP_ALIVE says otherwise
+const DefaultKeepAlive = 5 * time.Minute
+
+// ParseKeepAlive parses a keep_alive value the way Ollama does: a duration
+// such as "10m", a number of seconds, "0" to unload right away or a negative
+// value to keep the model loaded
+func ParseKeepAlive(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty keep_alive")
+	}
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid keep_alive %q (use a duration such as 10m, 0 or -1)", s)
+	}
+	return d, nil
+}
+
+// FormatKeepAlive formats a keep_alive duration for display
+func FormatKeepAlive(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "forever"
+	case d == 0:
+		return "unload after use"
+	default:
+		// 10m0s reads as 10m and 1h0m0s as 1h
+		s := d.String()
+		if strings.HasSuffix(s, "m0s") {
+			s = strings.TrimSuffix(s, "0s")
+		}
+		if strings.HasSuffix(s, "h0m") {
+			s = strings.TrimSuffix(s, "0m")
+		}
+		return s
+	}
+}
+
+// keepAliveValue is the keep_alive value sent to Ollama
+func keepAliveValue(d time.Duration) interface{} {
+	if d < 0 {
+		return -1
+	}
+	return d.String()
+}
+
+// KeepAlivePolicy resolves the configured keep_alive of each model
+type KeepAlivePolicy struct {
+	def    *time.Duration
+	models map[string]time.Duration
+}
+
+// NewKeepAlivePolicy validates the services.ai.keep_alive entries
+func NewKeepAlivePolicy(entries []config.ModelKeepAlive) (*KeepAlivePolicy, error) {
+	p := &KeepAlivePolicy{models: make(map[string]time.Duration)}
+	for _, entry := range entries {
+		if entry.Model == "" {
+			return nil, fmt.Errorf("keep_alive entry without a model (use %q for the default)", config.KeepAliveDefault)
+		}
+		d, err := ParseKeepAlive(entry.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("keep_alive for %s: %w", entry.Model, err)
+		}
+		if entry.Model == config.KeepAliveDefault {
+			p.def = &d
+			continue
+		}
+		p.models[keepAliveKey(entry.Model)] = d
+	}
+	return p, nil
+}
+
+func keepAliveKey(model string) string {
+	return strings.ToLower(strings.TrimSuffix(model, ":latest"))
+}
+
+// For returns the keep_alive of a model: its own entry, else the default
+// entry. ok is false when neither is configured.
+func (p *KeepAlivePolicy) For(model string) (d time.Duration, ok bool) {
+	if p == nil {
+		return 0, false
+	}
+	if d, ok := p.models[keepAliveKey(model)]; ok {
+		return d, true
+	}
+	if p.def != nil {
+		return *p.def, true
+	}
+	return 0, false
+}
+
+// Value returns the keep_alive to send with a request for the model
+func (p *KeepAlivePolicy) Value(model string) (interface{}, bool) {
+	d, ok := p.For(model)
+	if !ok {
+		return nil, false
+	}
+	return keepAliveValue(d), true
+}
+
+// Empty reports whether no keep_alive is configured
+func (p *KeepAlivePolicy) Empty() bool {
+	return p == nil || (p.def == nil && len(p.models) == 0)
+}
+
+// RunningModel is a model loaded in Ollama's memory
+type RunningModel struct {
+	Name      string    `json:"name"`
+	Model     string    `json:"model"`
+	Size      int64     `json:"size"`      // Memory in use
+	SizeVRAM  int64     `json:"size_vram"` // Part of Size on the GPU
+	Digest    string    `json:"digest"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Details   struct {
+		Family            string `json:"family"`
+		ParameterSize     string `json:"parameter_size"`
+		QuantizationLevel string `json:"quantization_level"`
+	} `json:"details"`
+}
+
+// Processor describes where the model runs, as ollama ps shows it
+func (r RunningModel) Processor() string {
+	switch {
+	case r.Size == 0 || r.SizeVRAM == 0:
+		return "100% CPU"
+	case r.SizeVRAM >= r.Size:
+		return "100% GPU"
+	default:
+		gpu := r.SizeVRAM * 100 / r.Size
+		return fmt.Sprintf("%d%%/%d%% CPU/GPU", 100-gpu, gpu)
+	}
+}
+
+// Running returns the models loaded in memory
+func (m *Manager) Running() ([]RunningModel, error) {
+	resp, err := m.httpClient.Get(m.ollamaEndpoint + "/api/ps")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Models []RunningModel `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Models, nil
+}
+
+// Unload frees the memory of a loaded model
+func (m *Manager) Unload(ctx context.Context, model string) error {
+	return m.SetKeepAlive(ctx, model, 0)
+}
+
+// SetKeepAlive sends an empty request so the model stays loaded for d from
+// now; 0 unloads it. Embedding models cannot generate, so they get an empty
+// embed request instead.
+func (m *Manager) SetKeepAlive(ctx context.Context, model string, d time.Duration) error {
+	path := "/api/generate"
+	payload := map[string]interface{}{"model": model, "keep_alive": keepAliveValue(d), "stream": false}
+	if IsEmbeddingModel(model) {
+		path = "/api/embed"
+		payload = map[string]interface{}{"model": model, "keep_alive": keepAliveValue(d), "input": []string{}}
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", m.ollamaEndpoint+path, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update %s: %s", model, ollamaError(body))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// EnforceKeepAlive shortens the expiry of loaded models that were last used
+// with a longer keep_alive than their policy, for example by clients that
+// talk to Ollama directly. Ollama restarts the expiry on each request, so
+// resetting it to the policy makes the model unload the policy's duration
+// after its last use (plus the enforcement interval). It returns the models
+// it changed.
+func (m *Manager) EnforceKeepAlive(ctx context.Context, policy *KeepAlivePolicy) ([]string, error) {
+	if policy.Empty() {
+		return nil, nil
+	}
+
+	running, err := m.Running()
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, r := range running {
+		d, ok := policy.For(r.Name)
+		if !ok || d < 0 {
+			continue
+		}
+		// Allow for the clock of a request that just arrived
+		if time.Until(r.ExpiresAt) <= d+time.Second {
+			continue
+		}
+		if err := m.SetKeepAlive(ctx, r.Name, d); err != nil {
+			return changed, err
+		}
+		changed = append(changed, r.Name)
+	}
+	return changed, nil
+}
+
+// WatchKeepAlive runs EnforceKeepAlive every interval until ctx is cancelled.
+// report, if set, is called with the models each pass changed or its error.
+func (m *Manager) WatchKeepAlive(ctx context.Context, policy *KeepAlivePolicy, interval time.Duration, report func(changed []string, err error)) {
+	if policy.Empty() {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := m.EnforceKeepAlive(ctx, policy)
+			if report != nil && (len(changed) > 0 || err != nil) {
+				report(changed, err)
+			}
+		}
+	}
+}
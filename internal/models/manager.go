@@ -3,12 +3,16 @@ package models
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/catalog"
+	"github.com/localcloud-sh/localcloud/internal/config"
 )
 
 // Model represents an AI model
@@ -34,8 +38,10 @@ type PullProgress struct {
 type Provider string
 
 const (
-	ProviderOllama Provider = "ollama"
-	ProviderOpenAI Provider = "openai"
+	ProviderOllama   Provider = "ollama"
+	ProviderOpenAI   Provider = "openai"
+	ProviderLlamaCpp Provider = "llamacpp"
+	ProviderLocalAI  Provider = "localai"
 )
 
 // Manager handles AI model operations
@@ -77,6 +83,13 @@ func NewManager(ollamaEndpoint string) *Manager {
 
 // Pull downloads a model with progress updates
 func (m *Manager) Pull(modelName string, progress chan<- PullProgress) error {
+	return m.PullContext(context.Background(), modelName, progress)
+}
+
+// PullContext downloads a model with progress updates until ctx is
+// cancelled. Ollama keeps the layers downloaded so far, so pulling the same
+// model again resumes the download.
+func (m *Manager) PullContext(ctx context.Context, modelName string, progress chan<- PullProgress) error {
 	defer close(progress)
 
 	// Prepare request
@@ -89,7 +102,7 @@ func (m *Manager) Pull(modelName string, progress chan<- PullProgress) error {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", m.ollamaEndpoint+"/api/pull", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", m.ollamaEndpoint+"/api/pull", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return err
 	}
@@ -137,6 +150,11 @@ func (m *Manager) Pull(modelName string, progress chan<- PullProgress) error {
 
 		lastUpdate = time.Now()
 
+		// Failures after the download started arrive in the stream
+		if msg := getString(update, "error"); msg != "" {
+			return fmt.Errorf("failed to pull model: %s", msg)
+		}
+
 		// Convert to PullProgress
 		p := PullProgress{
 			Status: getString(update, "status"),
@@ -243,15 +261,42 @@ func (m *Manager) Remove(modelName string) error {
 	return nil
 }
 
-// GetActiveModel returns the currently active model from config
-func (m *Manager) GetActiveModel(configDefault string) (string, error) {
-	// For now, return config default
-	// Can be enhanced to track last used model
-	return configDefault, nil
+// Components that have an active model
+const (
+	ComponentLLM       = "llm"
+	ComponentEmbedding = "embedding"
+)
+
+// GetActiveModel returns the model the project uses for component: the
+// default model for llm, the configured embedding model for embedding
+func (m *Manager) GetActiveModel(component string) (string, error) {
+	cfg := config.Get()
+	if cfg == nil {
+		return "", fmt.Errorf("configuration not loaded")
+	}
+
+	switch component {
+	case ComponentLLM:
+		return cfg.Services.AI.Default, nil
+	case ComponentEmbedding:
+		for _, model := range cfg.Services.AI.Models {
+			if IsEmbeddingModel(model) {
+				return model, nil
+			}
+		}
+		return "", nil
+	default:
+		return "", fmt.Errorf("unknown component %s (use llm or embedding)", component)
+	}
 }
 
-// SetActiveModel sets the active model
-func (m *Manager) SetActiveModel(modelName string) error {
+// SetActiveModel makes an installed model the active one for component and
+// saves it to the project configuration
+func (m *Manager) SetActiveModel(modelName, component string) error {
+	if component != ComponentLLM && component != ComponentEmbedding {
+		return fmt.Errorf("unknown component %s (use llm or embedding)", component)
+	}
+
 	// Verify model exists
 	models, err := m.List()
 	if err != nil {
@@ -260,55 +305,80 @@ func (m *Manager) SetActiveModel(modelName string) error {
 
 	found := false
 	for _, model := range models {
-		if model.Name == modelName || model.Model == modelName {
+		if model.Name == modelName || model.Model == modelName || model.Name == modelName+":latest" {
 			found = true
 			break
 		}
 	}
 
 	if !found {
-		return fmt.Errorf("model %s not found", modelName)
+		return fmt.Errorf("model %s is not installed. Download it with: lc models pull %s", modelName, modelName)
 	}
 
-	// TODO: Save to config or state file
-	return nil
+	// Chat models cannot produce embeddings and embedding models cannot chat
+	modelType := m.CheckModelType(modelName)
+	if component == ComponentEmbedding && modelType != "embedding" {
+		return fmt.Errorf("%s is not an embedding model", modelName)
+	}
+	if component == ComponentLLM && modelType == "embedding" {
+		return fmt.Errorf("%s is an embedding model; use --component embedding", modelName)
+	}
+
+	return SaveActiveModel(modelName, component)
 }
 
-// GetRecommendedModels returns a list of recommended models
+// SaveActiveModel records modelName as the active model for component in the
+// project configuration without checking that it is installed
+func SaveActiveModel(modelName, component string) error {
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	switch component {
+	case ComponentLLM:
+		cfg.Services.AI.Default = modelName
+		listed := false
+		for _, model := range cfg.Services.AI.Models {
+			if model == modelName {
+				listed = true
+				break
+			}
+		}
+		if !listed {
+			cfg.Services.AI.Models = append(cfg.Services.AI.Models, modelName)
+		}
+	case ComponentEmbedding:
+		// The project has one embedding model; it replaces the previous one
+		var kept []string
+		for _, model := range cfg.Services.AI.Models {
+			if !IsEmbeddingModel(model) && model != modelName {
+				kept = append(kept, model)
+			}
+		}
+		cfg.Services.AI.Models = append(kept, modelName)
+	}
+
+	return config.Save()
+}
+
+// GetRecommendedModels returns the recommended language models of the model catalog
 func GetRecommendedModels() []struct {
 	Name        string
 	Size        string
 	Description string
 } {
-	return []struct {
+	var list []struct {
 		Name        string
 		Size        string
 		Description string
-	}{
-		{
-			Name:        "qwen2.5:3b",
-			Size:        "2.3GB",
-			Description: "Fast general purpose model with good performance",
-		},
-		{
-			Name:        "deepseek-coder:1.3b",
-			Size:        "1.5GB",
-			Description: "Specialized for code completion and generation",
-		},
-		{
-			Name:        "llama3.2:3b",
-			Size:        "2.0GB",
-			Description: "Latest Llama model with improved capabilities",
-		},
-		{
-			Name:        "phi3:mini",
-			Size:        "2.3GB",
-			Description: "Microsoft's efficient small language model",
-		},
-		{
-			Name:        "gemma2:2b",
-			Size:        "1.6GB",
-			Description: "Google's lightweight open model",
-		},
 	}
+	for _, m := range catalog.Default().Recommended(false) {
+		list = append(list, struct {
+			Name        string
+			Size        string
+			Description string
+		}{m.Name, m.Size.String(), m.Description})
+	}
+	return list
 }
@@ -0,0 +1,846 @@
+// internal/models/mock.go
+package models
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/catalog"
+	"gopkg.in/yaml.v3"
+)
+
+// MockFixtures scripts the responses of the mock backend
+//
+//	responses:
+//	  - match: "capital of france"   # case-insensitive substring of the prompt
+//	    response: "Paris."
+//	  - pattern: "^translate"        # or a regular expression
+//	    model: qwen2.5:3b            # optionally only for one model
+//	    response: "Bonjour"
+//	default: "I am a mock model."    # used when nothing matches
+//	dimensions:
+//	  my-embedder: 512               # embedding size for models not in the registry
+type MockFixtures struct {
+	Responses  []MockResponse `yaml:"responses"`
+	Default    string         `yaml:"default,omitempty"`
+	Dimensions map[string]int `yaml:"dimensions,omitempty"`
+}
+
+// MockResponse is one scripted response
+type MockResponse struct {
+	Match    string `yaml:"match,omitempty"`
+	Pattern  string `yaml:"pattern,omitempty"`
+	Model    string `yaml:"model,omitempty"`
+	Response string `yaml:"response"`
+
+	re *regexp.Regexp
+}
+
+// LoadMockFixtures reads a fixtures file
+func LoadMockFixtures(path string) (*MockFixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	var fixtures MockFixtures
+	if err := yaml.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures: %w", err)
+	}
+
+	for i := range fixtures.Responses {
+		if pattern := fixtures.Responses[i].Pattern; pattern != "" {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fixture pattern %q: %w", pattern, err)
+			}
+			fixtures.Responses[i].re = re
+		}
+	}
+
+	return &fixtures, nil
+}
+
+// MockServer implements the subset of the Ollama API LocalCloud uses, with
+// deterministic output, so integration tests run without downloading models
+type MockServer struct {
+	fixtures *MockFixtures
+	mux      *http.ServeMux
+
+	mu        sync.Mutex
+	installed map[string]time.Time
+	blobs     map[string]bool
+	loaded    map[string]time.Time // Loaded models and when they unload
+}
+
+// NewMockServer creates a mock backend with models already installed.
+// fixtures may be nil.
+func NewMockServer(installed []string, fixtures *MockFixtures) *MockServer {
+	if fixtures == nil {
+		fixtures = &MockFixtures{}
+	}
+
+	s := &MockServer{
+		fixtures:  fixtures,
+		mux:       http.NewServeMux(),
+		installed: make(map[string]time.Time),
+		blobs:     make(map[string]bool),
+		loaded:    make(map[string]time.Time),
+	}
+	now := time.Now()
+	for _, name := range installed {
+		s.installed[mockModelName(name)] = now
+	}
+
+	s.mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Ollama is running"))
+	})
+	s.mux.HandleFunc("GET /api/version", func(w http.ResponseWriter, r *http.Request) {
+		mockJSON(w, http.StatusOK, map[string]string{"version": "0.0.0-mock"})
+	})
+	s.mux.HandleFunc("GET /api/tags", s.handleTags)
+	s.mux.HandleFunc("POST /api/show", s.handleShow)
+	s.mux.HandleFunc("POST /api/pull", s.handlePull)
+	s.mux.HandleFunc("DELETE /api/delete", s.handleDelete)
+	s.mux.HandleFunc("HEAD /api/blobs/{digest}", s.handleBlobExists)
+	s.mux.HandleFunc("POST /api/blobs/{digest}", s.handleBlobUpload)
+	s.mux.HandleFunc("POST /api/create", s.handleCreate)
+	s.mux.HandleFunc("POST /api/generate", s.handleGenerate)
+	s.mux.HandleFunc("POST /api/chat", s.handleChat)
+	s.mux.HandleFunc("POST /api/embed", s.handleEmbed)
+	s.mux.HandleFunc("POST /api/embeddings", s.handleEmbeddings)
+	s.mux.HandleFunc("GET /api/ps", s.handlePs)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves on addr until ctx is cancelled
+func (s *MockServer) ListenAndServe(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// mockModelName adds the implicit ":latest" tag like Ollama does
+func mockModelName(name string) string {
+	if !strings.Contains(name, ":") {
+		return name + ":latest"
+	}
+	return name
+}
+
+func (s *MockServer) isInstalled(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.installed[mockModelName(name)]
+	return ok
+}
+
+// requireModel writes Ollama's not-found error when name is not installed
+func (s *MockServer) requireModel(w http.ResponseWriter, name string) bool {
+	if name == "" {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": "model is required"})
+		return false
+	}
+	if !s.isInstalled(name) {
+		mockJSON(w, http.StatusNotFound, map[string]string{
+			"error": fmt.Sprintf("model %q not found, try pulling it first", name),
+		})
+		return false
+	}
+	return true
+}
+
+// mockDigest returns a stable digest for a model name
+func mockDigest(name string) string {
+	sum := sha256.Sum256([]byte(mockModelName(name)))
+	return hex.EncodeToString(sum[:])
+}
+
+// mockFamily reports the model family shown in /api/show and /api/tags
+func mockFamily(name string) string {
+	if IsEmbeddingModel(strings.TrimSuffix(name, ":latest")) {
+		return "bert"
+	}
+	return "llama"
+}
+
+// Dimensions returns the embedding size the mock produces for a model: the
+// fixtures override, then the model catalog, then 768
+func (s *MockServer) Dimensions(name string) int {
+	base := strings.TrimSuffix(name, ":latest")
+
+	if dim, ok := s.fixtures.Dimensions[name]; ok && dim > 0 {
+		return dim
+	}
+	if dim, ok := s.fixtures.Dimensions[base]; ok && dim > 0 {
+		return dim
+	}
+	if model := catalog.Default().Lookup(name); model != nil && model.Dimensions > 0 {
+		return model.Dimensions
+	}
+	return 768
+}
+
+func (s *MockServer) handleTags(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	names := make([]string, 0, len(s.installed))
+	for name := range s.installed {
+		names = append(names, name)
+	}
+	modified := make(map[string]time.Time, len(s.installed))
+	for name, t := range s.installed {
+		modified[name] = t
+	}
+	s.mu.Unlock()
+	sort.Strings(names)
+
+	list := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		list = append(list, map[string]interface{}{
+			"name":        name,
+			"model":       name,
+			"size":        int64(len(name)) * 1024 * 1024,
+			"digest":      mockDigest(name),
+			"modified_at": modified[name],
+			"details": map[string]interface{}{
+				"format":             "gguf",
+				"family":             mockFamily(name),
+				"families":           []string{mockFamily(name)},
+				"parameter_size":     "mock",
+				"quantization_level": "Q4_0",
+			},
+		})
+	}
+	mockJSON(w, http.StatusOK, map[string]interface{}{"models": list})
+}
+
+// mockModelRequest reads the model name from name or model, as Ollama accepts both
+type mockModelRequest struct {
+	Name   string `json:"name"`
+	Model  string `json:"model"`
+	Stream *bool  `json:"stream"`
+}
+
+func (req mockModelRequest) model() string {
+	if req.Model != "" {
+		return req.Model
+	}
+	return req.Name
+}
+
+func (s *MockServer) handleShow(w http.ResponseWriter, r *http.Request) {
+	var req mockModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	name := req.model()
+	if !s.requireModel(w, name) {
+		return
+	}
+
+	family := mockFamily(name)
+	info := map[string]interface{}{
+		"general.architecture":     family,
+		family + ".context_length": 4096,
+	}
+	if family == "bert" {
+		info[family+".embedding_length"] = s.Dimensions(name)
+	}
+
+	mockJSON(w, http.StatusOK, map[string]interface{}{
+		"modelfile":  "# Mock model\nFROM " + name,
+		"parameters": "num_ctx 4096",
+		"template":   "{{ .Prompt }}",
+		"details": map[string]interface{}{
+			"format":             "gguf",
+			"family":             family,
+			"families":           []string{family},
+			"parameter_size":     "mock",
+			"quantization_level": "Q4_0",
+		},
+		"model_info": info,
+	})
+}
+
+func (s *MockServer) handlePull(w http.ResponseWriter, r *http.Request) {
+	var req mockModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	name := req.model()
+	if name == "" {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": "model is required"})
+		return
+	}
+
+	s.mu.Lock()
+	s.installed[mockModelName(name)] = time.Now()
+	s.mu.Unlock()
+
+	if req.Stream != nil && !*req.Stream {
+		mockJSON(w, http.StatusOK, map[string]string{"status": "success"})
+		return
+	}
+
+	digest := "sha256:" + mockDigest(name)
+	total := int64(len(name)) * 1024 * 1024
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	enc.Encode(map[string]interface{}{"status": "pulling manifest"})
+	for step := int64(1); step <= 4; step++ {
+		enc.Encode(map[string]interface{}{
+			"status":    "pulling " + digest[7:19],
+			"digest":    digest,
+			"total":     total,
+			"completed": total * step / 4,
+		})
+		mockFlush(w)
+	}
+	enc.Encode(map[string]interface{}{"status": "verifying sha256 digest"})
+	enc.Encode(map[string]interface{}{"status": "writing manifest"})
+	enc.Encode(map[string]interface{}{"status": "success"})
+}
+
+func (s *MockServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	var req mockModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	name := req.model()
+	if !s.requireModel(w, name) {
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.installed, mockModelName(name))
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *MockServer) handleBlobExists(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	ok := s.blobs[r.PathValue("digest")]
+	s.mu.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *MockServer) handleBlobUpload(w http.ResponseWriter, r *http.Request) {
+	digest := r.PathValue("digest")
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r.Body); err != nil {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	if got := "sha256:" + hex.EncodeToString(hash.Sum(nil)); got != digest {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": "digest mismatch, expected \"" + digest + "\", got \"" + got + "\""})
+		return
+	}
+
+	s.mu.Lock()
+	s.blobs[digest] = true
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusCreated)
+}
+
+// mockCreateRequest is the part of a create request the mock checks
+type mockCreateRequest struct {
+	Model  string            `json:"model"`
+	From   string            `json:"from"`
+	Files  map[string]string `json:"files"`
+	Stream *bool             `json:"stream"`
+}
+
+func (s *MockServer) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req mockCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	if req.Model == "" {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": "model is required"})
+		return
+	}
+	if req.From == "" && len(req.Files) == 0 {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": "neither 'from' or 'files' was specified"})
+		return
+	}
+	if req.From != "" && !s.requireModel(w, req.From) {
+		return
+	}
+
+	s.mu.Lock()
+	for _, digest := range req.Files {
+		if !s.blobs[digest] {
+			s.mu.Unlock()
+			mockJSON(w, http.StatusBadRequest, map[string]string{"error": "blob " + digest + " not found"})
+			return
+		}
+	}
+	s.installed[mockModelName(req.Model)] = time.Now()
+	s.mu.Unlock()
+
+	if req.Stream != nil && !*req.Stream {
+		mockJSON(w, http.StatusOK, map[string]string{"status": "success"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	enc.Encode(map[string]interface{}{"status": "using existing layer sha256:" + mockDigest(req.Model)})
+	enc.Encode(map[string]interface{}{"status": "writing manifest"})
+	enc.Encode(map[string]interface{}{"status": "success"})
+}
+
+// mockOptions holds the generation options the mock honours
+type mockOptions struct {
+	NumPredict int `json:"num_predict"`
+}
+
+type mockGenerateRequest struct {
+	Model     string          `json:"model"`
+	Prompt    string          `json:"prompt"`
+	System    string          `json:"system"`
+	Stream    *bool           `json:"stream"`
+	Format    json.RawMessage `json:"format"`
+	Options   mockOptions     `json:"options"`
+	KeepAlive json.RawMessage `json:"keep_alive"`
+}
+
+func (s *MockServer) handleGenerate(w http.ResponseWriter, r *http.Request) {
+	var req mockGenerateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	if !s.requireModel(w, req.Model) {
+		return
+	}
+	if !s.touch(w, req.Model, req.KeepAlive) {
+		return
+	}
+
+	// An empty prompt just loads the model
+	if req.Prompt == "" {
+		mockJSON(w, http.StatusOK, map[string]interface{}{
+			"model":       req.Model,
+			"created_at":  time.Now().UTC(),
+			"response":    "",
+			"done":        true,
+			"done_reason": "load",
+		})
+		return
+	}
+
+	words := s.respond(req.Model, req.Prompt, req.Options.NumPredict, len(req.Format) > 0 && string(req.Format) != "null")
+	promptTokens := len(strings.Fields(req.System)) + len(strings.Fields(req.Prompt))
+
+	s.write(w, req.Stream, words, promptTokens, func(content string, done bool) map[string]interface{} {
+		return map[string]interface{}{
+			"model":      req.Model,
+			"created_at": time.Now().UTC(),
+			"response":   content,
+			"done":       done,
+		}
+	})
+}
+
+type mockChatRequest struct {
+	Model     string          `json:"model"`
+	Messages  []ChatMessage   `json:"messages"`
+	Stream    *bool           `json:"stream"`
+	Format    json.RawMessage `json:"format"`
+	Options   mockOptions     `json:"options"`
+	KeepAlive json.RawMessage `json:"keep_alive"`
+}
+
+func (s *MockServer) handleChat(w http.ResponseWriter, r *http.Request) {
+	var req mockChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	if !s.requireModel(w, req.Model) {
+		return
+	}
+	if !s.touch(w, req.Model, req.KeepAlive) {
+		return
+	}
+
+	prompt := ""
+	promptTokens := 0
+	for _, msg := range req.Messages {
+		promptTokens += len(strings.Fields(msg.Content))
+		if msg.Role == "user" {
+			prompt = msg.Content
+		}
+	}
+
+	words := s.respond(req.Model, prompt, req.Options.NumPredict, len(req.Format) > 0 && string(req.Format) != "null")
+
+	s.write(w, req.Stream, words, promptTokens, func(content string, done bool) map[string]interface{} {
+		return map[string]interface{}{
+			"model":      req.Model,
+			"created_at": time.Now().UTC(),
+			"message":    map[string]string{"role": "assistant", "content": content},
+			"done":       done,
+		}
+	})
+}
+
+// write sends a response as one object or as a stream of one word per chunk,
+// with the final object carrying Ollama's done fields
+func (s *MockServer) write(w http.ResponseWriter, stream *bool, words []string, promptTokens int, chunk func(content string, done bool) map[string]interface{}) {
+	final := func(obj map[string]interface{}) map[string]interface{} {
+		obj["done_reason"] = "stop"
+		obj["total_duration"] = int64(len(words)+1) * int64(10*time.Millisecond)
+		obj["load_duration"] = int64(time.Millisecond)
+		obj["prompt_eval_count"] = promptTokens
+		obj["prompt_eval_duration"] = int64(promptTokens) * int64(time.Millisecond)
+		obj["eval_count"] = len(words)
+		obj["eval_duration"] = int64(len(words)) * int64(10*time.Millisecond)
+		return obj
+	}
+
+	if stream != nil && !*stream {
+		mockJSON(w, http.StatusOK, final(chunk(strings.Join(words, ""), true)))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	for _, word := range words {
+		enc.Encode(chunk(word, false))
+		mockFlush(w)
+	}
+	enc.Encode(final(chunk("", true)))
+}
+
+// respond returns the response to prompt split into streaming pieces: a
+// matching fixture, the fixtures' default, or deterministic pseudo-text
+func (s *MockServer) respond(model, prompt string, numPredict int, jsonFormat bool) []string {
+	text, ok := s.fixture(model, prompt)
+	if !ok {
+		text = mockText(model, prompt, numPredict)
+		if jsonFormat {
+			data, _ := json.Marshal(map[string]string{"response": text})
+			text = string(data)
+		}
+	}
+
+	var words []string
+	for i, word := range strings.SplitAfter(text, " ") {
+		if numPredict > 0 && i >= numPredict {
+			break
+		}
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+func (s *MockServer) fixture(model, prompt string) (string, bool) {
+	lower := strings.ToLower(prompt)
+	for _, f := range s.fixtures.Responses {
+		if f.Model != "" && mockModelName(f.Model) != mockModelName(model) {
+			continue
+		}
+		if f.Match != "" && !strings.Contains(lower, strings.ToLower(f.Match)) {
+			continue
+		}
+		if f.re != nil && !f.re.MatchString(prompt) {
+			continue
+		}
+		return f.Response, true
+	}
+	if s.fixtures.Default != "" {
+		return s.fixtures.Default, true
+	}
+	return "", false
+}
+
+var mockVocabulary = strings.Fields(`the a local model response data service
+query result system context answer document vector search cloud project
+container request value input output token stream example simple fast
+stable private network config build deploy test check return process`)
+
+// mockText generates pseudo-text seeded by the model and prompt, so the same
+// request always produces the same response
+func mockText(model, prompt string, numPredict int) string {
+	rng := rand.New(rand.NewSource(mockSeed(model, prompt)))
+
+	count := 16 + rng.Intn(33)
+	if numPredict > 0 && numPredict < count {
+		count = numPredict
+	}
+
+	var b strings.Builder
+	start := true
+	for i := 0; i < count; i++ {
+		word := mockVocabulary[rng.Intn(len(mockVocabulary))]
+		if start {
+			word = strings.ToUpper(word[:1]) + word[1:]
+			start = false
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(word)
+		if i == count-1 || rng.Intn(8) == 0 {
+			b.WriteByte('.')
+			start = true
+		}
+	}
+	return b.String()
+}
+
+func mockSeed(parts ...string) int64 {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return int64(binary.LittleEndian.Uint64(sum[:8]))
+}
+
+type mockEmbedRequest struct {
+	Model      string          `json:"model"`
+	Input      json.RawMessage `json:"input"`
+	Dimensions int             `json:"dimensions"`
+	KeepAlive  json.RawMessage `json:"keep_alive"`
+}
+
+func (s *MockServer) handleEmbed(w http.ResponseWriter, r *http.Request) {
+	var req mockEmbedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	if !s.requireModel(w, req.Model) {
+		return
+	}
+	if !s.touch(w, req.Model, req.KeepAlive) {
+		return
+	}
+
+	var inputs []string
+	var single string
+	if err := json.Unmarshal(req.Input, &single); err == nil {
+		inputs = []string{single}
+	} else if err := json.Unmarshal(req.Input, &inputs); err != nil {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": "input must be a string or an array of strings"})
+		return
+	}
+
+	dim := s.Dimensions(req.Model)
+	if req.Dimensions > 0 && req.Dimensions < dim {
+		dim = req.Dimensions
+	}
+
+	embeddings := make([][]float32, len(inputs))
+	tokens := 0
+	for i, text := range inputs {
+		embeddings[i] = MockEmbedding(req.Model, text, dim)
+		tokens += len(strings.Fields(text))
+	}
+
+	mockJSON(w, http.StatusOK, map[string]interface{}{
+		"model":             req.Model,
+		"embeddings":        embeddings,
+		"prompt_eval_count": tokens,
+	})
+}
+
+// handleEmbeddings serves the legacy single-prompt endpoint
+func (s *MockServer) handleEmbeddings(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Model  string `json:"model"`
+		Prompt string `json:"prompt"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		mockJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	if !s.requireModel(w, req.Model) {
+		return
+	}
+	if !s.touch(w, req.Model, nil) {
+		return
+	}
+
+	mockJSON(w, http.StatusOK, map[string]interface{}{
+		"embedding": MockEmbedding(req.Model, req.Prompt, s.Dimensions(req.Model)),
+	})
+}
+
+// touch loads a model for keep_alive, Ollama's default when it is absent. It
+// writes an error and returns false for an invalid value.
+func (s *MockServer) touch(w http.ResponseWriter, model string, keepAlive json.RawMessage) bool {
+	d := DefaultKeepAlive
+	if len(keepAlive) > 0 && string(keepAlive) != "null" {
+		var value interface{}
+		json.Unmarshal(keepAlive, &value)
+		var err error
+		switch v := value.(type) {
+		case float64:
+			d = time.Duration(v * float64(time.Second))
+		case string:
+			d, err = ParseKeepAlive(v)
+		default:
+			err = fmt.Errorf("invalid keep_alive %s", keepAlive)
+		}
+		if err != nil {
+			mockJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return false
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := mockModelName(model)
+	switch {
+	case d == 0:
+		delete(s.loaded, name)
+	case d < 0:
+		// Ollama reports models kept forever with an expiry centuries away
+		s.loaded[name] = time.Date(2318, 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		s.loaded[name] = time.Now().Add(d)
+	}
+	return true
+}
+
+// handlePs lists loaded models; their memory is the catalog figure
+func (s *MockServer) handlePs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	now := time.Now()
+	var names []string
+	for name, expires := range s.loaded {
+		if expires.Before(now) {
+			delete(s.loaded, name)
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	running := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		size := int64(catalog.GB)
+		if m := catalog.Default().Lookup(name); m != nil {
+			size = int64(m.RAM)
+		}
+		running = append(running, map[string]interface{}{
+			"name":       name,
+			"model":      name,
+			"size":       size,
+			"size_vram":  0,
+			"digest":     mockDigest(name),
+			"expires_at": s.loaded[name],
+		})
+	}
+	s.mu.Unlock()
+
+	mockJSON(w, http.StatusOK, map[string]interface{}{"models": running})
+}
+
+// MockEmbedding returns a deterministic unit vector for text. Words are
+// hashed into buckets, so texts sharing words are more similar than
+// unrelated ones and retrieval tests see meaningful rankings.
+func MockEmbedding(model, text string, dim int) []float32 {
+	vector := make([]float64, dim)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	})
+	for _, word := range words {
+		h := fnv.New64a()
+		h.Write([]byte(model))
+		h.Write([]byte{0})
+		h.Write([]byte(word))
+		sum := h.Sum64()
+		sign := 1.0
+		if sum&1 == 1 {
+			sign = -1.0
+		}
+		vector[(sum>>1)%uint64(dim)] += sign
+	}
+
+	norm := mockNorm(vector)
+
+	// Text without words, or whose words cancel out, still needs a stable,
+	// non-zero vector
+	if norm == 0 {
+		rng := rand.New(rand.NewSource(mockSeed(model, text)))
+		for i := range vector {
+			vector[i] = rng.NormFloat64()
+		}
+		norm = mockNorm(vector)
+	}
+
+	result := make([]float32, dim)
+	for i, v := range vector {
+		result[i] = float32(v / norm)
+	}
+	return result
+}
+
+func mockNorm(vector []float64) float64 {
+	var sum float64
+	for _, v := range vector {
+		sum += v * v
+	}
+	return math.Sqrt(sum)
+}
+
+func mockJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func mockFlush(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,255 @@
+// internal/models/openai_compat.go
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// openAIClient talks to the OpenAI-compatible API that llama.cpp and
+// LocalAI serve under /v1
+type openAIClient struct {
+	endpoint   string
+	name       string // Server name for errors
+	httpClient *http.Client
+}
+
+func newOpenAIClient(endpoint, name string) openAIClient {
+	// Generation time depends on the model and prompt, so requests are
+	// bounded by their context instead of a client timeout
+	return openAIClient{endpoint: endpoint, name: name, httpClient: &http.Client{}}
+}
+
+// get reports whether path answers with 200 within a few seconds
+func (c openAIClient) get(ctx context.Context, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s is not answering at %s", c.name, c.endpoint)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s is not ready: %s", c.name, openAIError(body, resp.Status))
+	}
+	return nil
+}
+
+// post sends a JSON request and decodes the JSON response into out
+func (c openAIClient) post(ctx context.Context, path string, payload, out interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+path, bytes.NewReader(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", c.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", c.name, openAIError(body, resp.Status))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// models returns the IDs listed by GET /v1/models
+func (c openAIClient) models(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint+"/v1/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", c.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s: %s", c.name, openAIError(body, resp.Status))
+	}
+
+	var result struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	ids := make([]string, 0, len(result.Data))
+	for _, m := range result.Data {
+		ids = append(ids, m.ID)
+	}
+	return ids, nil
+}
+
+// chat sends a chat completion. Ollama option names are translated to their
+// OpenAI equivalents so callers can pass the same options to every backend.
+// When onToken is set the reply is streamed as server-sent events.
+func (c openAIClient) chat(ctx context.Context, model string, messages []ChatMessage, options map[string]interface{}, onToken func(string)) (string, error) {
+	payload := map[string]interface{}{
+		"model":    model,
+		"messages": messages,
+		"stream":   onToken != nil,
+	}
+	for key, value := range options {
+		switch key {
+		case "num_predict":
+			payload["max_tokens"] = value
+		case "temperature", "top_p", "top_k", "seed", "stop", "presence_penalty", "frequency_penalty", "repeat_penalty":
+			payload[key] = value
+		}
+	}
+
+	if onToken == nil {
+		var result struct {
+			Choices []struct {
+				Message ChatMessage `json:"message"`
+			} `json:"choices"`
+		}
+		if err := c.post(ctx, "/v1/chat/completions", payload, &result); err != nil {
+			return "", err
+		}
+		if len(result.Choices) == 0 {
+			return "", fmt.Errorf("%s returned no choices", c.name)
+		}
+		return result.Choices[0].Message.Content, nil
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/v1/chat/completions", bytes.NewReader(jsonData))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %w", c.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("%s: %s", c.name, openAIError(body, resp.Status))
+	}
+
+	var reply strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk struct {
+			Choices []struct {
+				Delta ChatMessage `json:"delta"`
+			} `json:"choices"`
+			Error json.RawMessage `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue
+		}
+		if len(chunk.Error) > 0 {
+			return reply.String(), fmt.Errorf("%s: %s", c.name, openAIError([]byte(data), resp.Status))
+		}
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			reply.WriteString(chunk.Choices[0].Delta.Content)
+			onToken(chunk.Choices[0].Delta.Content)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return reply.String(), err
+	}
+	return reply.String(), nil
+}
+
+// embed returns the embeddings of texts in input order
+func (c openAIClient) embed(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	var result struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	payload := map[string]interface{}{"model": model, "input": texts}
+	if err := c.post(ctx, "/v1/embeddings", payload, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(result.Data))
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for i, d := range result.Data {
+		index := d.Index
+		if index < 0 || index >= len(texts) || embeddings[index] != nil {
+			index = i
+		}
+		embeddings[index] = d.Embedding
+	}
+	return embeddings, nil
+}
+
+// openAIError extracts the message of an OpenAI-style error body
+func openAIError(body []byte, status string) string {
+	var apiErr struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil && len(apiErr.Error) > 0 {
+		var detail struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(apiErr.Error, &detail) == nil && detail.Message != "" {
+			return detail.Message
+		}
+		var message string
+		if json.Unmarshal(apiErr.Error, &message) == nil && message != "" {
+			return message
+		}
+	}
+	if text := strings.TrimSpace(string(body)); text != "" {
+		return text
+	}
+	return status
+}
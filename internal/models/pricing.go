@@ -0,0 +1,28 @@
+// internal/models/pricing.go
+package models
+
+// HostedPrice is what a hosted API charges, in USD per million tokens
+type HostedPrice struct {
+	Model  string  `json:"model"`
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+}
+
+// HostedPrices are list prices of popular hosted models, used to estimate
+// what local usage would have cost. Providers change their prices, so
+// estimates are indicative only.
+var HostedPrices = []HostedPrice{
+	{Model: "gpt-4o-mini", Input: 0.15, Output: 0.60},
+	{Model: "gpt-4.1", Input: 2.00, Output: 8.00},
+	{Model: "gpt-4o", Input: 2.50, Output: 10.00},
+	{Model: "claude-3-5-haiku", Input: 0.80, Output: 4.00},
+	{Model: "claude-sonnet-4", Input: 3.00, Output: 15.00},
+}
+
+// HostedEmbeddingPrice is the hosted price embedding tokens are estimated at
+var HostedEmbeddingPrice = HostedPrice{Model: "text-embedding-3-small", Input: 0.02}
+
+// Cost returns the price of the given tokens
+func (p HostedPrice) Cost(promptTokens, completionTokens int) float64 {
+	return (float64(promptTokens)*p.Input + float64(completionTokens)*p.Output) / 1e6
+}
@@ -0,0 +1,285 @@
+// internal/models/profiles.go
+package models
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+)
+
+// Profile sync outcomes
+const (
+	ProfileCreated   = "created"
+	ProfileUpdated   = "updated"
+	ProfileUnchanged = "unchanged"
+	ProfileFailed    = "failed"
+)
+
+// ProfileResult is the outcome of syncing one profile
+type ProfileResult struct {
+	Name   string
+	Base   string
+	Action string
+	Err    error
+}
+
+// ProfileState records the definition each profile model was built from, so
+// a changed definition is detected and the model rebuilt
+type ProfileState struct {
+	Profiles map[string]string `json:"profiles"` // Profile name -> definition digest
+}
+
+// ProfileStatePath returns where the profile state of the current project is kept
+func ProfileStatePath() string {
+	return filepath.Join(".localcloud", "model-profiles.json")
+}
+
+// LoadProfileState reads the profile state, returning an empty state if none exists
+func LoadProfileState(path string) (*ProfileState, error) {
+	state := &ProfileState{Profiles: make(map[string]string)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("invalid profile state %s: %w", path, err)
+	}
+	if state.Profiles == nil {
+		state.Profiles = make(map[string]string)
+	}
+	return state, nil
+}
+
+// Save writes the profile state
+func (s *ProfileState) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ValidateProfile checks that a profile can be built
+func ValidateProfile(profile config.ModelProfile) error {
+	if profile.Name == "" {
+		return fmt.Errorf("model profile without a name")
+	}
+	if profile.Base == "" {
+		return fmt.Errorf("model profile %s has no base model", profile.Name)
+	}
+	if sameModel(profile.Name, profile.Base) {
+		return fmt.Errorf("model profile %s cannot use itself as its base", profile.Name)
+	}
+	if profile.Temperature != nil && (*profile.Temperature < 0 || *profile.Temperature > 2) {
+		return fmt.Errorf("model profile %s: temperature must be between 0 and 2", profile.Name)
+	}
+	if profile.NumCtx < 0 {
+		return fmt.Errorf("model profile %s: num_ctx must be positive", profile.Name)
+	}
+	return nil
+}
+
+// ProfileRequest returns the create request that builds a profile
+func ProfileRequest(profile config.ModelProfile) CreateRequest {
+	req := CreateRequest{
+		Model:    profile.Name,
+		From:     profile.Base,
+		System:   profile.System,
+		Template: profile.Template,
+	}
+
+	params := make(map[string]interface{})
+	if profile.Temperature != nil {
+		params["temperature"] = *profile.Temperature
+	}
+	if profile.NumCtx > 0 {
+		params["num_ctx"] = profile.NumCtx
+	}
+	if len(profile.Stop) > 0 {
+		params["stop"] = profile.Stop
+	}
+	if len(params) > 0 {
+		req.Parameters = params
+	}
+
+	return req
+}
+
+// ProfileDigest identifies a profile definition. A profile built from
+// another profile includes its base's digest, because Ollama copies the base
+// at create time and the profile must be rebuilt when the base changes.
+func ProfileDigest(profiles []config.ModelProfile, profile config.ModelProfile) string {
+	hash := sha256.New()
+	seen := make(map[string]bool)
+	for p := &profile; p != nil && !seen[p.Name]; p = FindProfile(profiles, p.Base) {
+		seen[p.Name] = true
+		data, _ := json.Marshal(ProfileRequest(*p))
+		hash.Write(data)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// SyncProfiles builds the named profiles (all when names is empty) whose
+// model is missing or whose definition changed since it was last built, and
+// records the result in the state file at statePath. force rebuilds them
+// regardless. Profiles are built after the profiles they derive from.
+// Profiles whose base model is not installed fail; pull the base first.
+func (m *Manager) SyncProfiles(ctx context.Context, profiles []config.ModelProfile, names []string, statePath string, force bool) ([]ProfileResult, error) {
+	state, err := LoadProfileState(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	installed, err := m.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+	isInstalled := func(name string) bool {
+		for _, model := range installed {
+			if sameModel(model.Name, name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// A selected profile brings in the profiles it derives from, so it is
+	// never built from an outdated base
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		for p := FindProfile(profiles, name); p != nil && !wanted[p.Name]; p = FindProfile(profiles, p.Base) {
+			wanted[p.Name] = true
+		}
+	}
+	selected := func(name string) bool {
+		return len(names) == 0 || wanted[name]
+	}
+
+	ordered, err := orderProfiles(profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ProfileResult
+	for _, profile := range ordered {
+		if !selected(profile.Name) {
+			continue
+		}
+		result := ProfileResult{Name: profile.Name, Base: profile.Base}
+
+		digest := ProfileDigest(profiles, profile)
+		exists := isInstalled(profile.Name)
+		invalid := ValidateProfile(profile)
+
+		switch {
+		case invalid != nil:
+			result.Action, result.Err = ProfileFailed, invalid
+		case !force && exists && state.Profiles[profile.Name] == digest:
+			result.Action = ProfileUnchanged
+		case !isInstalled(profile.Base):
+			result.Action = ProfileFailed
+			result.Err = fmt.Errorf("base model %s is not installed. Download it with: lc models pull %s", profile.Base, profile.Base)
+		default:
+			progress := make(chan PullProgress)
+			go func() {
+				for range progress {
+				}
+			}()
+			if err := m.Create(ctx, ProfileRequest(profile), progress); err != nil {
+				result.Action, result.Err = ProfileFailed, err
+				break
+			}
+
+			result.Action = ProfileCreated
+			if exists {
+				result.Action = ProfileUpdated
+			}
+			state.Profiles[profile.Name] = digest
+			// Later profiles may use this one as their base
+			installed = append(installed, Model{Name: profile.Name})
+		}
+
+		results = append(results, result)
+	}
+
+	// Forget profiles removed from the configuration
+	for name := range state.Profiles {
+		if FindProfile(profiles, name) == nil {
+			delete(state.Profiles, name)
+		}
+	}
+
+	if err := state.Save(statePath); err != nil {
+		return results, fmt.Errorf("failed to save profile state: %w", err)
+	}
+	return results, nil
+}
+
+// orderProfiles returns profiles with every profile after the profile it is
+// based on
+func orderProfiles(profiles []config.ModelProfile) ([]config.ModelProfile, error) {
+	ordered := make([]config.ModelProfile, 0, len(profiles))
+	visited := make(map[string]int) // 1 while visiting, 2 when done
+
+	var visit func(profile config.ModelProfile) error
+	visit = func(profile config.ModelProfile) error {
+		switch visited[profile.Name] {
+		case 1:
+			return fmt.Errorf("model profile %s is part of a base cycle", profile.Name)
+		case 2:
+			return nil
+		}
+		visited[profile.Name] = 1
+		if base := FindProfile(profiles, profile.Base); base != nil && !sameModel(base.Name, profile.Name) {
+			if err := visit(*base); err != nil {
+				return err
+			}
+		}
+		visited[profile.Name] = 2
+		ordered = append(ordered, profile)
+		return nil
+	}
+
+	for _, profile := range profiles {
+		if err := visit(profile); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// FindProfile returns the profile named name, or nil
+func FindProfile(profiles []config.ModelProfile, name string) *config.ModelProfile {
+	for i := range profiles {
+		if sameModel(profiles[i].Name, name) {
+			return &profiles[i]
+		}
+	}
+	return nil
+}
+
+// sameModel reports whether two names refer to the same model, treating a
+// missing tag as :latest
+func sameModel(a, b string) bool {
+	if !strings.Contains(a, ":") {
+		a += ":latest"
+	}
+	if !strings.Contains(b, ":") {
+		b += ":latest"
+	}
+	return a == b
+}
@@ -0,0 +1,158 @@
+// internal/models/pull.go
+package models
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PullOptions configures PullAll
+type PullOptions struct {
+	Concurrency int           // Models downloaded at once (default 2)
+	Retries     int           // Extra attempts after a failure (default 3)
+	Backoff     time.Duration // Wait before the first retry, doubled each time (default 2s)
+}
+
+// PullUpdate reports progress of one model in a PullAll batch
+type PullUpdate struct {
+	Model    string
+	Progress PullProgress
+	Attempt  int           // 1 for the first attempt
+	Retrying time.Duration // Set when the attempt failed and a retry follows after this delay
+	Err      error         // The error that caused the retry, or the final error when Done
+	Done     bool
+}
+
+// PullResult is the outcome of one model in a PullAll batch
+type PullResult struct {
+	Model    string
+	Err      error
+	Attempts int
+	Duration time.Duration
+}
+
+// maxPullBackoff caps the delay between retries
+const maxPullBackoff = time.Minute
+
+// PullAll downloads models with a bounded number of concurrent pulls,
+// retrying failures with exponential backoff. Ollama keeps partially
+// downloaded layers, so a retry (or a later run after an interruption)
+// resumes where the previous attempt stopped. Updates are sent on updates,
+// which is closed when all pulls have finished. Results are in the order of
+// names.
+func (m *Manager) PullAll(ctx context.Context, names []string, opts PullOptions, updates chan<- PullUpdate) []PullResult {
+	defer close(updates)
+
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 2
+	}
+	if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 2 * time.Second
+	}
+
+	results := make([]PullResult, len(names))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency && w < len(names); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = m.pullWithRetry(ctx, names[i], opts, updates)
+			}
+		}()
+	}
+
+	for i := range names {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// pullWithRetry pulls one model, retrying transient failures
+func (m *Manager) pullWithRetry(ctx context.Context, name string, opts PullOptions, updates chan<- PullUpdate) PullResult {
+	start := time.Now()
+	backoff := opts.Backoff
+	result := PullResult{Model: name}
+
+	for attempt := 1; ; attempt++ {
+		result.Attempts = attempt
+
+		progress := make(chan PullProgress)
+		done := make(chan error, 1)
+		go func() {
+			done <- m.PullContext(ctx, name, progress)
+		}()
+		for p := range progress {
+			updates <- PullUpdate{Model: name, Progress: p, Attempt: attempt}
+		}
+		err := <-done
+
+		if err == nil || ctx.Err() != nil || attempt > opts.Retries || !retryablePullError(err) {
+			result.Err = err
+			result.Duration = time.Since(start)
+			updates <- PullUpdate{Model: name, Attempt: attempt, Err: err, Done: true}
+			return result
+		}
+
+		updates <- PullUpdate{Model: name, Attempt: attempt, Err: err, Retrying: backoff}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			result.Err = ctx.Err()
+			result.Duration = time.Since(start)
+			updates <- PullUpdate{Model: name, Attempt: attempt, Err: result.Err, Done: true}
+			return result
+		}
+
+		backoff *= 2
+		if backoff > maxPullBackoff {
+			backoff = maxPullBackoff
+		}
+	}
+}
+
+// retryablePullError reports whether a failed pull may succeed if repeated.
+// Unknown models and invalid names fail the same way every time.
+func retryablePullError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, permanent := range []string{"file does not exist", "manifest unknown", "invalid model name", "not found"} {
+		if strings.Contains(msg, permanent) {
+			return false
+		}
+	}
+	return true
+}
+
+// UniqueModels returns names without duplicates, treating "name" and
+// "name:latest" as the same model
+func UniqueModels(names []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		key := name
+		if !strings.Contains(key, ":") {
+			key += ":latest"
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, name)
+	}
+	return unique
+}
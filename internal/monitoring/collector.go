@@ -13,6 +13,7 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/models"
 )
 
 // Metrics represents collected metrics for a service
@@ -324,18 +325,54 @@ func (c *Collector) calculateDiskMetrics(stats *types.Stats) DiskMetrics {
 	return metrics
 }
 
+// latestBenchResult returns the newest benchmark of the default model, or of
+// any model if the default has not been benchmarked
+func (c *Collector) latestBenchResult() *models.BenchResult {
+	results, err := models.LatestBenchResults(models.BenchDir())
+	if err != nil || len(results) == 0 {
+		return nil
+	}
+
+	latest := results[0]
+	for _, result := range results {
+		if c.config != nil && result.Model == c.config.Services.AI.Default {
+			return result
+		}
+		if result.Timestamp.After(latest.Timestamp) {
+			latest = result
+		}
+	}
+	return latest
+}
+
 // registerPerformanceHandlers registers service-specific performance collectors
 func (c *Collector) registerPerformanceHandlers() {
 	// AI service performance
 	c.perfHandlers["ai"] = func(containerID string) (map[string]interface{}, error) {
-		// In a real implementation, this would query the Ollama API
-		// For now, return mock data
-		return map[string]interface{}{
-			"models_loaded":   1,
-			"inference_speed": "450 tokens/sec",
-			"active_sessions": 2,
-			"queue_size":      0,
-		}, nil
+		perf := map[string]interface{}{
+			"inference_speed": "not measured (run 'lc models bench')",
+		}
+
+		// Loaded models come from Ollama's /api/ps
+		if c.config != nil && c.config.Services.AI.Port > 0 {
+			manager := models.NewManager(fmt.Sprintf("http://localhost:%d", c.config.Services.AI.Port))
+			if running, err := manager.Running(); err == nil {
+				names := make([]string, 0, len(running))
+				for _, model := range running {
+					names = append(names, model.Name)
+				}
+				perf["models_loaded"] = len(running)
+				perf["loaded_models"] = names
+			}
+		}
+
+		// Inference speed comes from the latest lc models bench run
+		if result := c.latestBenchResult(); result != nil {
+			perf["inference_speed"] = fmt.Sprintf("%.1f tokens/sec", result.EvalTokPerSec)
+			perf["inference_model"] = result.Model
+			perf["benchmarked_at"] = result.Timestamp.Format(time.RFC3339)
+		}
+		return perf, nil
 	}
 
 	// PostgreSQL performance
@@ -26,6 +26,8 @@ var ComponentRoutes = map[string]int{
 	"ai":      11434, // Ollama
 	"cache":   8001,  // Redis Commander or custom interface
 	"queue":   8002,  // Redis Queue interface
+	"vector":  8091,  // lc vector serve
+	"gateway": 8092,  // lc gateway serve
 }
 
 // ServiceConfig represents configuration for a tunneled service
@@ -0,0 +1,269 @@
+// internal/rag/chunker.go
+// Package rag provides document ingestion and retrieval for LocalCloud
+package rag
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Strategy names a chunking strategy
+type Strategy string
+
+const (
+	StrategyAuto     Strategy = "auto"
+	StrategyFixed    Strategy = "fixed"
+	StrategyMarkdown Strategy = "markdown"
+	StrategyCode     Strategy = "code"
+)
+
+// ChunkOptions configures chunking
+type ChunkOptions struct {
+	Strategy  Strategy
+	ChunkSize int // Maximum tokens per chunk
+	Overlap   int // Tokens shared between consecutive fixed-size chunks
+}
+
+// DefaultChunkOptions returns the default chunking configuration
+func DefaultChunkOptions() ChunkOptions {
+	return ChunkOptions{
+		Strategy:  StrategyAuto,
+		ChunkSize: 512,
+		Overlap:   64,
+	}
+}
+
+// TextChunk is a piece of a document with byte offsets into the original text
+type TextChunk struct {
+	Content     string
+	StartOffset int
+	EndOffset   int
+	Heading     string // Markdown section the chunk belongs to, if any
+}
+
+// token is a whitespace-delimited word and its byte span
+type token struct {
+	start, end int
+}
+
+// ParseStrategy validates a strategy name
+func ParseStrategy(name string) (Strategy, error) {
+	switch s := Strategy(strings.ToLower(name)); s {
+	case StrategyAuto, StrategyFixed, StrategyMarkdown, StrategyCode:
+		return s, nil
+	default:
+		return "", fmt.Errorf("unknown chunking strategy: %s (available: auto, fixed, markdown, code)", name)
+	}
+}
+
+// StrategyFor picks the strategy used for a file type when Strategy is auto
+func StrategyFor(fileType FileType) Strategy {
+	switch fileType {
+	case FileTypeMarkdown:
+		return StrategyMarkdown
+	case FileTypeCode:
+		return StrategyCode
+	default:
+		return StrategyFixed
+	}
+}
+
+// Chunk splits text with the configured strategy
+func Chunk(text string, opts ChunkOptions) []TextChunk {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultChunkOptions().ChunkSize
+	}
+	if opts.Overlap < 0 || opts.Overlap >= opts.ChunkSize {
+		opts.Overlap = 0
+	}
+
+	switch opts.Strategy {
+	case StrategyMarkdown:
+		return chunkMarkdown(text, opts)
+	case StrategyCode:
+		return chunkCode(text, opts)
+	default:
+		return chunkFixed(text, 0, len(text), opts)
+	}
+}
+
+// chunkFixed splits text[start:end] into windows of ChunkSize tokens that
+// overlap by Overlap tokens
+func chunkFixed(text string, start, end int, opts ChunkOptions) []TextChunk {
+	tokens := tokenize(text, start, end)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	var chunks []TextChunk
+	step := opts.ChunkSize - opts.Overlap
+	for i := 0; i < len(tokens); i += step {
+		last := i + opts.ChunkSize
+		if last > len(tokens) {
+			last = len(tokens)
+		}
+
+		from, to := tokens[i].start, tokens[last-1].end
+		chunks = append(chunks, TextChunk{
+			Content:     text[from:to],
+			StartOffset: from,
+			EndOffset:   to,
+		})
+
+		if last == len(tokens) {
+			break
+		}
+	}
+
+	return chunks
+}
+
+var headingPattern = regexp.MustCompile(`(?m)^#{1,6}[ \t]+.*$`)
+
+// chunkMarkdown keeps each heading section together and only splits sections
+// that exceed the chunk size. Each chunk records its heading.
+func chunkMarkdown(text string, opts ChunkOptions) []TextChunk {
+	type section struct {
+		start, end int
+		heading    string
+	}
+
+	var sections []section
+	matches := headingPattern.FindAllStringIndex(stripFencedCode(text), -1)
+
+	prev, heading := 0, ""
+	for _, m := range matches {
+		if m[0] > prev {
+			sections = append(sections, section{prev, m[0], heading})
+		}
+		heading = strings.TrimSpace(strings.TrimLeft(text[m[0]:m[1]], "#"))
+		prev = m[0]
+	}
+	sections = append(sections, section{prev, len(text), heading})
+
+	var chunks []TextChunk
+	for _, s := range sections {
+		for _, c := range chunkFixed(text, s.start, s.end, opts) {
+			c.Heading = s.heading
+			chunks = append(chunks, c)
+		}
+	}
+
+	return chunks
+}
+
+// stripFencedCode blanks out fenced code blocks, keeping byte positions, so
+// "# comments" inside code are not mistaken for headings
+func stripFencedCode(text string) string {
+	b := []byte(text)
+	inFence := false
+	lineStart := 0
+	for i := 0; i <= len(b); i++ {
+		if i < len(b) && b[i] != '\n' {
+			continue
+		}
+		line := strings.TrimSpace(string(b[lineStart:i]))
+		isFence := strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~")
+		if inFence || isFence {
+			for j := lineStart; j < i; j++ {
+				b[j] = ' '
+			}
+		}
+		if isFence {
+			inFence = !inFence
+		}
+		lineStart = i + 1
+	}
+	return string(b)
+}
+
+// chunkCode groups top-level blocks (separated by blank lines and starting at
+// column zero) so functions and types are not split unless they are larger
+// than the chunk size
+func chunkCode(text string, opts ChunkOptions) []TextChunk {
+	// Find block boundaries: a non-indented line that follows a blank line
+	boundaries := []int{0}
+	prevBlank := false
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if prevBlank && trimmed != "" && !unicode.IsSpace(rune(line[0])) && !isClosingLine(trimmed) {
+			boundaries = append(boundaries, offset)
+		}
+		prevBlank = trimmed == ""
+		offset += len(line)
+	}
+	boundaries = append(boundaries, len(text))
+
+	var chunks []TextChunk
+	groupStart, groupTokens := 0, 0
+	flush := func(end int) {
+		if groupTokens > 0 {
+			chunks = append(chunks, trimChunk(text, groupStart, end))
+		}
+		groupStart, groupTokens = end, 0
+	}
+
+	for i := 0; i < len(boundaries)-1; i++ {
+		start, end := boundaries[i], boundaries[i+1]
+		n := len(tokenize(text, start, end))
+
+		if n > opts.ChunkSize {
+			// A single oversized block falls back to fixed windows
+			flush(start)
+			chunks = append(chunks, chunkFixed(text, start, end, opts)...)
+			groupStart = end
+			continue
+		}
+		if groupTokens+n > opts.ChunkSize {
+			flush(start)
+		}
+		groupTokens += n
+	}
+	flush(len(text))
+
+	return chunks
+}
+
+// isClosingLine reports lines such as "}" or "end" that close a block
+func isClosingLine(line string) bool {
+	switch line {
+	case "}", "};", ")", "]", "end", "fi", "done", "esac":
+		return true
+	}
+	return false
+}
+
+// trimChunk trims surrounding whitespace while keeping offsets accurate
+func trimChunk(text string, start, end int) TextChunk {
+	for start < end && unicode.IsSpace(rune(text[start])) {
+		start++
+	}
+	for end > start && unicode.IsSpace(rune(text[end-1])) {
+		end--
+	}
+	return TextChunk{Content: text[start:end], StartOffset: start, EndOffset: end}
+}
+
+// tokenize returns the whitespace-delimited tokens of text[start:end].
+// Tokens approximate model tokens closely enough for sizing chunks.
+func tokenize(text string, start, end int) []token {
+	var tokens []token
+	inToken := false
+	tokenStart := 0
+	for i := start; i < end; i++ {
+		space := text[i] == ' ' || text[i] == '\n' || text[i] == '\t' || text[i] == '\r'
+		if !space && !inToken {
+			inToken, tokenStart = true, i
+		} else if space && inToken {
+			inToken = false
+			tokens = append(tokens, token{tokenStart, i})
+		}
+	}
+	if inToken {
+		tokens = append(tokens, token{tokenStart, end})
+	}
+	return tokens
+}
@@ -0,0 +1,120 @@
+// internal/rag/chunker_test.go
+package rag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChunk(t *testing.T) {
+	markdown := "Intro text\n\n# Setup\nInstall it\n\n## Usage\nRun it\n```sh\n# not a heading\n```\n"
+	code := "package main\n\nfunc a() {\n\treturn\n}\n\nfunc b() {\n\treturn\n}\n"
+
+	tests := []struct {
+		name string
+		text string
+		opts ChunkOptions
+		want []TextChunk
+	}{
+		{
+			name: "fixed without overlap",
+			text: "one two three four five",
+			opts: ChunkOptions{Strategy: StrategyFixed, ChunkSize: 2},
+			want: []TextChunk{
+				{Content: "one two", StartOffset: 0, EndOffset: 7},
+				{Content: "three four", StartOffset: 8, EndOffset: 18},
+				{Content: "five", StartOffset: 19, EndOffset: 23},
+			},
+		},
+		{
+			name: "fixed with overlap",
+			text: "one two three four",
+			opts: ChunkOptions{Strategy: StrategyFixed, ChunkSize: 3, Overlap: 1},
+			want: []TextChunk{
+				{Content: "one two three", StartOffset: 0, EndOffset: 13},
+				{Content: "three four", StartOffset: 8, EndOffset: 18},
+			},
+		},
+		{
+			name: "overlap not below chunk size is ignored",
+			text: "one two three",
+			opts: ChunkOptions{Strategy: StrategyFixed, ChunkSize: 2, Overlap: 2},
+			want: []TextChunk{
+				{Content: "one two", StartOffset: 0, EndOffset: 7},
+				{Content: "three", StartOffset: 8, EndOffset: 13},
+			},
+		},
+		{
+			name: "empty text",
+			text: " \n\t ",
+			opts: ChunkOptions{Strategy: StrategyFixed, ChunkSize: 4},
+			want: nil,
+		},
+		{
+			name: "markdown sections keep their heading",
+			text: markdown,
+			opts: ChunkOptions{Strategy: StrategyMarkdown, ChunkSize: 50},
+			want: []TextChunk{
+				{Content: "Intro text", StartOffset: 0, EndOffset: 10},
+				{Content: "# Setup\nInstall it", StartOffset: 12, EndOffset: 30, Heading: "Setup"},
+				{Content: "## Usage\nRun it\n```sh\n# not a heading\n```", StartOffset: 32, EndOffset: 73, Heading: "Usage"},
+			},
+		},
+		{
+			name: "code groups blocks up to the chunk size",
+			text: code,
+			opts: ChunkOptions{Strategy: StrategyCode, ChunkSize: 7},
+			want: []TextChunk{
+				{Content: "package main\n\nfunc a() {\n\treturn\n}", StartOffset: 0, EndOffset: 34},
+				{Content: "func b() {\n\treturn\n}", StartOffset: 36, EndOffset: 56},
+			},
+		},
+		{
+			name: "oversized code block falls back to fixed windows",
+			text: "func a() {\n\tx := 1\n}\n",
+			opts: ChunkOptions{Strategy: StrategyCode, ChunkSize: 3},
+			want: []TextChunk{
+				{Content: "func a() {", StartOffset: 0, EndOffset: 10},
+				{Content: "x := 1", StartOffset: 12, EndOffset: 18},
+				{Content: "}", StartOffset: 19, EndOffset: 20},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Chunk(tt.text, tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chunk() =\n%#v\nwant\n%#v", got, tt.want)
+			}
+			for _, c := range got {
+				if tt.text[c.StartOffset:c.EndOffset] != c.Content {
+					t.Errorf("offsets %d:%d do not match content %q", c.StartOffset, c.EndOffset, c.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	for _, name := range []string{"auto", "fixed", "Markdown", "CODE"} {
+		if _, err := ParseStrategy(name); err != nil {
+			t.Errorf("ParseStrategy(%q): %v", name, err)
+		}
+	}
+	if _, err := ParseStrategy("sentences"); err == nil {
+		t.Error("ParseStrategy accepted an unknown strategy")
+	}
+}
+
+func TestStrategyFor(t *testing.T) {
+	tests := map[FileType]Strategy{
+		FileTypeMarkdown: StrategyMarkdown,
+		FileTypeCode:     StrategyCode,
+		FileTypeText:     StrategyFixed,
+	}
+	for fileType, want := range tests {
+		if got := StrategyFor(fileType); got != want {
+			t.Errorf("StrategyFor(%s) = %s, want %s", fileType, got, want)
+		}
+	}
+}
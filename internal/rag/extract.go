@@ -0,0 +1,220 @@
+// internal/rag/extract.go
+package rag
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// FileType classifies an ingestible file
+type FileType string
+
+const (
+	FileTypeMarkdown FileType = "markdown"
+	FileTypeText     FileType = "text"
+	FileTypeHTML     FileType = "html"
+	FileTypeCode     FileType = "code"
+	FileTypePDF      FileType = "pdf"
+)
+
+var codeExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true,
+	".java": true, ".kt": true, ".rb": true, ".rs": true, ".c": true, ".h": true,
+	".cpp": true, ".hpp": true, ".cs": true, ".php": true, ".swift": true,
+	".scala": true, ".sh": true, ".sql": true, ".lua": true, ".dart": true,
+	".yaml": true, ".yml": true, ".toml": true, ".json": true,
+}
+
+// DetectFileType returns the file type for a path, or false if the file
+// should not be ingested
+func DetectFileType(path string) (FileType, bool) {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".md", ".markdown", ".mdx":
+		return FileTypeMarkdown, true
+	case ".txt", ".text", ".rst", ".log", ".csv":
+		return FileTypeText, true
+	case ".html", ".htm":
+		return FileTypeHTML, true
+	case ".pdf":
+		return FileTypePDF, true
+	}
+	if codeExtensions[ext] {
+		return FileTypeCode, true
+	}
+	return "", false
+}
+
+// Extract reads a file and returns its plain text
+func Extract(path string, fileType FileType) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	switch fileType {
+	case FileTypeHTML:
+		return extractHTML(data)
+	case FileTypePDF:
+		return extractPDF(path, data)
+	default:
+		if !utf8.Valid(data) {
+			return "", fmt.Errorf("not valid UTF-8 text")
+		}
+		return string(data), nil
+	}
+}
+
+// extractHTML returns the visible text of an HTML document
+func extractHTML(data []byte) (string, error) {
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script", "style", "noscript", "template", "head":
+				return
+			}
+		}
+		if n.Type == html.TextNode {
+			if text := strings.TrimSpace(n.Data); text != "" {
+				sb.WriteString(text)
+				sb.WriteString(" ")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode && isBlockElement(n.Data) {
+			sb.WriteString("\n")
+		}
+	}
+	walk(doc)
+
+	return strings.TrimSpace(sb.String()), nil
+}
+
+func isBlockElement(tag string) bool {
+	switch tag {
+	case "p", "div", "br", "li", "tr", "h1", "h2", "h3", "h4", "h5", "h6",
+		"section", "article", "header", "footer", "pre", "blockquote", "table":
+		return true
+	}
+	return false
+}
+
+// extractPDF prefers pdftotext when installed and falls back to a basic
+// extractor that reads text operators from (optionally Flate-compressed)
+// content streams
+func extractPDF(path string, data []byte) (string, error) {
+	if _, err := exec.LookPath("pdftotext"); err == nil {
+		out, err := exec.Command("pdftotext", "-layout", path, "-").Output()
+		if err == nil {
+			return string(out), nil
+		}
+	}
+
+	text := extractPDFText(data)
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("no extractable text found (install pdftotext for better PDF support)")
+	}
+	return text, nil
+}
+
+var (
+	pdfStreamPattern = regexp.MustCompile(`(?s)<<(.*?)>>\s*stream\r?\n(.*?)\r?\nendstream`)
+	pdfTextPattern   = regexp.MustCompile(`(?s)\[(.*?)\]\s*TJ|\((.*?)\)\s*Tj|(T\*|Td|TD|ET)`)
+	pdfStringPattern = regexp.MustCompile(`\(((?:\\.|[^\\)])*)\)`)
+)
+
+func extractPDFText(data []byte) string {
+	var sb strings.Builder
+
+	for _, m := range pdfStreamPattern.FindAllSubmatch(data, -1) {
+		dict, stream := m[1], m[2]
+		if bytes.Contains(dict, []byte("/FlateDecode")) {
+			r, err := zlib.NewReader(bytes.NewReader(stream))
+			if err != nil {
+				continue
+			}
+			inflated, err := io.ReadAll(r)
+			r.Close()
+			if err != nil && len(inflated) == 0 {
+				continue
+			}
+			stream = inflated
+		} else if bytes.Contains(dict, []byte("/Filter")) {
+			// Other filters (images, fonts) carry no text
+			continue
+		}
+
+		for _, op := range pdfTextPattern.FindAllSubmatch(stream, -1) {
+			switch {
+			case op[1] != nil:
+				for _, s := range pdfStringPattern.FindAllSubmatch(op[1], -1) {
+					sb.WriteString(unescapePDFString(s[1]))
+				}
+			case op[2] != nil:
+				sb.WriteString(unescapePDFString(op[2]))
+			default:
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	// Collapse the blank lines produced by positioning operators
+	lines := strings.Split(sb.String(), "\n")
+	var out []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			out = append(out, strings.TrimSpace(line))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func unescapePDFString(s []byte) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r', 't':
+			sb.WriteByte(' ')
+		case '(', ')', '\\':
+			sb.WriteByte(s[i])
+		default:
+			// Octal escapes such as \050
+			if s[i] >= '0' && s[i] <= '7' {
+				v := 0
+				j := i
+				for ; j < len(s) && j < i+3 && s[j] >= '0' && s[j] <= '7'; j++ {
+					v = v*8 + int(s[j]-'0')
+				}
+				sb.WriteByte(byte(v))
+				i = j - 1
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,358 @@
+// internal/rag/ingest.go
+package rag
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+)
+
+// Embedder turns texts into embedding vectors. models.Manager implements it
+// against Ollama's embed API.
+type Embedder interface {
+	Embed(modelName string, texts []string) ([][]float32, error)
+}
+
+// IngestOptions configures an ingestion run
+type IngestOptions struct {
+	Model      string // Embedding model
+	Provider   string // Vector store provider the chunks are stored in
+	Collection string // Collection the chunks are stored in; empty means default
+	Chunk      ChunkOptions
+	BatchSize  int  // Chunks per embedding request
+	Force      bool // Re-ingest files even if unchanged
+	Prune      bool // Remove documents whose files no longer exist
+}
+
+// IngestResult summarizes an ingestion run
+type IngestResult struct {
+	Files    int
+	Ingested int
+	Skipped  int
+	Failed   int
+	Removed  int
+	Chunks   int
+	Errors   map[string]error
+}
+
+// ManifestEntry records what was ingested for a document
+type ManifestEntry struct {
+	Hash       string    `json:"hash"`
+	Model      string    `json:"model"`
+	Strategy   Strategy  `json:"strategy"`
+	ChunkSize  int       `json:"chunk_size"`
+	Overlap    int       `json:"overlap"`
+	Chunks     int       `json:"chunks"`
+	IngestedAt time.Time `json:"ingested_at"`
+}
+
+// Manifest maps document IDs to their last ingestion into one vector store
+// collection, which makes re-ingestion incremental
+type Manifest struct {
+	path      string
+	Documents map[string]ManifestEntry `json:"documents"`
+}
+
+// ManifestPath is where the ingestion manifest of a provider's collection is
+// kept in a project. Each store has its own, so ingesting into one does not
+// mark documents as present in another.
+func ManifestPath(provider, collection string) string {
+	if collection == "" {
+		collection = "default"
+	}
+	return filepath.Join(".localcloud", "rag", fmt.Sprintf("manifest-%s-%s.json", provider, collection))
+}
+
+// LoadManifest reads the manifest, returning an empty one if it does not exist
+func LoadManifest(path string) (*Manifest, error) {
+	m := &Manifest{path: path, Documents: make(map[string]ManifestEntry)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if m.Documents == nil {
+		m.Documents = make(map[string]ManifestEntry)
+	}
+	return m, nil
+}
+
+// Save writes the manifest to disk
+func (m *Manifest) Save() error {
+	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.path, data, 0644)
+}
+
+// Ingester loads files, chunks and embeds them, and stores the chunks
+type Ingester struct {
+	embedder Embedder
+	db       vectordb.VectorDB
+	manifest *Manifest
+	opts     IngestOptions
+
+	// OnFile is called after each file with its document ID and outcome
+	// ("ingested", "skipped", "failed" or "removed")
+	OnFile func(docID, status string, chunks int)
+}
+
+// NewIngester creates an ingester using the project manifest of the target
+// provider and collection
+func NewIngester(embedder Embedder, db vectordb.VectorDB, opts IngestOptions) (*Ingester, error) {
+	if opts.Model == "" {
+		return nil, fmt.Errorf("embedding model is required")
+	}
+	if opts.Provider == "" {
+		return nil, fmt.Errorf("vector store provider is required")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 32
+	}
+
+	manifest, err := LoadManifest(ManifestPath(opts.Provider, opts.Collection))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ingester{
+		embedder: embedder,
+		db:       db,
+		manifest: manifest,
+		opts:     opts,
+	}, nil
+}
+
+// Ingest walks root (a file or directory) and ingests every supported file
+func (in *Ingester) Ingest(ctx context.Context, root string) (IngestResult, error) {
+	result := IngestResult{Errors: make(map[string]error)}
+
+	files, err := collectFiles(root)
+	if err != nil {
+		return result, err
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		result.Files++
+		docID := documentID(path)
+		seen[docID] = true
+
+		chunks, skipped, err := in.ingestFile(ctx, path, docID)
+		switch {
+		case err != nil:
+			result.Failed++
+			result.Errors[docID] = err
+			in.notify(docID, "failed", 0)
+		case skipped:
+			result.Skipped++
+			in.notify(docID, "skipped", 0)
+		default:
+			result.Ingested++
+			result.Chunks += chunks
+			in.notify(docID, "ingested", chunks)
+		}
+	}
+
+	if in.opts.Prune {
+		prefix := documentID(root)
+		for docID := range in.manifest.Documents {
+			if seen[docID] || !(docID == prefix || strings.HasPrefix(docID, strings.TrimSuffix(prefix, "/")+"/") || prefix == ".") {
+				continue
+			}
+			if err := in.db.DeleteDocument(ctx, docID); err != nil {
+				result.Errors[docID] = err
+				continue
+			}
+			delete(in.manifest.Documents, docID)
+			result.Removed++
+			in.notify(docID, "removed", 0)
+		}
+		if err := in.manifest.Save(); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// ingestFile ingests a single file unless its content is unchanged
+func (in *Ingester) ingestFile(ctx context.Context, path, docID string) (int, bool, error) {
+	fileType, _ := DetectFileType(path)
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false, err
+	}
+	sum := sha256.Sum256(raw)
+	hash := hex.EncodeToString(sum[:])
+
+	strategy := in.opts.Chunk.Strategy
+	if strategy == "" || strategy == StrategyAuto {
+		strategy = StrategyFor(fileType)
+	}
+
+	entry := ManifestEntry{
+		Hash:      hash,
+		Model:     in.opts.Model,
+		Strategy:  strategy,
+		ChunkSize: in.opts.Chunk.ChunkSize,
+		Overlap:   in.opts.Chunk.Overlap,
+	}
+
+	if previous, ok := in.manifest.Documents[docID]; ok && !in.opts.Force {
+		if previous.Hash == entry.Hash && previous.Model == entry.Model &&
+			previous.Strategy == entry.Strategy && previous.ChunkSize == entry.ChunkSize &&
+			previous.Overlap == entry.Overlap {
+			return 0, true, nil
+		}
+	}
+
+	text, err := Extract(path, fileType)
+	if err != nil {
+		return 0, false, err
+	}
+
+	opts := in.opts.Chunk
+	opts.Strategy = strategy
+	textChunks := Chunk(text, opts)
+
+	chunks := make([]vectordb.Chunk, len(textChunks))
+	for i, tc := range textChunks {
+		metadata := map[string]interface{}{
+			"source":          docID,
+			"file_type":       string(fileType),
+			"strategy":        string(strategy),
+			"content_hash":    hash,
+			"embedding_model": in.opts.Model,
+		}
+		if tc.Heading != "" {
+			metadata["heading"] = tc.Heading
+		}
+
+		chunks[i] = vectordb.Chunk{
+			DocumentID:  docID,
+			ChunkIndex:  i,
+			Content:     tc.Content,
+			Metadata:    metadata,
+			StartOffset: tc.StartOffset,
+			EndOffset:   tc.EndOffset,
+		}
+	}
+
+	// Embed in batches
+	for start := 0; start < len(chunks); start += in.opts.BatchSize {
+		end := start + in.opts.BatchSize
+		if end > len(chunks) {
+			end = len(chunks)
+		}
+
+		texts := make([]string, end-start)
+		for i := range texts {
+			texts[i] = chunks[start+i].Content
+		}
+
+		vectors, err := in.embedder.Embed(in.opts.Model, texts)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to embed chunks: %w", err)
+		}
+		for i, v := range vectors {
+			chunks[start+i].Vector = v
+		}
+	}
+
+	// Replace previous chunks so a shorter document leaves no stale ones
+	if err := in.db.DeleteDocument(ctx, docID); err != nil {
+		return 0, false, fmt.Errorf("failed to remove previous chunks: %w", err)
+	}
+	if err := in.db.StoreChunks(ctx, chunks); err != nil {
+		return 0, false, fmt.Errorf("failed to store chunks: %w", err)
+	}
+
+	entry.Chunks = len(chunks)
+	entry.IngestedAt = time.Now()
+	in.manifest.Documents[docID] = entry
+	if err := in.manifest.Save(); err != nil {
+		return 0, false, err
+	}
+
+	return len(chunks), false, nil
+}
+
+func (in *Ingester) notify(docID, status string, chunks int) {
+	if in.OnFile != nil {
+		in.OnFile(docID, status, chunks)
+	}
+}
+
+// collectFiles returns the supported files under root in a stable order
+func collectFiles(root string) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		if _, ok := DetectFileType(root); !ok {
+			return nil, fmt.Errorf("unsupported file type: %s", root)
+		}
+		return []string{root}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if _, ok := DetectFileType(path); ok {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+
+	return files, err
+}
+
+// documentID identifies a file by its slash-separated path relative to the
+// working directory
+func documentID(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(cwd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
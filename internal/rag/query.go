@@ -0,0 +1,180 @@
+// internal/rag/query.go
+package rag
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/localcloud-sh/localcloud/internal/models"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+)
+
+// systemPrompt instructs the model to answer from the numbered sources only
+const systemPrompt = `You answer questions using only the numbered sources provided.
+Cite the sources you use with their numbers in square brackets, for example [1] or [2][3].
+If the sources do not contain the answer, say that you don't know.`
+
+// Source is a retrieved chunk that an answer can cite
+type Source struct {
+	Number      int     `json:"number"`
+	DocumentID  string  `json:"document_id"`
+	ChunkIndex  int     `json:"chunk_index"`
+	StartOffset int     `json:"start_offset"`
+	EndOffset   int     `json:"end_offset"`
+	Score       float32 `json:"score"`
+	Heading     string  `json:"heading,omitempty"`
+	Content     string  `json:"content"`
+}
+
+// Location returns the document ID with its byte range, e.g. docs/a.md:120-480
+func (s Source) Location() string {
+	if s.EndOffset > s.StartOffset {
+		return fmt.Sprintf("%s:%d-%d", s.DocumentID, s.StartOffset, s.EndOffset)
+	}
+	return fmt.Sprintf("%s#%d", s.DocumentID, s.ChunkIndex)
+}
+
+// Retrieve embeds the question and runs a hybrid search for it
+func Retrieve(ctx context.Context, embedder Embedder, db vectordb.VectorDB, model, question string, limit int) ([]vectordb.SearchResult, error) {
+	vectors, err := embedder.Embed(model, []string{question})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed question: %w", err)
+	}
+
+	results, err := db.HybridSearch(ctx, question, vectors[0], limit)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	return results, nil
+}
+
+// SelectSources numbers search results in rank order and keeps as many as fit
+// in budget tokens. Duplicate chunks are dropped, and no source is returned
+// when the budget cannot hold any of the best match.
+func SelectSources(results []vectordb.SearchResult, budget int) []Source {
+	var sources []Source
+	seen := make(map[string]bool)
+	used := 0
+
+	for _, r := range results {
+		key := fmt.Sprintf("%s#%d", r.DocumentID, r.ChunkIndex)
+		if seen[key] || strings.TrimSpace(r.Content) == "" {
+			continue
+		}
+
+		cost := EstimateTokens(r.Content) + 16 // source header
+		if used+cost > budget {
+			if len(sources) == 0 {
+				// Always include the best match, truncated to the budget
+				r.Content = truncateTokens(r.Content, budget-16)
+				if strings.TrimSpace(r.Content) == "" {
+					break
+				}
+			} else {
+				break
+			}
+		}
+		seen[key] = true
+		used += cost
+
+		source := Source{
+			Number:      len(sources) + 1,
+			DocumentID:  r.DocumentID,
+			ChunkIndex:  r.ChunkIndex,
+			StartOffset: r.StartOffset,
+			EndOffset:   r.EndOffset,
+			Score:       r.Score,
+			Content:     r.Content,
+		}
+		source.Heading, _ = r.Metadata["heading"].(string)
+		sources = append(sources, source)
+	}
+
+	return sources
+}
+
+// FormatContext renders sources as the numbered context block sent to the model
+func FormatContext(sources []Source) string {
+	var sb strings.Builder
+	for _, s := range sources {
+		fmt.Fprintf(&sb, "[%d] %s", s.Number, s.DocumentID)
+		if s.Heading != "" {
+			fmt.Fprintf(&sb, " (%s)", s.Heading)
+		}
+		sb.WriteString("\n")
+		sb.WriteString(strings.TrimSpace(s.Content))
+		sb.WriteString("\n\n")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// BuildMessages returns the chat messages for answering a question from sources
+func BuildMessages(question string, sources []Source) []models.ChatMessage {
+	return []models.ChatMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: fmt.Sprintf("Sources:\n\n%s\n\nQuestion: %s", FormatContext(sources), question)},
+	}
+}
+
+// GroundConversation returns a copy of a conversation whose last user
+// message carries the numbered sources. The grounding instructions are added
+// to its system prompt, or become the system prompt if it has none.
+func GroundConversation(messages []models.ChatMessage, sources []Source) []models.ChatMessage {
+	grounded := make([]models.ChatMessage, 0, len(messages)+1)
+	if len(messages) == 0 || messages[0].Role != "system" {
+		grounded = append(grounded, models.ChatMessage{Role: "system", Content: systemPrompt})
+	}
+	grounded = append(grounded, messages...)
+	if grounded[0].Content != systemPrompt {
+		grounded[0].Content = strings.TrimSpace(grounded[0].Content) + "\n\n" + systemPrompt
+	}
+
+	for i := len(grounded) - 1; i > 0; i-- {
+		if grounded[i].Role == "user" {
+			grounded[i].Content = fmt.Sprintf("Sources:\n\n%s\n\nQuestion: %s", FormatContext(sources), grounded[i].Content)
+			break
+		}
+	}
+	return grounded
+}
+
+// ContextBudget returns how many tokens of sources fit in a context window
+// after reserving room for the prompt, question and answer
+func ContextBudget(window, answerTokens int, question string) int {
+	budget := window - answerTokens - EstimateTokens(systemPrompt) - EstimateTokens(question) - 32
+	if budget < 0 {
+		return 0
+	}
+	return budget
+}
+
+// EstimateTokens approximates the number of model tokens in text. It errs on
+// the high side so assembled prompts stay within the context window.
+func EstimateTokens(text string) int {
+	byChars := (len(text) + 3) / 4
+	byWords := len(tokenize(text, 0, len(text))) * 4 / 3
+	if byWords > byChars {
+		return byWords
+	}
+	return byChars
+}
+
+// truncateTokens shortens text to roughly the given number of tokens
+func truncateTokens(text string, tokens int) string {
+	if tokens <= 0 {
+		return ""
+	}
+	for EstimateTokens(text) > tokens {
+		words := tokenize(text, 0, len(text))
+		keep := len(words) * tokens / EstimateTokens(text)
+		if keep >= len(words) {
+			keep = len(words) - 1
+		}
+		if keep <= 0 {
+			return ""
+		}
+		text = text[:words[keep-1].end]
+	}
+	return text
+}
@@ -27,10 +27,21 @@ func NewService(cfg *config.DatabaseConfig) *Service {
 	}
 }
 
+// NewServiceWithDSN creates a service that connects with a connection string
+// instead of the project's port and credentials
+func NewServiceWithDSN(dsn string, cfg *config.DatabaseConfig) *Service {
+	return &Service{
+		config:     cfg,
+		connString: dsn,
+	}
+}
+
 // Initialize initializes the PostgreSQL service
 func (s *Service) Initialize() error {
 	// Generate connection string
-	s.connString = s.generateConnectionString()
+	if s.connString == "" {
+		s.connString = s.generateConnectionString()
+	}
 
 	// Wait for database to be ready
 	if err := s.waitForReady(); err != nil {
@@ -0,0 +1,299 @@
+// internal/services/redis/client.go
+// Package redis is a minimal client for the project's Redis cache service.
+// It speaks RESP2 over a small pool of connections and covers the commands
+// LocalCloud itself uses.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds a command when its context has no deadline
+const DefaultTimeout = 5 * time.Second
+
+// maxIdle is the number of connections kept open between commands
+const maxIdle = 8
+
+// Error is an error reply from Redis, such as a wrong type or unknown command
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// conn is a connection with its reply reader
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// Client sends commands to a Redis server
+type Client struct {
+	addr string
+	idle chan *conn
+}
+
+// NewClient creates a client for the server at addr (host:port). Connections
+// are opened on first use.
+func NewClient(addr string) *Client {
+	return &Client{addr: addr, idle: make(chan *conn, maxIdle)}
+}
+
+// Addr returns the server address
+func (c *Client) Addr() string {
+	return c.addr
+}
+
+// Do sends a command and returns its reply: a string for simple and bulk
+// strings, an int64 for integers, a []interface{} for arrays and nil for
+// null replies. Error replies are returned as Error.
+func (c *Client) Do(ctx context.Context, args ...string) (interface{}, error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(DefaultTimeout)
+	}
+	cn.SetDeadline(deadline)
+
+	reply, err := cn.do(args)
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) {
+		// The connection is in an unknown state
+		cn.Close()
+		return nil, err
+	}
+	c.put(cn)
+	return reply, err
+}
+
+// get returns an idle connection or dials a new one
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	select {
+	case cn := <-c.idle:
+		return cn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: DefaultTimeout}
+	nc, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis at %s: %w", c.addr, err)
+	}
+	return &conn{Conn: nc, reader: bufio.NewReader(nc)}, nil
+}
+
+// put returns a connection to the pool, closing it if the pool is full
+func (c *Client) put(cn *conn) {
+	select {
+	case c.idle <- cn:
+	default:
+		cn.Close()
+	}
+}
+
+// Close closes the idle connections
+func (c *Client) Close() error {
+	for {
+		select {
+		case cn := <-c.idle:
+			cn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (cn *conn) do(args []string) (interface{}, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(cn, sb.String()); err != nil {
+		return nil, err
+	}
+	return cn.readReply()
+}
+
+// readReply reads one RESP2 reply
+func (cn *conn) readReply() (interface{}, error) {
+	line, err := cn.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(cn.reader, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		var firstErr error
+		for i := range items {
+			item, err := cn.readReply()
+			var replyErr Error
+			if err != nil && !errors.As(err, &replyErr) {
+				return nil, err
+			}
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			items[i] = item
+		}
+		return items, firstErr
+	}
+	return nil, fmt.Errorf("redis: unexpected reply %q", line)
+}
+
+// Ping checks that the server responds
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Do(ctx, "PING")
+	return err
+}
+
+// Get returns the value of key and whether it exists
+func (c *Client) Get(ctx context.Context, key string) (string, bool, error) {
+	reply, err := c.Do(ctx, "GET", key)
+	if err != nil || reply == nil {
+		return "", false, err
+	}
+	value, ok := reply.(string)
+	return value, ok, nil
+}
+
+// Set sets key to value, expiring after ttl when it is positive
+func (c *Client) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	args := []string{"SET", key, value}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := c.Do(ctx, args...)
+	return err
+}
+
+// Del deletes keys and returns how many existed
+func (c *Client) Del(ctx context.Context, keys ...string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	reply, err := c.Do(ctx, append([]string{"DEL"}, keys...)...)
+	n, _ := reply.(int64)
+	return n, err
+}
+
+// Expire sets a key's time to live
+func (c *Client) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	_, err := c.Do(ctx, "PEXPIRE", key, strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// HSet sets fields of the hash at key
+func (c *Client) HSet(ctx context.Context, key string, fields map[string]string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	args := []string{"HSET", key}
+	for field, value := range fields {
+		args = append(args, field, value)
+	}
+	_, err := c.Do(ctx, args...)
+	return err
+}
+
+// HGetAll returns every field of the hash at key
+func (c *Client) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	reply, err := c.Do(ctx, "HGETALL", key)
+	if err != nil {
+		return nil, err
+	}
+	items, _ := reply.([]interface{})
+	fields := make(map[string]string, len(items)/2)
+	for i := 0; i+1 < len(items); i += 2 {
+		field, _ := items[i].(string)
+		value, _ := items[i+1].(string)
+		fields[field] = value
+	}
+	return fields, nil
+}
+
+// HDel deletes fields of the hash at key
+func (c *Client) HDel(ctx context.Context, key string, fields ...string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	_, err := c.Do(ctx, append([]string{"HDEL", key}, fields...)...)
+	return err
+}
+
+// HIncrBy adds n to a field of the hash at key and returns the new value
+func (c *Client) HIncrBy(ctx context.Context, key, field string, n int64) (int64, error) {
+	reply, err := c.Do(ctx, "HINCRBY", key, field, strconv.FormatInt(n, 10))
+	value, _ := reply.(int64)
+	return value, err
+}
+
+// Scan returns the keys matching a glob pattern. Unlike KEYS it does not
+// block the server while iterating.
+func (c *Client) Scan(ctx context.Context, match string) ([]string, error) {
+	var keys []string
+	cursor := "0"
+	for {
+		reply, err := c.Do(ctx, "SCAN", cursor, "MATCH", match, "COUNT", "500")
+		if err != nil {
+			return nil, err
+		}
+		items, _ := reply.([]interface{})
+		if len(items) != 2 {
+			return nil, fmt.Errorf("redis: unexpected SCAN reply")
+		}
+		cursor, _ = items[0].(string)
+		batch, _ := items[1].([]interface{})
+		for _, item := range batch {
+			if key, ok := item.(string); ok {
+				keys = append(keys, key)
+			}
+		}
+		if cursor == "0" || cursor == "" {
+			return keys, nil
+		}
+	}
+}
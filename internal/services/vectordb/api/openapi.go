@@ -0,0 +1,225 @@
+// internal/services/vectordb/api/openapi.go
+package api
+
+// OpenAPISpec describes the vector API. It is served at /openapi.json.
+const OpenAPISpec = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "LocalCloud Vector API",
+    "version": "1.0.0",
+    "description": "JSON API over the project's vector database (pgvector, Qdrant or Chroma). Send either precomputed vectors or text; text is embedded with the configured embedding model. When an API key is set, send it as 'Authorization: Bearer <key>' or 'X-API-Key: <key>'."
+  },
+  "servers": [{"url": "http://localhost:8091"}],
+  "security": [{"bearerAuth": []}, {"apiKey": []}],
+  "paths": {
+    "/health": {
+      "get": {
+        "summary": "Service health",
+        "security": [],
+        "responses": {"200": {"description": "Service is up", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Health"}}}}}
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "summary": "This document",
+        "security": [],
+        "responses": {"200": {"description": "OpenAPI document"}}
+      }
+    },
+    "/v1/documents": {
+      "post": {
+        "summary": "Upsert documents",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {
+          "type": "object",
+          "required": ["documents"],
+          "properties": {"documents": {"type": "array", "items": {"$ref": "#/components/schemas/Document"}}}
+        }}}},
+        "responses": {
+          "200": {"description": "Documents stored", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Upserted"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "500": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/documents/{id}": {
+      "delete": {
+        "summary": "Delete a document and all of its chunks",
+        "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}, "description": "Document ID; may contain slashes"}],
+        "responses": {
+          "200": {"description": "Document deleted", "content": {"application/json": {"schema": {"type": "object", "properties": {"deleted": {"type": "string"}}}}}},
+          "500": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/chunks": {
+      "post": {
+        "summary": "Upsert document chunks",
+        "description": "Chunks are stored in the default collection, keyed by document_id and chunk_index.",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {
+          "type": "object",
+          "required": ["chunks"],
+          "properties": {"chunks": {"type": "array", "items": {"$ref": "#/components/schemas/Chunk"}}}
+        }}}},
+        "responses": {
+          "200": {"description": "Chunks stored", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Upserted"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "500": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/search": {
+      "post": {
+        "summary": "Vector similarity search",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/SearchRequest"}}}},
+        "responses": {
+          "200": {"$ref": "#/components/responses/Results"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "500": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/search/hybrid": {
+      "post": {
+        "summary": "Hybrid vector and keyword search over the default collection",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/SearchRequest"}}}},
+        "responses": {
+          "200": {"$ref": "#/components/responses/Results"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "500": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/collections": {
+      "get": {
+        "summary": "List collections",
+        "responses": {
+          "200": {"description": "Collection names", "content": {"application/json": {"schema": {"type": "object", "properties": {"collections": {"type": "array", "items": {"type": "string"}}}}}}},
+          "500": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "post": {
+        "summary": "Create a collection",
+        "description": "Returns 501 on pgvector, which keeps every embedding in the default collection.",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {
+          "type": "object",
+          "required": ["name", "dimension"],
+          "properties": {"name": {"type": "string"}, "dimension": {"type": "integer", "minimum": 1}}
+        }}}},
+        "responses": {
+          "201": {"description": "Collection created", "content": {"application/json": {"schema": {"type": "object", "properties": {"created": {"type": "string"}}}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "500": {"$ref": "#/components/responses/Error"},
+          "501": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/collections/{name}": {
+      "delete": {
+        "summary": "Delete a collection",
+        "parameters": [{"name": "name", "in": "path", "required": true, "schema": {"type": "string"}}],
+        "responses": {
+          "200": {"description": "Collection deleted", "content": {"application/json": {"schema": {"type": "object", "properties": {"deleted": {"type": "string"}}}}}},
+          "500": {"$ref": "#/components/responses/Error"},
+          "501": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/stats": {
+      "get": {
+        "summary": "Database statistics",
+        "responses": {
+          "200": {"description": "Statistics", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Stats"}}}},
+          "500": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "securitySchemes": {
+      "bearerAuth": {"type": "http", "scheme": "bearer"},
+      "apiKey": {"type": "apiKey", "in": "header", "name": "X-API-Key"}
+    },
+    "responses": {
+      "Error": {"description": "Error", "content": {"application/json": {"schema": {"type": "object", "properties": {"error": {"type": "string"}}}}}},
+      "Results": {"description": "Search results ordered by score", "content": {"application/json": {"schema": {
+        "type": "object",
+        "properties": {"results": {"type": "array", "items": {"$ref": "#/components/schemas/SearchResult"}}}
+      }}}}
+    },
+    "schemas": {
+      "Vector": {"type": "array", "items": {"type": "number", "format": "float"}},
+      "Metadata": {"type": "object", "additionalProperties": true},
+      "Document": {
+        "type": "object",
+        "required": ["id"],
+        "properties": {
+          "id": {"type": "string"},
+          "content": {"type": "string"},
+          "vector": {"$ref": "#/components/schemas/Vector"},
+          "metadata": {"$ref": "#/components/schemas/Metadata"},
+          "collection": {"type": "string"},
+          "created_at": {"type": "string", "format": "date-time"}
+        }
+      },
+      "Chunk": {
+        "type": "object",
+        "required": ["document_id", "chunk_index"],
+        "properties": {
+          "document_id": {"type": "string"},
+          "chunk_index": {"type": "integer"},
+          "content": {"type": "string"},
+          "vector": {"$ref": "#/components/schemas/Vector"},
+          "metadata": {"$ref": "#/components/schemas/Metadata"},
+          "start_offset": {"type": "integer"},
+          "end_offset": {"type": "integer"}
+        }
+      },
+      "SearchRequest": {
+        "type": "object",
+        "description": "Provide vector, or text to embed it server-side. Hybrid search also uses query (defaults to text) for keyword matching, and rejects collection and filter.",
+        "properties": {
+          "vector": {"$ref": "#/components/schemas/Vector"},
+          "text": {"type": "string"},
+          "query": {"type": "string"},
+          "collection": {"type": "string"},
+          "filter": {"$ref": "#/components/schemas/Metadata"},
+          "limit": {"type": "integer", "default": 10, "maximum": 1000}
+        }
+      },
+      "SearchResult": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "content": {"type": "string"},
+          "score": {"type": "number"},
+          "metadata": {"$ref": "#/components/schemas/Metadata"},
+          "document_id": {"type": "string"},
+          "chunk_index": {"type": "integer"},
+          "start_offset": {"type": "integer"},
+          "end_offset": {"type": "integer"}
+        }
+      },
+      "Upserted": {"type": "object", "properties": {"upserted": {"type": "integer"}}},
+      "Stats": {
+        "type": "object",
+        "properties": {
+          "total_documents": {"type": "integer"},
+          "total_vectors": {"type": "integer"},
+          "collections": {"type": "array", "items": {"type": "string"}},
+          "index_size_bytes": {"type": "integer"},
+          "last_updated": {"type": "string", "format": "date-time"}
+        }
+      },
+      "Health": {
+        "type": "object",
+        "properties": {
+          "status": {"type": "string"},
+          "provider": {"type": "string"},
+          "embedding_model": {"type": "string"},
+          "text_embedding": {"type": "boolean"}
+        }
+      }
+    }
+  }
+}
+`
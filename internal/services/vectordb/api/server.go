@@ -0,0 +1,486 @@
+// internal/services/vectordb/api/server.go
+// Package api exposes a VectorDB over a JSON REST API so applications that
+// cannot use the Go providers share the same storage and search logic
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+)
+
+// DefaultPort is the port lc vector serve listens on by default
+const DefaultPort = 8091
+
+// maxBodySize limits request bodies to keep a bad client from exhausting memory
+const maxBodySize = 32 << 20
+
+// Embedder turns texts into vectors, letting clients send text instead of
+// precomputed embeddings. models.Manager implements it.
+type Embedder interface {
+	Embed(modelName string, texts []string) ([][]float32, error)
+}
+
+// Options configures the server
+type Options struct {
+	APIKey         string   // Required bearer token; empty disables authentication
+	Embedder       Embedder // Optional; enables text-only requests
+	EmbeddingModel string   // Model used with Embedder
+	Provider       string   // Provider name reported by /health
+	AllowedOrigins []string // Browser origins allowed to call the API; "*" allows any
+}
+
+// Server serves the vector API
+type Server struct {
+	db   vectordb.VectorDB
+	opts Options
+	mux  *http.ServeMux
+}
+
+// NewServer creates a server for db
+func NewServer(db vectordb.VectorDB, opts Options) *Server {
+	s := &Server{
+		db:   db,
+		opts: opts,
+		mux:  http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /health", s.handleHealth)
+	s.mux.HandleFunc("GET /openapi.json", s.handleOpenAPI)
+	s.mux.HandleFunc("POST /v1/documents", s.handleUpsert)
+	s.mux.HandleFunc("DELETE /v1/documents/{id...}", s.handleDelete)
+	s.mux.HandleFunc("POST /v1/chunks", s.handleUpsertChunks)
+	s.mux.HandleFunc("POST /v1/search", s.handleSearch)
+	s.mux.HandleFunc("POST /v1/search/hybrid", s.handleHybridSearch)
+	s.mux.HandleFunc("GET /v1/collections", s.handleListCollections)
+	s.mux.HandleFunc("POST /v1/collections", s.handleCreateCollection)
+	s.mux.HandleFunc("DELETE /v1/collections/{name}", s.handleDeleteCollection)
+	s.mux.HandleFunc("GET /v1/stats", s.handleStats)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Browsers send Origin; only listed origins may use the API, so a web
+	// page cannot read or change the local vector store. Other clients do
+	// not send Origin and are unaffected.
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if !s.originAllowed(origin) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("origin %s is not allowed; start the server with --cors-origin %s", origin, origin))
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-API-Key")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		w.Header().Add("Vary", "Origin")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	// Health and the API description stay public so tooling can discover the API
+	if s.opts.APIKey != "" && r.URL.Path != "/health" && r.URL.Path != "/openapi.json" {
+		key := r.Header.Get("X-API-Key")
+		if key == "" {
+			key = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		}
+		if subtle.ConstantTimeCompare([]byte(key), []byte(s.opts.APIKey)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("invalid or missing API key"))
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	s.mux.ServeHTTP(w, r)
+}
+
+// originAllowed reports whether a browser origin is in AllowedOrigins
+func (s *Server) originAllowed(origin string) bool {
+	for _, allowed := range s.opts.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// upsertRequest is the body of POST /v1/documents
+type upsertRequest struct {
+	Documents []vectordb.Document `json:"documents"`
+}
+
+// chunksRequest is the body of POST /v1/chunks
+type chunksRequest struct {
+	Chunks []vectordb.Chunk `json:"chunks"`
+}
+
+// searchRequest is the body of POST /v1/search and /v1/search/hybrid
+type searchRequest struct {
+	Vector     []float32              `json:"vector,omitempty"`
+	Text       string                 `json:"text,omitempty"`  // Embedded when vector is omitted
+	Query      string                 `json:"query,omitempty"` // Keyword query for hybrid search
+	Collection string                 `json:"collection,omitempty"`
+	Filter     map[string]interface{} `json:"filter,omitempty"`
+	Limit      int                    `json:"limit,omitempty"`
+}
+
+// collectionRequest is the body of POST /v1/collections
+type collectionRequest struct {
+	Name      string `json:"name"`
+	Dimension int    `json:"dimension"`
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":          "ok",
+		"provider":        s.opts.Provider,
+		"embedding_model": s.opts.EmbeddingModel,
+		"text_embedding":  s.opts.Embedder != nil,
+	})
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	// Point the servers entry at the address the client used, which may be a tunnel
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	spec := strings.Replace(OpenAPISpec, "http://localhost:8091", scheme+"://"+r.Host, 1)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(spec))
+}
+
+func (s *Server) handleUpsert(w http.ResponseWriter, r *http.Request) {
+	var req upsertRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if len(req.Documents) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("documents must not be empty"))
+		return
+	}
+
+	var texts []string
+	for i, doc := range req.Documents {
+		if doc.ID == "" {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("documents[%d]: id is required", i))
+			return
+		}
+		if len(doc.Vector) == 0 {
+			texts = append(texts, doc.Content)
+		}
+		if doc.CreatedAt.IsZero() {
+			req.Documents[i].CreatedAt = time.Now()
+		}
+	}
+
+	if len(texts) > 0 {
+		vectors, err := s.embed(texts)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		for i := range req.Documents {
+			if len(req.Documents[i].Vector) == 0 {
+				req.Documents[i].Vector, vectors = vectors[0], vectors[1:]
+			}
+		}
+	}
+
+	if err := s.db.StoreEmbeddings(r.Context(), req.Documents); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"upserted": len(req.Documents)})
+}
+
+func (s *Server) handleUpsertChunks(w http.ResponseWriter, r *http.Request) {
+	var req chunksRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if len(req.Chunks) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("chunks must not be empty"))
+		return
+	}
+
+	var texts []string
+	for i, chunk := range req.Chunks {
+		if chunk.DocumentID == "" {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("chunks[%d]: document_id is required", i))
+			return
+		}
+		if len(chunk.Vector) == 0 {
+			texts = append(texts, chunk.Content)
+		}
+	}
+
+	if len(texts) > 0 {
+		vectors, err := s.embed(texts)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		for i := range req.Chunks {
+			if len(req.Chunks[i].Vector) == 0 {
+				req.Chunks[i].Vector, vectors = vectors[0], vectors[1:]
+			}
+		}
+	}
+
+	if err := s.db.StoreChunks(r.Context(), req.Chunks); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"upserted": len(req.Chunks)})
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := s.db.DeleteDocument(r.Context(), id); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"deleted": id})
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	var req searchRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if !s.namedCollections() && req.Collection != "" && req.Collection != "default" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%s keeps every embedding in the default collection, so collection %q cannot be searched", s.opts.Provider, req.Collection))
+		return
+	}
+
+	vector, ok := s.queryVector(w, req.Vector, req.Text)
+	if !ok {
+		return
+	}
+
+	results, err := s.db.SearchSimilar(r.Context(), vectordb.QueryVector{
+		Vector:     vector,
+		Collection: req.Collection,
+		Filter:     req.Filter,
+	}, limit(req.Limit))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeResults(w, results)
+}
+
+func (s *Server) handleHybridSearch(w http.ResponseWriter, r *http.Request) {
+	var req searchRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Query == "" {
+		req.Query = req.Text
+	}
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, errors.New("query is required"))
+		return
+	}
+	// HybridSearch always searches the default collection without a filter
+	if req.Collection != "" || len(req.Filter) > 0 {
+		writeError(w, http.StatusBadRequest, errors.New("hybrid search does not support collection or filter; use /v1/search"))
+		return
+	}
+
+	text := req.Text
+	if text == "" {
+		text = req.Query
+	}
+	vector, ok := s.queryVector(w, req.Vector, text)
+	if !ok {
+		return
+	}
+
+	results, err := s.db.HybridSearch(r.Context(), req.Query, vector, limit(req.Limit))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeResults(w, results)
+}
+
+func (s *Server) handleListCollections(w http.ResponseWriter, r *http.Request) {
+	var collections []string
+	if migrator, ok := s.db.(vectordb.Migrator); ok {
+		names, err := migrator.ListCollections(r.Context())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		collections = names
+	} else {
+		stats, err := s.db.GetStats(r.Context())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		collections = stats.Collections
+	}
+	if collections == nil {
+		collections = []string{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"collections": collections})
+}
+
+func (s *Server) handleCreateCollection(w http.ResponseWriter, r *http.Request) {
+	var req collectionRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.Dimension <= 0 {
+		writeError(w, http.StatusBadRequest, errors.New("name and a positive dimension are required"))
+		return
+	}
+	if !s.namedCollections() {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("%s does not support named collections; use the qdrant or chroma component", s.opts.Provider))
+		return
+	}
+
+	if err := s.db.CreateCollection(r.Context(), req.Name, req.Dimension); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"created": req.Name})
+}
+
+func (s *Server) handleDeleteCollection(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if !s.namedCollections() {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("%s does not support named collections; use the qdrant or chroma component", s.opts.Provider))
+		return
+	}
+	if err := s.db.DeleteCollection(r.Context(), name); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"deleted": name})
+}
+
+// namedCollections reports whether the provider stores collections separately.
+// pgvector keeps a single table, so creating, deleting or searching a named
+// collection would silently act on the default one.
+func (s *Server) namedCollections() bool {
+	return s.opts.Provider != string(vectordb.ProviderPgVector)
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.db.GetStats(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if stats.Collections == nil {
+		stats.Collections = []string{}
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// queryVector returns the given vector, or embeds text when none was sent
+func (s *Server) queryVector(w http.ResponseWriter, vector []float32, text string) ([]float32, bool) {
+	if len(vector) > 0 {
+		return vector, true
+	}
+	if text == "" {
+		writeError(w, http.StatusBadRequest, errors.New("vector or text is required"))
+		return nil, false
+	}
+
+	vectors, err := s.embed([]string{text})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+	return vectors[0], true
+}
+
+func (s *Server) embed(texts []string) ([][]float32, error) {
+	if s.opts.Embedder == nil || s.opts.EmbeddingModel == "" {
+		return nil, errors.New("vector is required (no embedding model configured for text input)")
+	}
+	vectors, err := s.opts.Embedder.Embed(s.opts.EmbeddingModel, texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed text: %w", err)
+	}
+	return vectors, nil
+}
+
+// ListenAndServe serves on addr until ctx is cancelled
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+func limit(n int) int {
+	if n <= 0 {
+		return 10
+	}
+	if n > 1000 {
+		return 1000
+	}
+	return n
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON body: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeResults(w http.ResponseWriter, results []vectordb.SearchResult) {
+	if results == nil {
+		results = []vectordb.SearchResult{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -3,6 +3,9 @@ package vectordb
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -54,12 +57,14 @@ type QueryVector struct {
 
 // SearchResult represents a search result
 type SearchResult struct {
-	ID         string                 `json:"id"`
-	Content    string                 `json:"content"`
-	Score      float32                `json:"score"`
-	Metadata   map[string]interface{} `json:"metadata,omitempty"`
-	DocumentID string                 `json:"document_id,omitempty"`
-	ChunkIndex int                    `json:"chunk_index,omitempty"`
+	ID          string                 `json:"id"`
+	Content     string                 `json:"content"`
+	Score       float32                `json:"score"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	DocumentID  string                 `json:"document_id,omitempty"`
+	ChunkIndex  int                    `json:"chunk_index,omitempty"`
+	StartOffset int                    `json:"start_offset,omitempty"`
+	EndOffset   int                    `json:"end_offset,omitempty"`
 }
 
 // Stats represents vector database statistics
@@ -73,10 +78,43 @@ type Stats struct {
 
 // Config represents vector database configuration
 type Config struct {
-	Provider     string `json:"provider"`      // "pgvector" or "chroma"
+	Provider     string `json:"provider"`      // "pgvector", "qdrant" or "chroma"
 	EmbeddingDim int    `json:"embedding_dim"` // Default: 1536
 	MaxResults   int    `json:"max_results"`   // Default: 10
 	IndexType    string `json:"index_type"`    // "ivfflat" or "hnsw"
+	Collection   string `json:"collection"`    // Collection for chunks and hybrid search (default: "default")
+	Metric       string `json:"metric"`        // "cosine" (default), "dot" or "euclidean"
+}
+
+// Metrics vectors can be ranked by. Scores are higher for closer vectors
+// under every metric: the cosine similarity, the dot product, or 1/(1+d) for
+// the euclidean distance d.
+const (
+	MetricCosine    = "cosine"
+	MetricDot       = "dot"
+	MetricEuclidean = "euclidean"
+)
+
+// Metrics are the supported metrics
+var Metrics = []string{MetricCosine, MetricDot, MetricEuclidean}
+
+// ConfigMetric returns the metric of a configuration, defaulting to cosine
+func ConfigMetric(config *Config) (string, error) {
+	if config == nil || config.Metric == "" {
+		return MetricCosine, nil
+	}
+	for _, metric := range Metrics {
+		if config.Metric == metric {
+			return metric, nil
+		}
+	}
+	return "", fmt.Errorf("unknown metric %q, use %s", config.Metric, strings.Join(Metrics, ", "))
+}
+
+// EuclideanScore turns a euclidean distance into a score that grows as
+// vectors get closer, reaching 1 for identical vectors
+func EuclideanScore(distance float64) float32 {
+	return float32(1 / (1 + distance))
 }
 
 // Provider represents a vector database provider
@@ -85,4 +123,73 @@ type Provider string
 const (
 	ProviderPgVector Provider = "pgvector"
 	ProviderChroma   Provider = "chroma"
+	ProviderQdrant   Provider = "qdrant"
 )
+
+// Record represents a stored embedding together with its source position
+type Record struct {
+	ID          string                 `json:"id"`
+	DocumentID  string                 `json:"document_id"`
+	ChunkIndex  int                    `json:"chunk_index"`
+	Content     string                 `json:"content"`
+	Vector      []float32              `json:"vector"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	StartOffset int                    `json:"start_offset"`
+	EndOffset   int                    `json:"end_offset"`
+	Collection  string                 `json:"collection"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// ShadowMarker is part of the name of every collection re-embedding creates:
+// shadows being filled and data set aside during a swap
+const ShadowMarker = "_reembed_"
+
+// IsShadow reports whether a collection was created by re-embedding rather
+// than by the user
+func IsShadow(collection string) bool {
+	return strings.Contains(collection, ShadowMarker)
+}
+
+// Migrator is implemented by providers that can copy records into a shadow
+// collection and swap it into place, which re-embedding relies on
+type Migrator interface {
+	// ListCollections returns the logical collection names
+	ListCollections(ctx context.Context) ([]string, error)
+
+	// ListRecords returns every record in a collection including vectors
+	ListRecords(ctx context.Context, collection string) ([]Record, error)
+
+	// CreateShadow creates a collection for the given dimension if it does not exist yet
+	CreateShadow(ctx context.Context, shadow string, dimension int) error
+
+	// DropShadow removes an abandoned shadow collection if it exists
+	DropShadow(ctx context.Context, shadow string) error
+
+	// StoreRecords upserts records into a collection, keeping their IDs and offsets
+	StoreRecords(ctx context.Context, collection string, records []Record) error
+
+	// SwapCollection replaces collection with shadow and drops the old data
+	SwapCollection(ctx context.Context, shadow, collection string) error
+}
+
+// DimensionReporter is implemented by providers that can report the size of
+// the vectors a collection holds, so callers can detect a model change that
+// needs re-embedding
+type DimensionReporter interface {
+	// CollectionDimension returns the vector dimension of a collection, or 0
+	// if the collection is empty or does not exist
+	CollectionDimension(ctx context.Context, collection string) (int, error)
+}
+
+// ErrDimensionMismatch is returned when a collection holds vectors of a
+// different size than the embedding model produces
+var ErrDimensionMismatch = errors.New("embedding dimension mismatch")
+
+// DimensionEnforcer is implemented by providers whose storage is declared
+// with a fixed vector size, such as pgvector's vector(n) column
+type DimensionEnforcer interface {
+	// EnsureDimension makes an empty collection accept vectors of the given
+	// dimension and returns ErrDimensionMismatch if it already holds vectors
+	// of another size
+	EnsureDimension(ctx context.Context, collection string, dimension int) error
+}
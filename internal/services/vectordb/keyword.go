@@ -0,0 +1,34 @@
+// internal/services/vectordb/keyword.go
+package vectordb
+
+import "strings"
+
+// Tokenize splits text into lowercase terms for keyword scoring
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	})
+}
+
+// KeywordScore returns the fraction of query terms present in content.
+// Providers without full-text search use it to rescore vector candidates in
+// HybridSearch.
+func KeywordScore(terms []string, content string) float32 {
+	if len(terms) == 0 {
+		return 0
+	}
+
+	present := make(map[string]bool)
+	for _, t := range Tokenize(content) {
+		present[t] = true
+	}
+
+	matched := 0
+	for _, t := range terms {
+		if present[t] {
+			matched++
+		}
+	}
+
+	return float32(matched) / float32(len(terms))
+}
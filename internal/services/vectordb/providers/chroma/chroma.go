@@ -0,0 +1,749 @@
+// internal/services/vectordb/providers/chroma/chroma.go
+package chroma
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+)
+
+// DefaultCollection is used when a document or query does not name a collection
+const DefaultCollection = "default"
+
+// Metadata keys reserved by LocalCloud. User metadata is flattened next to
+// these so it can be used in Chroma "where" filters, and is also kept as JSON
+// so nested values survive a round trip.
+const (
+	keyDocumentID   = "document_id"
+	keyChunkIndex   = "chunk_index"
+	keyStartOffset  = "start_offset"
+	keyEndOffset    = "end_offset"
+	keyCreatedAt    = "created_at"
+	keyMetadataJSON = "metadata_json"
+)
+
+// ChromaDB implements VectorDB interface using Chroma's HTTP API
+type ChromaDB struct {
+	endpoint          string
+	httpClient        *http.Client
+	config            *vectordb.Config
+	defaultCollection string
+	metric            string
+
+	mu            sync.Mutex
+	collectionIDs map[string]string
+}
+
+// spaces maps metrics to Chroma's hnsw:space values
+var spaces = map[string]string{
+	vectordb.MetricCosine:    "cosine",
+	vectordb.MetricDot:       "ip",
+	vectordb.MetricEuclidean: "l2",
+}
+
+// entry is a single embedding prepared for upsert
+type entry struct {
+	id       string
+	vector   []float32
+	content  string
+	metadata map[string]interface{}
+}
+
+// New creates a new Chroma instance
+func New(endpoint string, config *vectordb.Config) (*ChromaDB, error) {
+	if endpoint == "" {
+		endpoint = "http://localhost:8000"
+	}
+
+	metric, err := vectordb.ConfigMetric(config)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &ChromaDB{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		config:            config,
+		defaultCollection: DefaultCollection,
+		metric:            metric,
+		collectionIDs:     make(map[string]string),
+	}
+	if config != nil && config.Collection != "" {
+		db.defaultCollection = config.Collection
+	}
+
+	// Verify Chroma is reachable
+	if err := db.request(context.Background(), "GET", "/api/v1/heartbeat", nil, nil); err != nil {
+		return nil, fmt.Errorf("chroma not reachable at %s: %w", db.endpoint, err)
+	}
+
+	return db, nil
+}
+
+// StoreEmbedding stores a single document embedding
+func (db *ChromaDB) StoreEmbedding(ctx context.Context, doc vectordb.Document) error {
+	return db.StoreEmbeddings(ctx, []vectordb.Document{doc})
+}
+
+// StoreEmbeddings stores multiple document embeddings
+func (db *ChromaDB) StoreEmbeddings(ctx context.Context, docs []vectordb.Document) error {
+	byCollection := make(map[string][]entry)
+
+	for _, doc := range docs {
+		createdAt := doc.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		metadata, err := flattenMetadata(doc.Metadata)
+		if err != nil {
+			return err
+		}
+		metadata[keyDocumentID] = doc.ID
+		metadata[keyChunkIndex] = 0
+		metadata[keyCreatedAt] = createdAt.Format(time.RFC3339Nano)
+
+		collection := db.collectionName(doc.Collection)
+		byCollection[collection] = append(byCollection[collection], entry{
+			id:       recordID(doc.ID, 0),
+			vector:   doc.Vector,
+			content:  doc.Content,
+			metadata: metadata,
+		})
+	}
+
+	for collection, entries := range byCollection {
+		if err := db.upsert(ctx, collection, entries); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SearchSimilar performs similarity search
+func (db *ChromaDB) SearchSimilar(ctx context.Context, query vectordb.QueryVector, limit int) ([]vectordb.SearchResult, error) {
+	return db.query(ctx, db.collectionName(query.Collection), query.Vector, query.Filter, limit)
+}
+
+// DeleteDocument deletes all embeddings for a document in every collection
+func (db *ChromaDB) DeleteDocument(ctx context.Context, documentID string) error {
+	collections, err := db.ListCollections(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"where": map[string]interface{}{keyDocumentID: documentID},
+	}
+
+	for _, collection := range collections {
+		id, err := db.collectionID(ctx, collection)
+		if err != nil {
+			return err
+		}
+		if err := db.request(ctx, "POST", "/api/v1/collections/"+id+"/delete", body, nil); err != nil {
+			return fmt.Errorf("failed to delete document from %s: %w", collection, err)
+		}
+	}
+
+	return nil
+}
+
+// StoreChunks stores document chunks for RAG
+func (db *ChromaDB) StoreChunks(ctx context.Context, chunks []vectordb.Chunk) error {
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	now := time.Now().Format(time.RFC3339Nano)
+	entries := make([]entry, 0, len(chunks))
+	for _, chunk := range chunks {
+		metadata, err := flattenMetadata(chunk.Metadata)
+		if err != nil {
+			return err
+		}
+		metadata[keyDocumentID] = chunk.DocumentID
+		metadata[keyChunkIndex] = chunk.ChunkIndex
+		metadata[keyStartOffset] = chunk.StartOffset
+		metadata[keyEndOffset] = chunk.EndOffset
+		metadata[keyCreatedAt] = now
+
+		entries = append(entries, entry{
+			id:       recordID(chunk.DocumentID, chunk.ChunkIndex),
+			vector:   chunk.Vector,
+			content:  chunk.Content,
+			metadata: metadata,
+		})
+	}
+
+	return db.upsert(ctx, db.defaultCollection, entries)
+}
+
+// HybridSearch performs both text and vector search
+func (db *ChromaDB) HybridSearch(ctx context.Context, textQuery string, vector []float32, limit int) ([]vectordb.SearchResult, error) {
+	// Over-fetch vector candidates, then rescore with keyword overlap using the
+	// same 0.7/0.3 weighting as the pgvector provider
+	candidates, err := db.query(ctx, db.defaultCollection, vector, nil, limit*4)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := vectordb.Tokenize(textQuery)
+	for i := range candidates {
+		candidates[i].Score = 0.7*candidates[i].Score + 0.3*vectordb.KeywordScore(terms, candidates[i].Content)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates, nil
+}
+
+// GetStats returns database statistics
+func (db *ChromaDB) GetStats(ctx context.Context) (vectordb.Stats, error) {
+	var stats vectordb.Stats
+	stats.LastUpdated = time.Now()
+
+	collections, err := db.ListCollections(ctx)
+	if err != nil {
+		return stats, err
+	}
+	stats.Collections = collections
+
+	for _, collection := range collections {
+		documents := make(map[string]bool)
+		err := db.scan(ctx, collection, false, func(r vectordb.Record) error {
+			stats.TotalVectors++
+			documents[r.DocumentID] = true
+			return nil
+		})
+		if err != nil {
+			return stats, err
+		}
+		stats.TotalDocuments += int64(len(documents))
+	}
+
+	return stats, nil
+}
+
+// CreateCollection creates a collection with the space of the configured
+// metric. Chroma infers the dimension from the first embedding, so dimension
+// is only validated.
+func (db *ChromaDB) CreateCollection(ctx context.Context, name string, dimension int) error {
+	if dimension <= 0 && db.config != nil {
+		dimension = db.config.EmbeddingDim
+	}
+	if dimension <= 0 {
+		return fmt.Errorf("collection dimension must be positive")
+	}
+
+	body := map[string]interface{}{
+		"name":          name,
+		"get_or_create": true,
+		"metadata": map[string]interface{}{
+			"hnsw:space": spaces[db.metric],
+			"dimension":  dimension,
+		},
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := db.request(ctx, "POST", "/api/v1/collections", body, &resp); err != nil {
+		return fmt.Errorf("failed to create collection %s: %w", name, err)
+	}
+
+	db.mu.Lock()
+	db.collectionIDs[name] = resp.ID
+	db.mu.Unlock()
+
+	return nil
+}
+
+// DeleteCollection deletes a collection and all its embeddings
+func (db *ChromaDB) DeleteCollection(ctx context.Context, name string) error {
+	db.mu.Lock()
+	delete(db.collectionIDs, name)
+	db.mu.Unlock()
+
+	return db.request(ctx, "DELETE", "/api/v1/collections/"+name, nil, nil)
+}
+
+// ListRecords returns every embedding in a collection including vectors
+func (db *ChromaDB) ListRecords(ctx context.Context, collection string) ([]vectordb.Record, error) {
+	var records []vectordb.Record
+	err := db.scan(ctx, db.collectionName(collection), true, func(r vectordb.Record) error {
+		records = append(records, r)
+		return nil
+	})
+	return records, err
+}
+
+// ListCollections returns the names of all collections
+func (db *ChromaDB) ListCollections(ctx context.Context) ([]string, error) {
+	var resp []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := db.request(ctx, "GET", "/api/v1/collections", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(resp))
+	db.mu.Lock()
+	for _, c := range resp {
+		names = append(names, c.Name)
+		db.collectionIDs[c.Name] = c.ID
+	}
+	db.mu.Unlock()
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// CreateShadow creates a collection for re-embedding unless it already exists
+func (db *ChromaDB) CreateShadow(ctx context.Context, shadow string, dimension int) error {
+	// Collections are created with get_or_create, so this is idempotent
+	return db.CreateCollection(ctx, shadow, dimension)
+}
+
+// DropShadow deletes an abandoned shadow collection if it exists
+func (db *ChromaDB) DropShadow(ctx context.Context, shadow string) error {
+	if _, err := db.collectionID(ctx, shadow); err != nil {
+		return nil
+	}
+	return db.DeleteCollection(ctx, shadow)
+}
+
+// StoreRecords upserts records into a collection, keeping their IDs
+func (db *ChromaDB) StoreRecords(ctx context.Context, collection string, records []vectordb.Record) error {
+	entries := make([]entry, 0, len(records))
+	for _, record := range records {
+		createdAt := record.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		metadata, err := flattenMetadata(record.Metadata)
+		if err != nil {
+			return err
+		}
+		metadata[keyDocumentID] = record.DocumentID
+		metadata[keyChunkIndex] = record.ChunkIndex
+		metadata[keyStartOffset] = record.StartOffset
+		metadata[keyEndOffset] = record.EndOffset
+		metadata[keyCreatedAt] = createdAt.Format(time.RFC3339Nano)
+
+		id := record.ID
+		if id == "" {
+			id = recordID(record.DocumentID, record.ChunkIndex)
+		}
+
+		entries = append(entries, entry{
+			id:       id,
+			vector:   record.Vector,
+			content:  record.Content,
+			metadata: metadata,
+		})
+	}
+
+	return db.upsert(ctx, db.collectionName(collection), entries)
+}
+
+// SwapCollection renames shadow to collection. Chroma has no atomic rename
+// swap, so the old collection is moved aside first and dropped afterwards.
+func (db *ChromaDB) SwapCollection(ctx context.Context, shadow, collection string) error {
+	shadowID, err := db.collectionID(ctx, shadow)
+	if err != nil {
+		return err
+	}
+
+	retired := ""
+	if oldID, err := db.collectionID(ctx, collection); err == nil {
+		retired = fmt.Sprintf("%s_retired_%d", collection, time.Now().Unix())
+		if err := db.rename(ctx, oldID, retired); err != nil {
+			return fmt.Errorf("failed to move %s aside: %w", collection, err)
+		}
+	}
+
+	if err := db.rename(ctx, shadowID, collection); err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", shadow, collection, err)
+	}
+
+	db.mu.Lock()
+	delete(db.collectionIDs, shadow)
+	db.collectionIDs[collection] = shadowID
+	db.mu.Unlock()
+
+	if retired != "" {
+		return db.request(ctx, "DELETE", "/api/v1/collections/"+retired, nil, nil)
+	}
+
+	return nil
+}
+
+// rename changes the name of a collection
+func (db *ChromaDB) rename(ctx context.Context, id, name string) error {
+	body := map[string]interface{}{"new_name": name}
+	return db.request(ctx, "PUT", "/api/v1/collections/"+id, body, nil)
+}
+
+// upsert writes entries to a collection, creating it on first use
+func (db *ChromaDB) upsert(ctx context.Context, collection string, entries []entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	id, err := db.collectionID(ctx, collection)
+	if err != nil {
+		if err := db.CreateCollection(ctx, collection, len(entries[0].vector)); err != nil {
+			return err
+		}
+		if id, err = db.collectionID(ctx, collection); err != nil {
+			return err
+		}
+	}
+
+	ids := make([]string, len(entries))
+	embeddings := make([][]float32, len(entries))
+	documents := make([]string, len(entries))
+	metadatas := make([]map[string]interface{}, len(entries))
+	for i, e := range entries {
+		ids[i] = e.id
+		embeddings[i] = e.vector
+		documents[i] = e.content
+		metadatas[i] = e.metadata
+	}
+
+	body := map[string]interface{}{
+		"ids":        ids,
+		"embeddings": embeddings,
+		"documents":  documents,
+		"metadatas":  metadatas,
+	}
+	if err := db.request(ctx, "POST", "/api/v1/collections/"+id+"/upsert", body, nil); err != nil {
+		return fmt.Errorf("failed to upsert embeddings: %w", err)
+	}
+
+	return nil
+}
+
+// query runs a vector search against a single collection
+func (db *ChromaDB) query(ctx context.Context, collection string, vector []float32, filter map[string]interface{}, limit int) ([]vectordb.SearchResult, error) {
+	id, err := db.collectionID(ctx, collection)
+	if err != nil {
+		// Searching a collection that was never written to yields no results
+		return []vectordb.SearchResult{}, nil
+	}
+
+	body := map[string]interface{}{
+		"query_embeddings": [][]float32{vector},
+		"n_results":        limit,
+		"include":          []string{"documents", "metadatas", "distances"},
+	}
+	if where := buildWhere(filter); where != nil {
+		body["where"] = where
+	}
+
+	var resp struct {
+		IDs       [][]string                 `json:"ids"`
+		Documents [][]string                 `json:"documents"`
+		Metadatas [][]map[string]interface{} `json:"metadatas"`
+		Distances [][]float32                `json:"distances"`
+	}
+	if err := db.request(ctx, "POST", "/api/v1/collections/"+id+"/query", body, &resp); err != nil {
+		return nil, err
+	}
+
+	results := []vectordb.SearchResult{}
+	if len(resp.IDs) == 0 {
+		return results, nil
+	}
+
+	for i, recordID := range resp.IDs[0] {
+		record := decodeRecord(recordID, resp.Documents[0][i], resp.Metadatas[0][i])
+		results = append(results, vectordb.SearchResult{
+			ID:          record.ID,
+			Content:     record.Content,
+			Score:       db.score(resp.Distances[0][i]),
+			Metadata:    record.Metadata,
+			DocumentID:  record.DocumentID,
+			ChunkIndex:  record.ChunkIndex,
+			StartOffset: record.StartOffset,
+			EndOffset:   record.EndOffset,
+		})
+	}
+
+	return results, nil
+}
+
+// score converts a Chroma distance into a score that grows as vectors get
+// closer. The cosine and ip spaces return one minus the similarity; l2
+// returns the squared distance.
+func (db *ChromaDB) score(distance float32) float32 {
+	if db.metric == vectordb.MetricEuclidean {
+		return vectordb.EuclideanScore(math.Sqrt(math.Max(float64(distance), 0)))
+	}
+	return 1 - distance
+}
+
+// scan pages through all embeddings in a collection
+func (db *ChromaDB) scan(ctx context.Context, collection string, withVector bool, fn func(vectordb.Record) error) error {
+	id, err := db.collectionID(ctx, collection)
+	if err != nil {
+		return err
+	}
+
+	include := []string{"documents", "metadatas"}
+	if withVector {
+		include = append(include, "embeddings")
+	}
+
+	const pageSize = 256
+	for offset := 0; ; offset += pageSize {
+		body := map[string]interface{}{
+			"limit":   pageSize,
+			"offset":  offset,
+			"include": include,
+		}
+
+		var resp struct {
+			IDs        []string                 `json:"ids"`
+			Documents  []string                 `json:"documents"`
+			Metadatas  []map[string]interface{} `json:"metadatas"`
+			Embeddings [][]float32              `json:"embeddings"`
+		}
+		if err := db.request(ctx, "POST", "/api/v1/collections/"+id+"/get", body, &resp); err != nil {
+			return err
+		}
+
+		for i, recordID := range resp.IDs {
+			record := decodeRecord(recordID, resp.Documents[i], resp.Metadatas[i])
+			record.Collection = collection
+			if withVector && i < len(resp.Embeddings) {
+				record.Vector = resp.Embeddings[i]
+			}
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+
+		if len(resp.IDs) < pageSize {
+			return nil
+		}
+	}
+}
+
+// CollectionDimension returns the size of the first stored embedding. Chroma
+// fixes a collection's dimension when the first embedding is added.
+func (db *ChromaDB) CollectionDimension(ctx context.Context, collection string) (int, error) {
+	collections, err := db.ListCollections(ctx)
+	if err != nil {
+		return 0, err
+	}
+	collection = db.collectionName(collection)
+	found := false
+	for _, name := range collections {
+		if name == collection {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return 0, nil
+	}
+
+	id, err := db.collectionID(ctx, collection)
+	if err != nil {
+		return 0, err
+	}
+
+	var resp struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	body := map[string]interface{}{
+		"limit":   1,
+		"include": []string{"embeddings"},
+	}
+	if err := db.request(ctx, "POST", "/api/v1/collections/"+id+"/get", body, &resp); err != nil {
+		return 0, err
+	}
+	if len(resp.Embeddings) == 0 {
+		return 0, nil
+	}
+	return len(resp.Embeddings[0]), nil
+}
+
+// collectionID resolves a collection name to its Chroma ID
+func (db *ChromaDB) collectionID(ctx context.Context, name string) (string, error) {
+	db.mu.Lock()
+	id, ok := db.collectionIDs[name]
+	db.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := db.request(ctx, "GET", "/api/v1/collections/"+name, nil, &resp); err != nil {
+		return "", fmt.Errorf("collection %s not found: %w", name, err)
+	}
+
+	db.mu.Lock()
+	db.collectionIDs[name] = resp.ID
+	db.mu.Unlock()
+
+	return resp.ID, nil
+}
+
+// collectionName falls back to the default collection
+func (db *ChromaDB) collectionName(name string) string {
+	if name == "" {
+		return db.defaultCollection
+	}
+	return name
+}
+
+// request sends a JSON request to Chroma and decodes the response into out
+func (db *ChromaDB) request(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, db.endpoint+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := db.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Chroma: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("chroma returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// flattenMetadata copies scalar metadata values so they are filterable and
+// stores the full metadata as JSON, since Chroma only accepts flat values
+func flattenMetadata(metadata map[string]interface{}) (map[string]interface{}, error) {
+	flat := make(map[string]interface{})
+	if len(metadata) == 0 {
+		return flat, nil
+	}
+
+	for key, value := range metadata {
+		switch value.(type) {
+		case string, bool, int, int32, int64, float32, float64:
+			flat[key] = value
+		}
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	flat[keyMetadataJSON] = string(data)
+
+	return flat, nil
+}
+
+// decodeRecord rebuilds a record from Chroma's flat metadata
+func decodeRecord(id, content string, metadata map[string]interface{}) vectordb.Record {
+	record := vectordb.Record{
+		ID:      id,
+		Content: content,
+	}
+
+	record.DocumentID, _ = metadata[keyDocumentID].(string)
+	if idx, ok := metadata[keyChunkIndex].(float64); ok {
+		record.ChunkIndex = int(idx)
+	}
+	if offset, ok := metadata[keyStartOffset].(float64); ok {
+		record.StartOffset = int(offset)
+	}
+	if offset, ok := metadata[keyEndOffset].(float64); ok {
+		record.EndOffset = int(offset)
+	}
+	if ts, ok := metadata[keyCreatedAt].(string); ok {
+		record.CreatedAt, _ = time.Parse(time.RFC3339Nano, ts)
+	}
+	if raw, ok := metadata[keyMetadataJSON].(string); ok {
+		json.Unmarshal([]byte(raw), &record.Metadata)
+	}
+
+	return record
+}
+
+// buildWhere converts a flat key/value filter into a Chroma "where" clause
+func buildWhere(filter map[string]interface{}) map[string]interface{} {
+	if len(filter) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	conditions := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		switch v := filter[key].(type) {
+		case []interface{}:
+			conditions = append(conditions, map[string]interface{}{key: map[string]interface{}{"$in": v}})
+		case []string:
+			conditions = append(conditions, map[string]interface{}{key: map[string]interface{}{"$in": v}})
+		default:
+			conditions = append(conditions, map[string]interface{}{key: map[string]interface{}{"$eq": v}})
+		}
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return map[string]interface{}{"$and": conditions}
+}
+
+// recordID derives a stable ID for a document chunk
+func recordID(documentID string, chunkIndex int) string {
+	return fmt.Sprintf("%s#%d", documentID, chunkIndex)
+}
@@ -0,0 +1,56 @@
+// internal/services/vectordb/providers/chroma/chroma_test.go
+package chroma
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/vectordbtest"
+)
+
+// TestConformance runs the shared suite against a scratch Chroma, e.g.
+//
+//	LOCALCLOUD_TEST_CHROMA_URL=http://localhost:8000 go test ./internal/services/vectordb/providers/chroma
+//
+// Collections are named with a per-run prefix, and only those are deleted.
+func TestConformance(t *testing.T) {
+	endpoint := os.Getenv("LOCALCLOUD_TEST_CHROMA_URL")
+	if endpoint == "" {
+		t.Skip("set LOCALCLOUD_TEST_CHROMA_URL to run against Chroma")
+	}
+
+	prefix := fmt.Sprintf("lctest_%x_", time.Now().UnixNano())
+
+	vectordbtest.ConformanceSuite{
+		New: func(t *testing.T, cfg *vectordb.Config) vectordb.VectorDB {
+			db, err := New(endpoint, cfg)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			reset := func() {
+				collections, err := db.ListCollections(context.Background())
+				if err != nil {
+					t.Fatalf("ListCollections: %v", err)
+				}
+				for _, collection := range collections {
+					if !strings.HasPrefix(collection, prefix) {
+						continue
+					}
+					if err := db.DeleteCollection(context.Background(), collection); err != nil {
+						t.Fatalf("DeleteCollection(%s): %v", collection, err)
+					}
+				}
+			}
+			reset()
+			t.Cleanup(reset)
+			return db
+		},
+		Metrics: vectordb.Metrics,
+		Prefix:  prefix,
+	}.Run(t)
+}
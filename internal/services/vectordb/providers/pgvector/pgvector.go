@@ -3,11 +3,15 @@ package pgvector
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/localcloud-sh/localcloud/internal/services/postgres"
 	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
 )
@@ -17,14 +21,27 @@ type PgVectorDB struct {
 	client       *postgres.Client
 	config       *vectordb.Config
 	defaultTable string
+	metric       string
 }
 
 // New creates a new PgVector instance
 func New(client *postgres.Client, config *vectordb.Config) (*PgVectorDB, error) {
+	metric, err := vectordb.ConfigMetric(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Searches always read the embeddings table, so refuse a collection
+	// rather than silently searching the wrong data
+	if config != nil && config.Collection != "" && config.Collection != "default" {
+		return nil, fmt.Errorf("pgvector keeps every embedding in the default collection, so collection %q cannot be selected; named collections need the qdrant or chroma component", config.Collection)
+	}
+
 	db := &PgVectorDB{
 		client:       client,
 		config:       config,
 		defaultTable: "localcloud.embeddings",
+		metric:       metric,
 	}
 
 	// Ensure pgvector tables exist
@@ -100,20 +117,27 @@ func (db *PgVectorDB) StoreEmbeddings(ctx context.Context, docs []vectordb.Docum
 func (db *PgVectorDB) SearchSimilar(ctx context.Context, query vectordb.QueryVector, limit int) ([]vectordb.SearchResult, error) {
 	vectorStr := vectorToString(query.Vector)
 
-	sqlQuery := `
+	where, filterArgs, err := buildFilter(query.Filter, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery := fmt.Sprintf(`
 		SELECT 
 			id,
 			document_id,
 			chunk_index,
 			content,
 			metadata,
-			1 - (embedding <=> $1::vector) as similarity
+			%s as similarity
 		FROM localcloud.embeddings
-		ORDER BY embedding <=> $1::vector
+		%s
+		ORDER BY %s
 		LIMIT $2
-	`
+	`, db.scoreExpr(), where, db.distanceExpr())
 
-	rows, err := db.client.Query(sqlQuery, vectorStr, limit)
+	args := append([]interface{}{vectorStr, limit}, filterArgs...)
+	rows, err := db.client.Query(sqlQuery, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +165,7 @@ func (db *PgVectorDB) SearchSimilar(ctx context.Context, query vectordb.QueryVec
 		if err := json.Unmarshal(metadataJSON, &result.Metadata); err != nil {
 			return nil, err
 		}
+		result.StartOffset, result.EndOffset = offsetsFromMetadata(result.Metadata)
 
 		results = append(results, result)
 	}
@@ -164,7 +189,17 @@ func (db *PgVectorDB) StoreChunks(ctx context.Context, chunks []vectordb.Chunk)
 	defer tx.Rollback()
 
 	for _, chunk := range chunks {
-		metadataJSON, err := json.Marshal(chunk.Metadata)
+		// The table has no offset columns, so offsets are kept in metadata
+		metadata := make(map[string]interface{}, len(chunk.Metadata)+2)
+		for k, v := range chunk.Metadata {
+			metadata[k] = v
+		}
+		if chunk.EndOffset > 0 {
+			metadata["start_offset"] = chunk.StartOffset
+			metadata["end_offset"] = chunk.EndOffset
+		}
+
+		metadataJSON, err := json.Marshal(metadata)
 		if err != nil {
 			return fmt.Errorf("failed to marshal metadata: %w", err)
 		}
@@ -172,7 +207,7 @@ func (db *PgVectorDB) StoreChunks(ctx context.Context, chunks []vectordb.Chunk)
 		vectorStr := vectorToString(chunk.Vector)
 
 		query := `
-			INSERT INTO localcloud.embeddings 
+			INSERT INTO localcloud.embeddings
 			(document_id, chunk_index, content, embedding, metadata)
 			VALUES ($1, $2, $3, $4::vector, $5)
 			ON CONFLICT (document_id, chunk_index) 
@@ -202,7 +237,7 @@ func (db *PgVectorDB) HybridSearch(ctx context.Context, textQuery string, vector
 	vectorStr := vectorToString(vector)
 
 	// Combine vector similarity with text search using pg_trgm
-	query := `
+	query := fmt.Sprintf(`
 		SELECT 
 			id,
 			document_id,
@@ -210,16 +245,16 @@ func (db *PgVectorDB) HybridSearch(ctx context.Context, textQuery string, vector
 			content,
 			metadata,
 			(
-				0.7 * (1 - (embedding <=> $1::vector)) +
+				0.7 * %s +
 				0.3 * similarity(content, $2)
 			) as combined_score
 		FROM localcloud.embeddings
 		WHERE 
-			content % $2  -- pg_trgm similarity threshold
+			content %% $2  -- pg_trgm similarity threshold
 			OR embedding <=> $1::vector < 0.8
 		ORDER BY combined_score DESC
 		LIMIT $3
-	`
+	`, db.scoreExpr())
 
 	rows, err := db.client.Query(query, vectorStr, textQuery, limit)
 	if err != nil {
@@ -250,6 +285,7 @@ func (db *PgVectorDB) HybridSearch(ctx context.Context, textQuery string, vector
 		if err := json.Unmarshal(metadataJSON, &result.Metadata); err != nil {
 			return nil, err
 		}
+		result.StartOffset, result.EndOffset = offsetsFromMetadata(result.Metadata)
 
 		results = append(results, result)
 	}
@@ -295,6 +331,211 @@ func (db *PgVectorDB) DeleteCollection(ctx context.Context, name string) error {
 	return nil
 }
 
+// ListCollections returns the single logical collection
+func (db *PgVectorDB) ListCollections(ctx context.Context) ([]string, error) {
+	return []string{"default"}, nil
+}
+
+// ListRecords returns every row of a collection table including vectors
+func (db *PgVectorDB) ListRecords(ctx context.Context, collection string) ([]vectordb.Record, error) {
+	query := fmt.Sprintf(`
+		SELECT id, document_id, chunk_index, content, embedding::text, metadata, created_at
+		FROM %s
+		ORDER BY document_id, chunk_index
+	`, tableName(collection))
+
+	rows, err := db.client.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []vectordb.Record
+	for rows.Next() {
+		var record vectordb.Record
+		var vectorStr sql.NullString
+		var metadataJSON []byte
+
+		if err := rows.Scan(
+			&record.ID,
+			&record.DocumentID,
+			&record.ChunkIndex,
+			&record.Content,
+			&vectorStr,
+			&metadataJSON,
+			&record.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if vectorStr.Valid {
+			record.Vector = stringToVector(vectorStr.String)
+		}
+		if len(metadataJSON) > 0 {
+			if err := json.Unmarshal(metadataJSON, &record.Metadata); err != nil {
+				return nil, err
+			}
+		}
+		record.Collection = collection
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// CollectionDimension returns the dimension of the stored vectors
+func (db *PgVectorDB) CollectionDimension(ctx context.Context, collection string) (int, error) {
+	query := fmt.Sprintf(
+		"SELECT vector_dims(embedding) FROM %s WHERE embedding IS NOT NULL LIMIT 1",
+		tableName(collection),
+	)
+
+	var dimension int
+	err := db.client.QueryRow(query).Scan(&dimension)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return dimension, err
+}
+
+// CreateShadow creates a table with the new embedding dimension next to
+// the live one
+func (db *PgVectorDB) CreateShadow(ctx context.Context, shadow string, dimension int) error {
+	_, err := db.client.Exec(createTableQuery(tableName(shadow), dimension))
+	return err
+}
+
+// DropShadow drops an abandoned shadow table. Only shadow names are
+// accepted, since every other collection maps to the live table.
+func (db *PgVectorDB) DropShadow(ctx context.Context, shadow string) error {
+	if !vectordb.IsShadow(shadow) {
+		return fmt.Errorf("%s is not a shadow collection", shadow)
+	}
+	_, err := db.client.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", tableName(shadow)))
+	return err
+}
+
+// EnsureDimension compares the declared size of the embedding column with
+// the model's dimension. An empty table is recreated for the new size; a
+// table with rows has to be re-embedded instead.
+func (db *PgVectorDB) EnsureDimension(ctx context.Context, collection string, dimension int) error {
+	table := tableName(collection)
+
+	// pgvector stores the n of vector(n) as the column's type modifier
+	var declared int
+	err := db.client.QueryRow(`
+		SELECT atttypmod FROM pg_attribute
+		WHERE attrelid = $1::regclass AND attname = 'embedding'
+	`, table).Scan(&declared)
+	if err != nil {
+		return fmt.Errorf("failed to read embedding column of %s: %w", table, err)
+	}
+	// An unconstrained vector column accepts any size
+	if declared <= 0 || declared == dimension {
+		return nil
+	}
+
+	var rows int
+	if err := db.client.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", table)).Scan(&rows); err != nil {
+		return err
+	}
+	if rows > 0 {
+		return fmt.Errorf("%w: %s holds %d %d-dimensional vectors, the model produces %d",
+			vectordb.ErrDimensionMismatch, table, rows, declared, dimension)
+	}
+
+	tx, err := db.client.Transaction()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	statements := []string{
+		fmt.Sprintf("DROP TABLE %s", table),
+		createTableQuery(table, dimension),
+		createIndexQuery(table, db.metric),
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return fmt.Errorf("failed to recreate %s: %w", table, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// StoreRecords upserts records into a collection table, keeping their IDs
+func (db *PgVectorDB) StoreRecords(ctx context.Context, collection string, records []vectordb.Record) error {
+	tx, err := db.client.Transaction()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s
+		(id, document_id, chunk_index, content, embedding, metadata, created_at)
+		VALUES ($1, $2, $3, $4, $5::vector, $6, $7)
+		ON CONFLICT (document_id, chunk_index)
+		DO UPDATE SET
+			content = EXCLUDED.content,
+			embedding = EXCLUDED.embedding,
+			metadata = EXCLUDED.metadata
+	`, tableName(collection))
+
+	for _, record := range records {
+		metadataJSON, err := json.Marshal(record.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+
+		createdAt := record.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		if _, err := tx.Exec(query,
+			record.ID,
+			record.DocumentID,
+			record.ChunkIndex,
+			record.Content,
+			vectorToString(record.Vector),
+			metadataJSON,
+			createdAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SwapCollection replaces the live table with the shadow table in a single
+// transaction and rebuilds the similarity index
+func (db *PgVectorDB) SwapCollection(ctx context.Context, shadow, collection string) error {
+	target := tableName(collection)
+	targetName := target[strings.Index(target, ".")+1:]
+
+	tx, err := db.client.Transaction()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	statements := []string{
+		fmt.Sprintf("DROP TABLE IF EXISTS %s", target),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tableName(shadow), targetName),
+		createIndexQuery(target, db.metric),
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return fmt.Errorf("failed to swap tables: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
 // ensureTables creates necessary tables if they don't exist
 func (db *PgVectorDB) ensureTables() error {
 	// This is already handled in postgres.go initialization
@@ -319,6 +560,148 @@ func (db *PgVectorDB) ensureTables() error {
 	return nil
 }
 
+// buildFilter converts a metadata filter into a WHERE clause. Scalar values
+// must match exactly and list values match any element. Placeholders are
+// numbered from argIndex.
+func buildFilter(filter map[string]interface{}, argIndex int) (string, []interface{}, error) {
+	if len(filter) == 0 {
+		return "", nil, nil
+	}
+
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var conditions []string
+	var args []interface{}
+	for _, key := range keys {
+		switch v := filter[key].(type) {
+		case []interface{}:
+			values := make([]string, len(v))
+			for i, item := range v {
+				values[i] = fmt.Sprintf("%v", item)
+			}
+			conditions = append(conditions, fmt.Sprintf("metadata->>$%d = ANY($%d)", argIndex, argIndex+1))
+			args = append(args, key, pq.Array(values))
+			argIndex += 2
+		case []string:
+			conditions = append(conditions, fmt.Sprintf("metadata->>$%d = ANY($%d)", argIndex, argIndex+1))
+			args = append(args, key, pq.Array(v))
+			argIndex += 2
+		default:
+			containment, err := json.Marshal(map[string]interface{}{key: v})
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to marshal filter: %w", err)
+			}
+			conditions = append(conditions, fmt.Sprintf("metadata @> $%d::jsonb", argIndex))
+			args = append(args, string(containment))
+			argIndex++
+		}
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+// createTableQuery returns the statement creating a collection table whose
+// embedding column holds vectors of the given dimension
+func createTableQuery(table string, dimension int) string {
+	return fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+			document_id TEXT NOT NULL,
+			chunk_index INTEGER NOT NULL,
+			content TEXT NOT NULL,
+			embedding vector(%d),
+			metadata JSONB,
+			created_at TIMESTAMP DEFAULT NOW(),
+			UNIQUE(document_id, chunk_index)
+		)
+	`, table, dimension)
+}
+
+// createIndexQuery returns the statement creating the similarity index of a
+// collection table for a metric
+func createIndexQuery(table, metric string) string {
+	ops := map[string]string{
+		vectordb.MetricCosine:    "vector_cosine_ops",
+		vectordb.MetricDot:       "vector_ip_ops",
+		vectordb.MetricEuclidean: "vector_l2_ops",
+	}[metric]
+
+	return fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_vector_idx
+			ON %s
+			USING ivfflat (embedding %s)
+			WITH (lists = 100)`, table[strings.Index(table, ".")+1:], table, ops)
+}
+
+// distanceExpr returns the pgvector distance between the stored embedding and
+// the query vector in $1 for the configured metric. Smaller is closer; the
+// <#> operator returns the negative inner product.
+func (db *PgVectorDB) distanceExpr() string {
+	switch db.metric {
+	case vectordb.MetricDot:
+		return "(embedding <#> $1::vector)"
+	case vectordb.MetricEuclidean:
+		return "(embedding <-> $1::vector)"
+	default:
+		return "(embedding <=> $1::vector)"
+	}
+}
+
+// scoreExpr converts distanceExpr into a score that grows as vectors get
+// closer
+func (db *PgVectorDB) scoreExpr() string {
+	switch db.metric {
+	case vectordb.MetricDot:
+		return "(-" + db.distanceExpr() + ")"
+	case vectordb.MetricEuclidean:
+		return "(1 / (1 + " + db.distanceExpr() + "))"
+	default:
+		return "(1 - " + db.distanceExpr() + ")"
+	}
+}
+
+// tableName maps a collection to its table. The default collection is the
+// embeddings table; other names are only used for re-embedding shadows.
+func tableName(collection string) string {
+	if collection == "" || collection == "default" {
+		return "localcloud.embeddings"
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return '_'
+	}, collection)
+
+	return "localcloud.embeddings_" + name
+}
+
+// stringToVector parses PostgreSQL vector text format
+func stringToVector(s string) []float32 {
+	s = strings.Trim(s, "[]")
+	if s == "" {
+		return nil
+	}
+
+	parts := strings.Split(s, ",")
+	vector := make([]float32, 0, len(parts))
+	for _, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return nil
+		}
+		vector = append(vector, float32(v))
+	}
+	return vector
+}
+
 // vectorToString converts float32 slice to PostgreSQL vector format
 func vectorToString(vector []float32) string {
 	parts := make([]string, len(vector))
@@ -327,3 +710,10 @@ func vectorToString(vector []float32) string {
 	}
 	return "[" + strings.Join(parts, ",") + "]"
 }
+
+// offsetsFromMetadata reads the chunk offsets StoreChunks keeps in metadata
+func offsetsFromMetadata(metadata map[string]interface{}) (int, int) {
+	start, _ := metadata["start_offset"].(float64)
+	end, _ := metadata["end_offset"].(float64)
+	return int(start), int(end)
+}
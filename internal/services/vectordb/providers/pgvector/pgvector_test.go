@@ -0,0 +1,155 @@
+// internal/services/vectordb/providers/pgvector/pgvector_test.go
+package pgvector
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/services/postgres"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/vectordbtest"
+)
+
+// TestConformance runs the shared suite against a scratch database on a
+// PostgreSQL with pgvector installed, e.g.
+//
+//	createdb -h localhost -U localcloud localcloud_test
+//	LOCALCLOUD_TEST_PGVECTOR_DSN="host=localhost port=5432 user=localcloud password=localcloud dbname=localcloud_test sslmode=disable" \
+//		go test ./internal/services/vectordb/providers/pgvector
+//
+// pgvector always uses localcloud.embeddings, so every subtest empties that
+// table. The test refuses to run when the table already holds rows, and
+// restores the column's dimension afterwards.
+func TestConformance(t *testing.T) {
+	dsn := os.Getenv("LOCALCLOUD_TEST_PGVECTOR_DSN")
+	if dsn == "" {
+		t.Skip("set LOCALCLOUD_TEST_PGVECTOR_DSN to run against PostgreSQL")
+	}
+
+	service := postgres.NewServiceWithDSN(dsn, &config.DatabaseConfig{
+		Extensions: []string{"pgvector", "pg_trgm"},
+	})
+	if err := service.Initialize(); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	t.Cleanup(func() { service.Close() })
+	client := postgres.NewClient(service)
+
+	var rows int
+	if err := client.QueryRow("SELECT COUNT(*) FROM localcloud.embeddings").Scan(&rows); err != nil {
+		t.Fatalf("count embeddings: %v", err)
+	}
+	if rows > 0 {
+		t.Fatalf("localcloud.embeddings holds %d rows; point LOCALCLOUD_TEST_PGVECTOR_DSN at a scratch database", rows)
+	}
+
+	var dimension int
+	err := client.QueryRow(`
+		SELECT atttypmod FROM pg_attribute
+		WHERE attrelid = 'localcloud.embeddings'::regclass AND attname = 'embedding'
+	`).Scan(&dimension)
+	if err != nil {
+		t.Fatalf("read embedding dimension: %v", err)
+	}
+	if dimension > 0 {
+		t.Cleanup(func() {
+			db, err := New(client, nil)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if err := db.EnsureDimension(context.Background(), "default", dimension); err != nil {
+				t.Errorf("restore dimension %d: %v", dimension, err)
+			}
+		})
+	}
+
+	vectordbtest.ConformanceSuite{
+		New: func(t *testing.T, cfg *vectordb.Config) vectordb.VectorDB {
+			reset := func() {
+				if _, err := client.Exec("TRUNCATE localcloud.embeddings"); err != nil {
+					t.Fatalf("TRUNCATE: %v", err)
+				}
+			}
+			reset()
+			t.Cleanup(reset)
+
+			db, err := New(client, cfg)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if err := db.EnsureDimension(context.Background(), "default", cfg.EmbeddingDim); err != nil {
+				t.Fatalf("EnsureDimension: %v", err)
+			}
+			return db
+		},
+		Metrics:          vectordb.Metrics,
+		SingleCollection: true,
+	}.Run(t)
+}
+
+func TestBuildFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   map[string]interface{}
+		argIndex int
+		where    string
+		args     []interface{}
+	}{
+		{
+			name:     "empty",
+			filter:   nil,
+			argIndex: 3,
+		},
+		{
+			name:     "scalar",
+			filter:   map[string]interface{}{"source": "a.md"},
+			argIndex: 3,
+			where:    "WHERE metadata @> $3::jsonb",
+			args:     []interface{}{`{"source":"a.md"}`},
+		},
+		{
+			name:     "list",
+			filter:   map[string]interface{}{"tag": []interface{}{"go", 1}},
+			argIndex: 2,
+			where:    "WHERE metadata->>$2 = ANY($3)",
+			args:     []interface{}{"tag", pq.Array([]string{"go", "1"})},
+		},
+		{
+			name:     "string list",
+			filter:   map[string]interface{}{"tag": []string{"go", "rust"}},
+			argIndex: 2,
+			where:    "WHERE metadata->>$2 = ANY($3)",
+			args:     []interface{}{"tag", pq.Array([]string{"go", "rust"})},
+		},
+		{
+			name:     "keys are sorted and placeholders numbered in order",
+			filter:   map[string]interface{}{"b": []string{"x"}, "a": true, "c": 2},
+			argIndex: 1,
+			where:    "WHERE metadata @> $1::jsonb AND metadata->>$2 = ANY($3) AND metadata @> $4::jsonb",
+			args:     []interface{}{`{"a":true}`, "b", pq.Array([]string{"x"}), `{"c":2}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := buildFilter(tt.filter, tt.argIndex)
+			if err != nil {
+				t.Fatalf("buildFilter: %v", err)
+			}
+			if where != tt.where {
+				t.Errorf("where = %q, want %q", where, tt.where)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %#v, want %#v", args, tt.args)
+			}
+		})
+	}
+
+	if _, _, err := buildFilter(map[string]interface{}{"bad": make(chan int)}, 1); err == nil {
+		t.Error("buildFilter accepted a value that cannot be marshalled")
+	}
+}
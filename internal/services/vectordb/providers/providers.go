@@ -0,0 +1,86 @@
+// internal/services/vectordb/providers/providers.go
+// Package providers selects a VectorDB implementation from configuration
+package providers
+
+import (
+	"fmt"
+
+	"github.com/localcloud-sh/localcloud/internal/config"
+	"github.com/localcloud-sh/localcloud/internal/services/postgres"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/providers/chroma"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/providers/pgvector"
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb/providers/qdrant"
+)
+
+// DefaultProvider returns the provider backing the project's vector search.
+// Dedicated vector databases take precedence over pgvector.
+func DefaultProvider(cfg *config.Config) vectordb.Provider {
+	switch {
+	case cfg.Services.Qdrant.Type != "":
+		return vectordb.ProviderQdrant
+	case cfg.Services.Chroma.Type != "":
+		return vectordb.ProviderChroma
+	default:
+		return vectordb.ProviderPgVector
+	}
+}
+
+// Configured returns every provider the project has enabled: pgvector when
+// the database has the extension, and the qdrant and chroma components
+func Configured(cfg *config.Config) []vectordb.Provider {
+	var configured []vectordb.Provider
+	if cfg.Services.Database.Type != "" {
+		for _, ext := range cfg.Services.Database.Extensions {
+			if ext == "pgvector" || ext == "vector" {
+				configured = append(configured, vectordb.ProviderPgVector)
+				break
+			}
+		}
+	}
+	if cfg.Services.Qdrant.Type != "" {
+		configured = append(configured, vectordb.ProviderQdrant)
+	}
+	if cfg.Services.Chroma.Type != "" {
+		configured = append(configured, vectordb.ProviderChroma)
+	}
+	return configured
+}
+
+// Open connects to the provider named in vcfg.Provider, falling back to
+// DefaultProvider when it is empty
+func Open(cfg *config.Config, vcfg *vectordb.Config) (vectordb.VectorDB, error) {
+	if vcfg == nil {
+		vcfg = &vectordb.Config{}
+	}
+	if vcfg.Provider == "" {
+		vcfg.Provider = string(DefaultProvider(cfg))
+	}
+
+	switch vectordb.Provider(vcfg.Provider) {
+	case vectordb.ProviderPgVector:
+		if cfg.Services.Database.Type == "" {
+			return nil, fmt.Errorf("PostgreSQL database not configured (required for pgvector)")
+		}
+		service := postgres.NewService(&cfg.Services.Database)
+		if err := service.Initialize(); err != nil {
+			return nil, err
+		}
+		return pgvector.New(postgres.NewClient(service), vcfg)
+
+	case vectordb.ProviderQdrant:
+		if cfg.Services.Qdrant.Type == "" {
+			return nil, fmt.Errorf("qdrant component not configured")
+		}
+		return qdrant.New(fmt.Sprintf("http://localhost:%d", cfg.Services.Qdrant.Port), vcfg)
+
+	case vectordb.ProviderChroma:
+		if cfg.Services.Chroma.Type == "" {
+			return nil, fmt.Errorf("chroma component not configured")
+		}
+		return chroma.New(fmt.Sprintf("http://localhost:%d", cfg.Services.Chroma.Port), vcfg)
+
+	default:
+		return nil, fmt.Errorf("unknown vector database provider: %s", vcfg.Provider)
+	}
+}
@@ -0,0 +1,793 @@
+// internal/services/vectordb/providers/qdrant/qdrant.go
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+)
+
+// DefaultCollection is used when a document or query does not name a collection
+const DefaultCollection = "default"
+
+// QdrantDB implements VectorDB interface using Qdrant's REST API
+type QdrantDB struct {
+	endpoint          string
+	httpClient        *http.Client
+	config            *vectordb.Config
+	defaultCollection string
+	metric            string
+
+	// createMu serializes creating collections on first use, so concurrent
+	// writers do not race to create the same one
+	createMu sync.Mutex
+}
+
+// distances maps metrics to Qdrant's distance names
+var distances = map[string]string{
+	vectordb.MetricCosine:    "Cosine",
+	vectordb.MetricDot:       "Dot",
+	vectordb.MetricEuclidean: "Euclid",
+}
+
+// point is the wire format of a Qdrant point
+type point struct {
+	ID      interface{}            `json:"id"`
+	Vector  []float32              `json:"vector,omitempty"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
+	Score   float32                `json:"score,omitempty"`
+}
+
+// New creates a new Qdrant instance
+func New(endpoint string, config *vectordb.Config) (*QdrantDB, error) {
+	if endpoint == "" {
+		endpoint = "http://localhost:6333"
+	}
+
+	metric, err := vectordb.ConfigMetric(config)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &QdrantDB{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		config:            config,
+		defaultCollection: DefaultCollection,
+		metric:            metric,
+	}
+	if config != nil && config.Collection != "" {
+		db.defaultCollection = config.Collection
+	}
+
+	// Verify Qdrant is reachable
+	if err := db.request(context.Background(), "GET", "/readyz", nil, nil); err != nil {
+		return nil, fmt.Errorf("qdrant not reachable at %s: %w", db.endpoint, err)
+	}
+
+	return db, nil
+}
+
+// StoreEmbedding stores a single document embedding
+func (db *QdrantDB) StoreEmbedding(ctx context.Context, doc vectordb.Document) error {
+	return db.StoreEmbeddings(ctx, []vectordb.Document{doc})
+}
+
+// StoreEmbeddings stores multiple document embeddings
+func (db *QdrantDB) StoreEmbeddings(ctx context.Context, docs []vectordb.Document) error {
+	byCollection := make(map[string][]point)
+
+	for _, doc := range docs {
+		createdAt := doc.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		collection := db.collectionName(doc.Collection)
+		byCollection[collection] = append(byCollection[collection], point{
+			ID:     pointID(doc.ID, 0),
+			Vector: doc.Vector,
+			Payload: map[string]interface{}{
+				"document_id": doc.ID,
+				"chunk_index": 0,
+				"content":     doc.Content,
+				"metadata":    doc.Metadata,
+				"created_at":  createdAt.Format(time.RFC3339Nano),
+			},
+		})
+	}
+
+	for collection, points := range byCollection {
+		if err := db.upsert(ctx, collection, points); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SearchSimilar performs similarity search
+func (db *QdrantDB) SearchSimilar(ctx context.Context, query vectordb.QueryVector, limit int) ([]vectordb.SearchResult, error) {
+	return db.search(ctx, db.collectionName(query.Collection), query.Vector, query.Filter, limit)
+}
+
+// DeleteDocument deletes all points for a document in every collection
+func (db *QdrantDB) DeleteDocument(ctx context.Context, documentID string) error {
+	collections, err := db.listCollections(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"filter": buildFilter(map[string]interface{}{"document_id": documentID}, false),
+	}
+
+	for _, collection := range collections {
+		path := fmt.Sprintf("/collections/%s/points/delete?wait=true", collection)
+		if err := db.request(ctx, "POST", path, body, nil); err != nil {
+			return fmt.Errorf("failed to delete document from %s: %w", collection, err)
+		}
+	}
+
+	return nil
+}
+
+// StoreChunks stores document chunks for RAG
+func (db *QdrantDB) StoreChunks(ctx context.Context, chunks []vectordb.Chunk) error {
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	now := time.Now().Format(time.RFC3339Nano)
+	points := make([]point, 0, len(chunks))
+	for _, chunk := range chunks {
+		points = append(points, point{
+			ID:     pointID(chunk.DocumentID, chunk.ChunkIndex),
+			Vector: chunk.Vector,
+			Payload: map[string]interface{}{
+				"document_id":  chunk.DocumentID,
+				"chunk_index":  chunk.ChunkIndex,
+				"content":      chunk.Content,
+				"metadata":     chunk.Metadata,
+				"start_offset": chunk.StartOffset,
+				"end_offset":   chunk.EndOffset,
+				"created_at":   now,
+			},
+		})
+	}
+
+	return db.upsert(ctx, db.defaultCollection, points)
+}
+
+// HybridSearch performs both text and vector search
+func (db *QdrantDB) HybridSearch(ctx context.Context, textQuery string, vector []float32, limit int) ([]vectordb.SearchResult, error) {
+	// Over-fetch vector candidates, then rescore with keyword overlap using the
+	// same 0.7/0.3 weighting as the pgvector provider
+	candidates, err := db.search(ctx, db.defaultCollection, vector, nil, limit*4)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := vectordb.Tokenize(textQuery)
+	for i := range candidates {
+		candidates[i].Score = 0.7*candidates[i].Score + 0.3*vectordb.KeywordScore(terms, candidates[i].Content)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates, nil
+}
+
+// GetStats returns database statistics
+func (db *QdrantDB) GetStats(ctx context.Context) (vectordb.Stats, error) {
+	var stats vectordb.Stats
+	stats.LastUpdated = time.Now()
+
+	collections, err := db.listCollections(ctx)
+	if err != nil {
+		return stats, err
+	}
+	stats.Collections = collections
+
+	for _, collection := range collections {
+		var info struct {
+			Result struct {
+				PointsCount int64 `json:"points_count"`
+			} `json:"result"`
+		}
+		if err := db.request(ctx, "GET", "/collections/"+collection, nil, &info); err != nil {
+			return stats, err
+		}
+		stats.TotalVectors += info.Result.PointsCount
+
+		documents := make(map[string]bool)
+		err := db.scroll(ctx, collection, false, []string{"document_id"}, func(p point) error {
+			if id, ok := p.Payload["document_id"].(string); ok {
+				documents[id] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return stats, err
+		}
+		stats.TotalDocuments += int64(len(documents))
+	}
+
+	return stats, nil
+}
+
+// CreateCollection creates a collection with the distance of the configured
+// metric
+func (db *QdrantDB) CreateCollection(ctx context.Context, name string, dimension int) error {
+	if dimension <= 0 {
+		dimension = db.config.EmbeddingDim
+	}
+	if dimension <= 0 {
+		return fmt.Errorf("collection dimension must be positive")
+	}
+
+	body := map[string]interface{}{
+		"vectors": map[string]interface{}{
+			"size":     dimension,
+			"distance": distances[db.metric],
+		},
+	}
+	if err := db.request(ctx, "PUT", "/collections/"+name, body, nil); err != nil {
+		return fmt.Errorf("failed to create collection %s: %w", name, err)
+	}
+
+	// Index document_id so deletes and filters stay fast
+	index := map[string]interface{}{
+		"field_name":   "document_id",
+		"field_schema": "keyword",
+	}
+	return db.request(ctx, "PUT", fmt.Sprintf("/collections/%s/index?wait=true", name), index, nil)
+}
+
+// DeleteCollection deletes a collection and all its points
+func (db *QdrantDB) DeleteCollection(ctx context.Context, name string) error {
+	aliases, err := db.listAliases(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A swapped collection is an alias, so drop the collection behind it
+	if target, ok := aliases[name]; ok {
+		body := map[string]interface{}{
+			"actions": []map[string]interface{}{
+				{"delete_alias": map[string]interface{}{"alias_name": name}},
+			},
+		}
+		if err := db.request(ctx, "POST", "/collections/aliases", body, nil); err != nil {
+			return err
+		}
+		name = target
+	}
+
+	return db.request(ctx, "DELETE", "/collections/"+name, nil, nil)
+}
+
+// ListRecords returns every point in a collection including vectors
+func (db *QdrantDB) ListRecords(ctx context.Context, collection string) ([]vectordb.Record, error) {
+	collection = db.collectionName(collection)
+
+	var records []vectordb.Record
+	err := db.scroll(ctx, collection, true, nil, func(p point) error {
+		record := vectordb.Record{
+			ID:         fmt.Sprintf("%v", p.ID),
+			Vector:     p.Vector,
+			Collection: collection,
+		}
+		record.DocumentID, _ = p.Payload["document_id"].(string)
+		record.Content, _ = p.Payload["content"].(string)
+		record.Metadata, _ = p.Payload["metadata"].(map[string]interface{})
+		if idx, ok := p.Payload["chunk_index"].(float64); ok {
+			record.ChunkIndex = int(idx)
+		}
+		if offset, ok := p.Payload["start_offset"].(float64); ok {
+			record.StartOffset = int(offset)
+		}
+		if offset, ok := p.Payload["end_offset"].(float64); ok {
+			record.EndOffset = int(offset)
+		}
+		if ts, ok := p.Payload["created_at"].(string); ok {
+			record.CreatedAt, _ = time.Parse(time.RFC3339Nano, ts)
+		}
+		records = append(records, record)
+		return nil
+	})
+
+	return records, err
+}
+
+// ListCollections returns the names of all collections
+func (db *QdrantDB) ListCollections(ctx context.Context) ([]string, error) {
+	return db.listCollections(ctx)
+}
+
+// CreateShadow creates a collection for re-embedding unless it already exists
+func (db *QdrantDB) CreateShadow(ctx context.Context, shadow string, dimension int) error {
+	exists, err := db.collectionExists(ctx, shadow)
+	if err != nil || exists {
+		return err
+	}
+	return db.CreateCollection(ctx, shadow, dimension)
+}
+
+// DropShadow deletes an abandoned shadow collection if it exists
+func (db *QdrantDB) DropShadow(ctx context.Context, shadow string) error {
+	exists, err := db.collectionExists(ctx, shadow)
+	if err != nil || !exists {
+		return err
+	}
+	return db.DeleteCollection(ctx, shadow)
+}
+
+// StoreRecords upserts records into a collection, keeping their point IDs
+func (db *QdrantDB) StoreRecords(ctx context.Context, collection string, records []vectordb.Record) error {
+	points := make([]point, 0, len(records))
+	for _, record := range records {
+		createdAt := record.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		id := record.ID
+		if id == "" {
+			id = pointID(record.DocumentID, record.ChunkIndex)
+		}
+
+		points = append(points, point{
+			ID:     id,
+			Vector: record.Vector,
+			Payload: map[string]interface{}{
+				"document_id":  record.DocumentID,
+				"chunk_index":  record.ChunkIndex,
+				"content":      record.Content,
+				"metadata":     record.Metadata,
+				"start_offset": record.StartOffset,
+				"end_offset":   record.EndOffset,
+				"created_at":   createdAt.Format(time.RFC3339Nano),
+			},
+		})
+	}
+
+	return db.upsert(ctx, db.collectionName(collection), points)
+}
+
+// SwapCollection points collection at shadow using a Qdrant alias. When
+// collection is already an alias the switch is atomic. The first swap has to
+// free the name for the alias, so the original points are copied aside before
+// the collection is dropped and removed only once the alias exists; an
+// interrupted swap can be repeated without losing data.
+func (db *QdrantDB) SwapCollection(ctx context.Context, shadow, collection string) error {
+	aliases, err := db.listAliases(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Dropping a collection an alias points at would drop the alias too
+	for alias, target := range aliases {
+		if target == collection {
+			return fmt.Errorf("collection %s is the target of alias %s; re-embed %s instead", collection, alias, alias)
+		}
+	}
+
+	if previous, ok := aliases[collection]; ok {
+		body := map[string]interface{}{
+			"actions": []map[string]interface{}{
+				{"delete_alias": map[string]interface{}{"alias_name": collection}},
+				{"create_alias": map[string]interface{}{"collection_name": shadow, "alias_name": collection}},
+			},
+		}
+		if err := db.request(ctx, "POST", "/collections/aliases", body, nil); err != nil {
+			return fmt.Errorf("failed to switch alias %s: %w", collection, err)
+		}
+
+		// Drop the collection the alias used to point at
+		if previous != shadow {
+			if err := db.request(ctx, "DELETE", "/collections/"+previous, nil, nil); err != nil {
+				return fmt.Errorf("failed to drop old collection %s: %w", previous, err)
+			}
+		}
+		return nil
+	}
+
+	physical, err := db.physicalCollections(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A missing collection means an earlier swap stopped after dropping it;
+	// its points are still in the aside copy
+	aside := collection + vectordb.ShadowMarker + "previous"
+	if physical[collection] {
+		if err := db.copyCollection(ctx, collection, aside); err != nil {
+			return fmt.Errorf("failed to set aside collection %s: %w", collection, err)
+		}
+		if err := db.request(ctx, "DELETE", "/collections/"+collection, nil, nil); err != nil {
+			return fmt.Errorf("failed to drop collection %s: %w", collection, err)
+		}
+	}
+
+	body := map[string]interface{}{
+		"actions": []map[string]interface{}{
+			{"create_alias": map[string]interface{}{"collection_name": shadow, "alias_name": collection}},
+		},
+	}
+	if err := db.request(ctx, "POST", "/collections/aliases", body, nil); err != nil {
+		return fmt.Errorf("failed to create alias %s (the previous points are kept in %s): %w", collection, aside, err)
+	}
+
+	physical, err = db.physicalCollections(ctx)
+	if err != nil {
+		return err
+	}
+	if physical[aside] {
+		if err := db.request(ctx, "DELETE", "/collections/"+aside, nil, nil); err != nil {
+			return fmt.Errorf("failed to drop old collection %s: %w", aside, err)
+		}
+	}
+
+	return nil
+}
+
+// copyCollection copies every point of source into a new collection dest,
+// replacing what an earlier attempt left there
+func (db *QdrantDB) copyCollection(ctx context.Context, source, dest string) error {
+	physical, err := db.physicalCollections(ctx)
+	if err != nil {
+		return err
+	}
+	if physical[dest] {
+		if err := db.request(ctx, "DELETE", "/collections/"+dest, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	var batch []point
+	err = db.scroll(ctx, source, true, nil, func(p point) error {
+		batch = append(batch, point{ID: p.ID, Vector: p.Vector, Payload: p.Payload})
+		if len(batch) < 256 {
+			return nil
+		}
+		err := db.upsert(ctx, dest, batch)
+		batch = nil
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return db.upsert(ctx, dest, batch)
+}
+
+// upsert writes points to a collection, creating it on first use
+func (db *QdrantDB) upsert(ctx context.Context, collection string, points []point) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	db.createMu.Lock()
+	exists, err := db.collectionExists(ctx, collection)
+	if err == nil && !exists {
+		err = db.CreateCollection(ctx, collection, len(points[0].Vector))
+	}
+	db.createMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{"points": points}
+	path := fmt.Sprintf("/collections/%s/points?wait=true", collection)
+	if err := db.request(ctx, "PUT", path, body, nil); err != nil {
+		return fmt.Errorf("failed to upsert points: %w", err)
+	}
+
+	return nil
+}
+
+// search runs a vector search against a single collection
+func (db *QdrantDB) search(ctx context.Context, collection string, vector []float32, filter map[string]interface{}, limit int) ([]vectordb.SearchResult, error) {
+	body := map[string]interface{}{
+		"vector":       vector,
+		"limit":        limit,
+		"with_payload": true,
+	}
+	if len(filter) > 0 {
+		body["filter"] = buildFilter(filter, true)
+	}
+
+	var resp struct {
+		Result []point `json:"result"`
+	}
+	path := fmt.Sprintf("/collections/%s/points/search", collection)
+	if err := db.request(ctx, "POST", path, body, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]vectordb.SearchResult, 0, len(resp.Result))
+	for _, p := range resp.Result {
+		result := vectordb.SearchResult{
+			ID:    fmt.Sprintf("%v", p.ID),
+			Score: p.Score,
+		}
+		// Qdrant scores euclidean matches by distance, smallest first
+		if db.metric == vectordb.MetricEuclidean {
+			result.Score = vectordb.EuclideanScore(float64(p.Score))
+		}
+		result.DocumentID, _ = p.Payload["document_id"].(string)
+		result.Content, _ = p.Payload["content"].(string)
+		result.Metadata, _ = p.Payload["metadata"].(map[string]interface{})
+		if idx, ok := p.Payload["chunk_index"].(float64); ok {
+			result.ChunkIndex = int(idx)
+		}
+		if offset, ok := p.Payload["start_offset"].(float64); ok {
+			result.StartOffset = int(offset)
+		}
+		if offset, ok := p.Payload["end_offset"].(float64); ok {
+			result.EndOffset = int(offset)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// scroll pages through all points in a collection
+func (db *QdrantDB) scroll(ctx context.Context, collection string, withVector bool, fields []string, fn func(point) error) error {
+	var offset interface{}
+
+	for {
+		body := map[string]interface{}{
+			"limit":       256,
+			"with_vector": withVector,
+		}
+		if len(fields) > 0 {
+			body["with_payload"] = fields
+		} else {
+			body["with_payload"] = true
+		}
+		if offset != nil {
+			body["offset"] = offset
+		}
+
+		var resp struct {
+			Result struct {
+				Points         []point     `json:"points"`
+				NextPageOffset interface{} `json:"next_page_offset"`
+			} `json:"result"`
+		}
+		path := fmt.Sprintf("/collections/%s/points/scroll", collection)
+		if err := db.request(ctx, "POST", path, body, &resp); err != nil {
+			return err
+		}
+
+		for _, p := range resp.Result.Points {
+			if err := fn(p); err != nil {
+				return err
+			}
+		}
+
+		if resp.Result.NextPageOffset == nil {
+			return nil
+		}
+		offset = resp.Result.NextPageOffset
+	}
+}
+
+// physicalCollections returns the names of the collections that exist, not
+// counting aliases
+func (db *QdrantDB) physicalCollections(ctx context.Context) (map[string]bool, error) {
+	var resp struct {
+		Result struct {
+			Collections []struct {
+				Name string `json:"name"`
+			} `json:"collections"`
+		} `json:"result"`
+	}
+	if err := db.request(ctx, "GET", "/collections", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(resp.Result.Collections))
+	for _, c := range resp.Result.Collections {
+		names[c.Name] = true
+	}
+	return names, nil
+}
+
+// listCollections returns all collection names. Collections reached through
+// an alias are reported under the alias name.
+func (db *QdrantDB) listCollections(ctx context.Context) ([]string, error) {
+	physical, err := db.physicalCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := db.listAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aliased := make(map[string]string)
+	for alias, target := range aliases {
+		aliased[target] = alias
+	}
+
+	names := make([]string, 0, len(physical))
+	for name := range physical {
+		if alias, ok := aliased[name]; ok {
+			names = append(names, alias)
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// listAliases returns a map of alias name to collection name
+func (db *QdrantDB) listAliases(ctx context.Context) (map[string]string, error) {
+	var resp struct {
+		Result struct {
+			Aliases []struct {
+				AliasName      string `json:"alias_name"`
+				CollectionName string `json:"collection_name"`
+			} `json:"aliases"`
+		} `json:"result"`
+	}
+	if err := db.request(ctx, "GET", "/aliases", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	aliases := make(map[string]string, len(resp.Result.Aliases))
+	for _, a := range resp.Result.Aliases {
+		aliases[a.AliasName] = a.CollectionName
+	}
+
+	return aliases, nil
+}
+
+// CollectionDimension returns the vector size the collection was created with
+func (db *QdrantDB) CollectionDimension(ctx context.Context, collection string) (int, error) {
+	collection = db.collectionName(collection)
+	exists, err := db.collectionExists(ctx, collection)
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var resp struct {
+		Result struct {
+			Config struct {
+				Params struct {
+					Vectors struct {
+						Size int `json:"size"`
+					} `json:"vectors"`
+				} `json:"params"`
+			} `json:"config"`
+		} `json:"result"`
+	}
+	if err := db.request(ctx, "GET", "/collections/"+collection, nil, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Result.Config.Params.Vectors.Size, nil
+}
+
+// collectionExists checks whether a collection has been created
+func (db *QdrantDB) collectionExists(ctx context.Context, name string) (bool, error) {
+	collections, err := db.listCollections(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range collections {
+		if c == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// collectionName falls back to the default collection
+func (db *QdrantDB) collectionName(name string) string {
+	if name == "" {
+		return db.defaultCollection
+	}
+	return name
+}
+
+// request sends a JSON request to Qdrant and decodes the response into out
+func (db *QdrantDB) request(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, db.endpoint+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := db.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Qdrant: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("qdrant returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// buildFilter converts a flat key/value filter into a Qdrant "must" filter.
+// Metadata keys are nested under the metadata payload field.
+func buildFilter(filter map[string]interface{}, metadata bool) map[string]interface{} {
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	must := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		field := key
+		if metadata {
+			field = "metadata." + key
+		}
+
+		condition := map[string]interface{}{"key": field}
+		switch v := filter[key].(type) {
+		case []interface{}:
+			condition["match"] = map[string]interface{}{"any": v}
+		case []string:
+			condition["match"] = map[string]interface{}{"any": v}
+		default:
+			condition["match"] = map[string]interface{}{"value": v}
+		}
+		must = append(must, condition)
+	}
+
+	return map[string]interface{}{"must": must}
+}
+
+// pointID derives a stable UUID for a document chunk, since Qdrant only
+// accepts unsigned integers or UUIDs as point IDs
+func pointID(documentID string, chunkIndex int) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s#%d", documentID, chunkIndex)))
+	sum[6] = (sum[6] & 0x0f) | 0x50 // version 5
+	sum[8] = (sum[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
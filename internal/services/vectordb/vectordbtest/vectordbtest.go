@@ -0,0 +1,502 @@
+// internal/services/vectordb/vectordbtest/vectordbtest.go
+// Package vectordbtest provides a conformance suite for vectordb.VectorDB
+// implementations
+package vectordbtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/localcloud-sh/localcloud/internal/services/vectordb"
+)
+
+// ConformanceSuite checks that a vectordb.VectorDB implementation behaves
+// the way the rest of LocalCloud expects. Providers run it from their own
+// tests:
+//
+//	vectordbtest.ConformanceSuite{
+//		New: func(t *testing.T, cfg *vectordb.Config) vectordb.VectorDB {
+//			return newEmptyDB(t, cfg)
+//		},
+//		Metrics: vectordb.Metrics,
+//	}.Run(t)
+type ConformanceSuite struct {
+	// New returns an empty database opened with cfg for a single subtest.
+	// Any cleanup should be registered with t.Cleanup.
+	New func(t *testing.T, cfg *vectordb.Config) vectordb.VectorDB
+
+	// Dimension of the generated vectors. Defaults to 8.
+	Dimension int
+
+	// Metrics the provider supports. Score ordering is checked for each of
+	// them; the other checks use cosine. Defaults to cosine only.
+	Metrics []string
+
+	// SingleCollection marks providers that keep every embedding in one
+	// collection, which skips the collection isolation checks.
+	SingleCollection bool
+
+	// Prefix is prepended to every collection the suite uses, including the
+	// default one, so New can clean up without touching other collections.
+	// Ignored when SingleCollection is set.
+	Prefix string
+}
+
+// Run executes every conformance check as a subtest
+func (s ConformanceSuite) Run(t *testing.T) {
+	if s.New == nil {
+		t.Fatal("ConformanceSuite.New must be set")
+	}
+	if s.Dimension <= 0 {
+		s.Dimension = 8
+	}
+	if len(s.Metrics) == 0 {
+		s.Metrics = []string{vectordb.MetricCosine}
+	}
+
+	t.Run("UpsertReplaces", s.testUpsertReplaces)
+	t.Run("ChunkOrdering", s.testChunkOrdering)
+	t.Run("Filter", s.testFilter)
+	t.Run("ScoreOrdering", func(t *testing.T) {
+		for _, metric := range s.Metrics {
+			metric := metric
+			t.Run(metric, func(t *testing.T) { s.testScoreOrdering(t, metric) })
+		}
+	})
+	t.Run("CollectionIsolation", s.testCollectionIsolation)
+	t.Run("DeleteDocument", s.testDeleteDocument)
+	t.Run("Stats", s.testStats)
+	t.Run("ConcurrentWriters", s.testConcurrentWriters)
+}
+
+func (s ConformanceSuite) testUpsertReplaces(t *testing.T) {
+	ctx := context.Background()
+	db := s.open(t, vectordb.MetricCosine)
+	before := s.stats(t, db)
+
+	doc := vectordb.Document{ID: conformanceID(1), Content: "first version", Vector: s.vector(1)}
+	if err := db.StoreEmbedding(ctx, doc); err != nil {
+		t.Fatalf("StoreEmbedding: %v", err)
+	}
+
+	doc.Content = "second version"
+	if err := db.StoreEmbedding(ctx, doc); err != nil {
+		t.Fatalf("StoreEmbedding (update): %v", err)
+	}
+
+	if got := s.stats(t, db).TotalVectors - before.TotalVectors; got != 1 {
+		t.Errorf("storing the same document twice added %d vectors, want 1", got)
+	}
+
+	results := s.search(t, db, vectordb.QueryVector{Vector: s.vector(1)}, 5)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if results[0].Content != "second version" {
+		t.Errorf("content = %q, want the upserted %q", results[0].Content, "second version")
+	}
+}
+
+func (s ConformanceSuite) testChunkOrdering(t *testing.T) {
+	ctx := context.Background()
+	db := s.open(t, vectordb.MetricCosine)
+
+	docID := conformanceID(2)
+	chunks := make([]vectordb.Chunk, 5)
+	for i := range chunks {
+		chunks[i] = vectordb.Chunk{
+			DocumentID:  docID,
+			ChunkIndex:  i,
+			Content:     fmt.Sprintf("chunk %d", i),
+			Vector:      s.vector(i),
+			StartOffset: i * 100,
+			EndOffset:   (i + 1) * 100,
+		}
+	}
+	if err := db.StoreChunks(ctx, chunks); err != nil {
+		t.Fatalf("StoreChunks: %v", err)
+	}
+
+	for i := range chunks {
+		results := s.search(t, db, vectordb.QueryVector{Vector: s.vector(i)}, 1)
+		if len(results) != 1 {
+			t.Fatalf("chunk %d: got %d results, want 1", i, len(results))
+		}
+		if results[0].DocumentID != docID {
+			t.Errorf("chunk %d: document_id = %q, want %q", i, results[0].DocumentID, docID)
+		}
+		if results[0].ChunkIndex != i {
+			t.Errorf("chunk %d: chunk_index = %d", i, results[0].ChunkIndex)
+		}
+		if results[0].Content != chunks[i].Content {
+			t.Errorf("chunk %d: content = %q, want %q", i, results[0].Content, chunks[i].Content)
+		}
+	}
+}
+
+func (s ConformanceSuite) testFilter(t *testing.T) {
+	ctx := context.Background()
+	db := s.open(t, vectordb.MetricCosine)
+
+	languages := []string{"go", "python", "rust"}
+	var docs []vectordb.Document
+	for i := 0; i < 6; i++ {
+		docs = append(docs, vectordb.Document{
+			ID:       conformanceID(10 + i),
+			Content:  fmt.Sprintf("document %d", i),
+			Vector:   s.vector(i),
+			Metadata: map[string]interface{}{"lang": languages[i%len(languages)]},
+		})
+	}
+	if err := db.StoreEmbeddings(ctx, docs); err != nil {
+		t.Fatalf("StoreEmbeddings: %v", err)
+	}
+
+	results := s.search(t, db, vectordb.QueryVector{
+		Vector: s.vector(1),
+		Filter: map[string]interface{}{"lang": "go"},
+	}, 10)
+	if len(results) != 2 {
+		t.Errorf("scalar filter returned %d results, want 2", len(results))
+	}
+	for _, r := range results {
+		if r.Metadata["lang"] != "go" {
+			t.Errorf("scalar filter returned lang=%v", r.Metadata["lang"])
+		}
+	}
+
+	results = s.search(t, db, vectordb.QueryVector{
+		Vector: s.vector(1),
+		Filter: map[string]interface{}{"lang": []interface{}{"go", "rust"}},
+	}, 10)
+	if len(results) != 4 {
+		t.Errorf("list filter returned %d results, want 4", len(results))
+	}
+	for _, r := range results {
+		if r.Metadata["lang"] == "python" {
+			t.Errorf("list filter returned lang=python")
+		}
+	}
+
+	results = s.search(t, db, vectordb.QueryVector{
+		Vector: s.vector(1),
+		Filter: map[string]interface{}{"lang": "cobol"},
+	}, 10)
+	if len(results) != 0 {
+		t.Errorf("filter with no matches returned %d results", len(results))
+	}
+}
+
+func (s ConformanceSuite) testScoreOrdering(t *testing.T, metric string) {
+	ctx := context.Background()
+	db := s.open(t, metric)
+
+	// Unit vectors on different axes, plus a long vector between axes 3 and
+	// 4. For a query along axis 3 the exact match wins under cosine and
+	// euclidean distance, and the long vector wins under the dot product.
+	var docs []vectordb.Document
+	for i := 0; i < s.Dimension; i++ {
+		docs = append(docs, vectordb.Document{
+			ID:      conformanceID(20 + i),
+			Content: fmt.Sprintf("document %d", i),
+			Vector:  s.vector(i),
+		})
+	}
+	long := make([]float32, s.Dimension)
+	for i, x := range normalize(add(s.vector(3), s.vector(4))) {
+		long[i] = 3 * x
+	}
+	docs = append(docs, vectordb.Document{ID: conformanceID(20 + s.Dimension), Content: "long", Vector: long})
+	if err := db.StoreEmbeddings(ctx, docs); err != nil {
+		t.Fatalf("StoreEmbeddings: %v", err)
+	}
+
+	query := s.vector(3)
+	want := make(map[string]float64, len(docs))
+	for _, doc := range docs {
+		want[doc.ID] = expectedScore(metric, doc.Vector, query)
+	}
+	best := append([]vectordb.Document(nil), docs...)
+	sort.SliceStable(best, func(i, j int) bool { return want[best[i].ID] > want[best[j].ID] })
+
+	results := s.search(t, db, vectordb.QueryVector{Vector: query}, len(docs))
+	if len(results) != len(docs) {
+		t.Fatalf("got %d results, want %d", len(results), len(docs))
+	}
+	if results[0].DocumentID != best[0].ID {
+		t.Errorf("best match = %q, want %q (%s)", results[0].Content, best[0].Content, metric)
+	}
+	for i, r := range results {
+		if i > 0 && r.Score > results[i-1].Score {
+			t.Errorf("results not ordered by descending score at %d: %f > %f",
+				i, r.Score, results[i-1].Score)
+		}
+		if expected := want[r.DocumentID]; math.Abs(float64(r.Score)-expected) > 0.01*math.Max(1, math.Abs(expected)) {
+			t.Errorf("%s score = %f, want %f for %s", r.Content, r.Score, expected, metric)
+		}
+	}
+
+	results = s.search(t, db, vectordb.QueryVector{Vector: query}, 2)
+	if len(results) != 2 {
+		t.Errorf("limit 2 returned %d results", len(results))
+	}
+}
+
+func (s ConformanceSuite) testCollectionIsolation(t *testing.T) {
+	if s.SingleCollection {
+		t.Skip("provider stores all embeddings in a single collection")
+	}
+
+	ctx := context.Background()
+	db := s.open(t, vectordb.MetricCosine)
+
+	a, b := s.Prefix+"conformance_a", s.Prefix+"conformance_b"
+	for _, name := range []string{a, b} {
+		if err := db.CreateCollection(ctx, name, s.Dimension); err != nil {
+			t.Fatalf("CreateCollection(%s): %v", name, err)
+		}
+	}
+
+	doc := vectordb.Document{
+		ID:         conformanceID(30),
+		Content:    "only in a",
+		Vector:     s.vector(0),
+		Collection: a,
+	}
+	if err := db.StoreEmbedding(ctx, doc); err != nil {
+		t.Fatalf("StoreEmbedding: %v", err)
+	}
+
+	results := s.search(t, db, vectordb.QueryVector{Vector: s.vector(0), Collection: b}, 5)
+	if len(results) != 0 {
+		t.Errorf("collection b returned %d results from collection a", len(results))
+	}
+
+	results = s.search(t, db, vectordb.QueryVector{Vector: s.vector(0), Collection: a}, 5)
+	if len(results) != 1 {
+		t.Errorf("collection a returned %d results, want 1", len(results))
+	}
+
+	if err := db.DeleteCollection(ctx, a); err != nil {
+		t.Fatalf("DeleteCollection: %v", err)
+	}
+	stats, err := db.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	for _, name := range stats.Collections {
+		if name == a {
+			t.Errorf("deleted collection still listed in stats")
+		}
+	}
+}
+
+func (s ConformanceSuite) testDeleteDocument(t *testing.T) {
+	ctx := context.Background()
+	db := s.open(t, vectordb.MetricCosine)
+
+	keep, drop := conformanceID(40), conformanceID(41)
+	var chunks []vectordb.Chunk
+	for i := 0; i < 3; i++ {
+		chunks = append(chunks,
+			vectordb.Chunk{DocumentID: keep, ChunkIndex: i, Content: "keep", Vector: s.vector(i)},
+			vectordb.Chunk{DocumentID: drop, ChunkIndex: i, Content: "drop", Vector: s.vector(i + 3)},
+		)
+	}
+	if err := db.StoreChunks(ctx, chunks); err != nil {
+		t.Fatalf("StoreChunks: %v", err)
+	}
+
+	if err := db.DeleteDocument(ctx, drop); err != nil {
+		t.Fatalf("DeleteDocument: %v", err)
+	}
+
+	results := s.search(t, db, vectordb.QueryVector{Vector: s.vector(4)}, 10)
+	for _, r := range results {
+		if r.DocumentID == drop {
+			t.Errorf("deleted document chunk %d still returned", r.ChunkIndex)
+		}
+	}
+	if len(results) != 3 {
+		t.Errorf("got %d results after delete, want the 3 kept chunks", len(results))
+	}
+
+	if err := db.DeleteDocument(ctx, conformanceID(99)); err != nil {
+		t.Errorf("deleting an unknown document should not fail: %v", err)
+	}
+}
+
+func (s ConformanceSuite) testStats(t *testing.T) {
+	ctx := context.Background()
+	db := s.open(t, vectordb.MetricCosine)
+	before := s.stats(t, db)
+
+	var chunks []vectordb.Chunk
+	for doc := 0; doc < 3; doc++ {
+		for i := 0; i < 4; i++ {
+			chunks = append(chunks, vectordb.Chunk{
+				DocumentID: conformanceID(50 + doc),
+				ChunkIndex: i,
+				Content:    fmt.Sprintf("doc %d chunk %d", doc, i),
+				Vector:     s.vector(doc*4 + i),
+			})
+		}
+	}
+	if err := db.StoreChunks(ctx, chunks); err != nil {
+		t.Fatalf("StoreChunks: %v", err)
+	}
+
+	stats := s.stats(t, db)
+	if got := stats.TotalDocuments - before.TotalDocuments; got != 3 {
+		t.Errorf("TotalDocuments grew by %d, want 3", got)
+	}
+	if got := stats.TotalVectors - before.TotalVectors; got != 12 {
+		t.Errorf("TotalVectors grew by %d, want 12", got)
+	}
+	if len(stats.Collections) == 0 {
+		t.Errorf("Collections is empty")
+	}
+}
+
+func (s ConformanceSuite) testConcurrentWriters(t *testing.T) {
+	ctx := context.Background()
+	db := s.open(t, vectordb.MetricCosine)
+	before := s.stats(t, db)
+
+	const writers = 8
+	const chunksPerWriter = 5
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			chunks := make([]vectordb.Chunk, chunksPerWriter)
+			for i := range chunks {
+				chunks[i] = vectordb.Chunk{
+					DocumentID: conformanceID(60 + w),
+					ChunkIndex: i,
+					Content:    fmt.Sprintf("writer %d chunk %d", w, i),
+					Vector:     s.vector(w*chunksPerWriter + i),
+				}
+			}
+			if err := db.StoreChunks(ctx, chunks); err != nil {
+				errs <- err
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent StoreChunks: %v", err)
+	}
+
+	stats := s.stats(t, db)
+	if got := stats.TotalVectors - before.TotalVectors; got != writers*chunksPerWriter {
+		t.Errorf("TotalVectors grew by %d, want %d", got, writers*chunksPerWriter)
+	}
+	if got := stats.TotalDocuments - before.TotalDocuments; got != writers {
+		t.Errorf("TotalDocuments grew by %d, want %d", got, writers)
+	}
+}
+
+// open returns an empty database that ranks vectors by metric
+func (s ConformanceSuite) open(t *testing.T, metric string) vectordb.VectorDB {
+	t.Helper()
+	cfg := &vectordb.Config{EmbeddingDim: s.Dimension, Metric: metric}
+	if !s.SingleCollection && s.Prefix != "" {
+		cfg.Collection = s.Prefix + "default"
+	}
+	return s.New(t, cfg)
+}
+
+// stats runs GetStats and fails the test on error. Providers count every
+// collection, so checks compare against a baseline taken after open.
+func (s ConformanceSuite) stats(t *testing.T, db vectordb.VectorDB) vectordb.Stats {
+	t.Helper()
+	stats, err := db.GetStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	return stats
+}
+
+// search runs SearchSimilar and fails the test on error
+func (s ConformanceSuite) search(t *testing.T, db vectordb.VectorDB, query vectordb.QueryVector, limit int) []vectordb.SearchResult {
+	t.Helper()
+	results, err := db.SearchSimilar(context.Background(), query, limit)
+	if err != nil {
+		t.Fatalf("SearchSimilar: %v", err)
+	}
+	return results
+}
+
+// vector returns a deterministic unit vector for seed. Seeds below Dimension
+// lean on different axes, so each one is its own nearest neighbour.
+func (s ConformanceSuite) vector(seed int) []float32 {
+	v := make([]float32, s.Dimension)
+	for i := range v {
+		// A dominant axis plus a small seed-dependent tail
+		x := 0.05 * float64((seed*7+i*3)%11) / 11
+		if i == seed%s.Dimension {
+			x++
+		}
+		v[i] = float32(x)
+	}
+	return normalize(v)
+}
+
+// expectedScore computes the score a provider should report for a stored
+// vector under metric
+func expectedScore(metric string, stored, query []float32) float64 {
+	var dot, stored2, query2, dist2 float64
+	for i := range stored {
+		a, b := float64(stored[i]), float64(query[i])
+		dot += a * b
+		stored2 += a * a
+		query2 += b * b
+		dist2 += (a - b) * (a - b)
+	}
+	switch metric {
+	case vectordb.MetricDot:
+		return dot
+	case vectordb.MetricEuclidean:
+		return float64(vectordb.EuclideanScore(math.Sqrt(dist2)))
+	default:
+		return dot / math.Sqrt(stored2*query2)
+	}
+}
+
+// add returns the element-wise sum of two vectors
+func add(a, b []float32) []float32 {
+	sum := make([]float32, len(a))
+	for i := range a {
+		sum[i] = a[i] + b[i]
+	}
+	return sum
+}
+
+// normalize scales v to unit length
+func normalize(v []float32) []float32 {
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	norm = math.Sqrt(norm)
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = float32(float64(x) / norm)
+	}
+	return out
+}
+
+// conformanceID returns a UUID-formatted ID, which every provider accepts
+func conformanceID(n int) string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", n)
+}
@@ -0,0 +1,160 @@
+// internal/services/whisper/client.go
+// Package whisper is a client for the project's speech-to-text service, the
+// Whisper ASR webservice started for the stt component.
+package whisper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Output formats the service can return a transcript in
+const (
+	FormatText = "text"
+	FormatSRT  = "srt"
+	FormatVTT  = "vtt"
+	FormatJSON = "json"
+)
+
+// Formats are the supported output formats
+var Formats = []string{FormatText, FormatSRT, FormatVTT, FormatJSON}
+
+// ErrUnavailable is returned when the service does not accept connections
+var ErrUnavailable = errors.New("speech-to-text service is not reachable")
+
+// TranscribeOptions control a transcription
+type TranscribeOptions struct {
+	Format    string // One of Formats; defaults to text
+	Language  string // Spoken language code such as "en"; detected when empty
+	Translate bool   // Translate the speech to English instead of transcribing
+	Prompt    string // Text that guides spelling and style, such as names
+}
+
+// Client calls the speech-to-text service
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewClient creates a client for the service at baseURL, such as
+// http://localhost:9100
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		// Transcribing long recordings takes minutes; callers bound it with
+		// their context
+		http: &http.Client{},
+	}
+}
+
+// Ready reports whether the service is up and has loaded its model
+func (c *Client) Ready(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/docs", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// TranscribeFile transcribes the audio or video file at path and returns the
+// transcript in the requested format
+func (c *Client) TranscribeFile(ctx context.Context, path string, opts TranscribeOptions) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return c.Transcribe(ctx, filepath.Base(path), file, opts)
+}
+
+// Transcribe transcribes audio read from r; name is the file name sent with
+// it, whose extension helps the service decode the audio
+func (c *Client) Transcribe(ctx context.Context, name string, r io.Reader, opts TranscribeOptions) ([]byte, error) {
+	output, err := outputParam(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("output", output)
+	query.Set("encode", "true")
+	query.Set("task", "transcribe")
+	if opts.Translate {
+		query.Set("task", "translate")
+	}
+	if opts.Language != "" {
+		query.Set("language", opts.Language)
+	}
+	if opts.Prompt != "" {
+		query.Set("initial_prompt", opts.Prompt)
+	}
+
+	// Stream the file into the multipart body instead of buffering it
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		part, err := form.CreateFormFile("audio_file", name)
+		if err == nil {
+			_, err = io.Copy(part, r)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/asr?"+query.Encode(), body)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		body.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		message := strings.TrimSpace(string(data))
+		if len(message) > 200 {
+			message = message[:200] + "..."
+		}
+		return nil, fmt.Errorf("transcription failed (%s): %s", resp.Status, message)
+	}
+	return data, nil
+}
+
+// outputParam maps a format to the service's output parameter
+func outputParam(format string) (string, error) {
+	switch format {
+	case "", FormatText, "txt":
+		return "txt", nil
+	case FormatSRT, FormatVTT, FormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, use %s", format, strings.Join(Formats, ", "))
+	}
+}
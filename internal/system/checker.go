@@ -208,7 +208,8 @@ func (c *Checker) CheckRequirements(minRAM, minDisk int64) error {
 	return nil
 }
 
-// RecommendModel suggests the best model based on available RAM
+// RecommendModel suggests the best model based on available RAM. PlanModel
+// also accounts for CPU cores, other components and the context length.
 func (c *Checker) RecommendModel(models []ModelSpec, availableRAM int64) *ModelSpec {
 	var best *ModelSpec
 
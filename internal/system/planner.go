@@ -0,0 +1,311 @@
+package system
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/localcloud-sh/localcloud/internal/catalog"
+)
+
+// Planner constants. Catalog RAM figures include a 2048 token context; longer
+// contexts add KV cache on top.
+const (
+	catalogContext = 2048
+	osHeadroom     = 1 * catalog.GB // Left for the OS and Docker itself
+
+	// Without a GPU generation speed falls with model size, so the planner
+	// proposes at most this many parameters per CPU core
+	paramsPerCore = 1.5e9
+)
+
+// FitRisk says how a model is expected to behave in the available memory
+type FitRisk int
+
+const (
+	FitOK      FitRisk = iota // Fits in free memory
+	FitSwap                   // Fits in total memory only; likely to swap
+	FitOOM                    // Exceeds total memory; likely to be killed
+	FitUnknown                // Not in the catalog
+)
+
+// PlanRequest describes the machine and the project the model must fit into.
+// Zero TotalRAM, AvailableRAM or CPUCount are read from the system by
+// Checker.PlanModel.
+type PlanRequest struct {
+	TotalRAM     int64
+	AvailableRAM int64
+	CPUCount     int
+	ReservedRAM  int64 // Memory of the project's other enabled components
+	Context      int   // Desired context length in tokens (default 4096)
+	Capability   string
+}
+
+// ModelPlan is a recommended model and quantization with the reasoning
+type ModelPlan struct {
+	Model        catalog.Model
+	Tag          string // Name to pull: the model or one of its variants
+	Quantization string
+	RAM          int64 // Estimated memory including the requested context
+	Budget       int64 // Memory available to the model
+	Risk         FitRisk
+	Reasons      []string
+}
+
+// ModelFit is the assessment of a model chosen by the user
+type ModelFit struct {
+	Name    string
+	RAM     int64
+	Risk    FitRisk
+	Message string
+}
+
+// PlanModel fills in the machine's resources and plans a model from the
+// default catalog
+func (c *Checker) PlanModel(req PlanRequest) (*ModelPlan, error) {
+	if err := c.fillPlanRequest(&req); err != nil {
+		return nil, err
+	}
+	return PlanModel(catalog.Default().Models, req)
+}
+
+// AssessModel fills in the machine's resources and assesses a model the user
+// picked
+func (c *Checker) AssessModel(name string, req PlanRequest) (*ModelFit, error) {
+	if err := c.fillPlanRequest(&req); err != nil {
+		return nil, err
+	}
+	return AssessModel(catalog.Default(), name, req), nil
+}
+
+func (c *Checker) fillPlanRequest(req *PlanRequest) error {
+	if req.TotalRAM == 0 || req.AvailableRAM == 0 {
+		total, available, err := c.GetRAM()
+		if err != nil {
+			return err
+		}
+		req.TotalRAM, req.AvailableRAM = total, available
+	}
+	if req.CPUCount == 0 {
+		count, err := c.GetCPU()
+		if err != nil {
+			return err
+		}
+		req.CPUCount = count
+	}
+	return nil
+}
+
+// planOption is one pullable quantization of a model
+type planOption struct {
+	model        catalog.Model
+	tag          string
+	quantization string
+	ram          int64
+	params       float64
+}
+
+// PlanModel picks the largest model the CPU can run at a usable speed whose
+// memory fits the free RAM left after the other components, then the most
+// precise quantization of it that still fits. When nothing fits free memory
+// the smallest option is returned with its risk.
+func PlanModel(models []catalog.Model, req PlanRequest) (*ModelPlan, error) {
+	if req.Context <= 0 {
+		req.Context = 4096
+	}
+	if req.Capability == "" {
+		req.Capability = catalog.CapabilityChat
+	}
+
+	freeBudget := req.AvailableRAM - req.ReservedRAM
+	totalBudget := req.TotalRAM - osHeadroom - req.ReservedRAM
+	maxParams := float64(req.CPUCount) * paramsPerCore
+
+	var options []planOption
+	for _, m := range models {
+		if !m.Has(req.Capability) || m.IsEmbedding() {
+			continue
+		}
+		if m.Context > 0 && m.Context < req.Context {
+			continue
+		}
+		params := ParseParameters(m.Parameters)
+		options = append(options, planOption{m, m.Name, m.Quantization, EstimateRAM(m, int64(m.RAM), req.Context), params})
+		for _, v := range m.Variants {
+			options = append(options, planOption{m, v.Tag, v.Quantization, EstimateRAM(m, int64(v.RAM), req.Context), params})
+		}
+	}
+	if len(options) == 0 {
+		return nil, fmt.Errorf("no %s model in the catalog supports a %d token context", req.Capability, req.Context)
+	}
+
+	// Largest model first; among equal sizes recommended models, then the
+	// most precise quantization
+	sort.SliceStable(options, func(i, j int) bool {
+		a, b := options[i], options[j]
+		if a.params != b.params {
+			return a.params > b.params
+		}
+		if a.model.Default != b.model.Default {
+			return a.model.Default
+		}
+		if a.model.Recommended != b.model.Recommended {
+			return a.model.Recommended
+		}
+		return a.ram > b.ram
+	})
+
+	plan := &ModelPlan{Budget: freeBudget}
+	plan.Reasons = append(plan.Reasons, fmt.Sprintf("%s free of %s total, %s reserved for other components → %s for the model",
+		FormatBytes(req.AvailableRAM), FormatBytes(req.TotalRAM), FormatBytes(req.ReservedRAM), FormatBytes(max64(freeBudget, 0))))
+
+	var chosen *planOption
+	skippedForCPU := false
+	for i := range options {
+		o := &options[i]
+		if o.ram > freeBudget {
+			continue
+		}
+		if o.params > maxParams && req.CPUCount > 0 {
+			skippedForCPU = true
+			continue
+		}
+		chosen = o
+		break
+	}
+
+	if chosen == nil {
+		// Nothing fits free memory: take the smallest option
+		smallest := &options[0]
+		for i := range options {
+			if options[i].ram < smallest.ram {
+				smallest = &options[i]
+			}
+		}
+		chosen = smallest
+		plan.Risk = riskFor(chosen.ram, freeBudget, totalBudget)
+		plan.Reasons = append(plan.Reasons, fmt.Sprintf("No model fits in free memory; %s is the smallest (~%s)", chosen.tag, FormatBytes(chosen.ram)))
+	} else {
+		plan.Reasons = append(plan.Reasons, fmt.Sprintf("%s (%s parameters, %s) needs ~%s with a %d token context",
+			chosen.tag, chosen.model.Parameters, chosen.quantization, FormatBytes(chosen.ram), req.Context))
+		if skippedForCPU {
+			plan.Reasons = append(plan.Reasons, fmt.Sprintf("Larger models fit in memory but would generate slowly on %d CPU cores", req.CPUCount))
+		}
+		if better := largerVariant(options, chosen); better != nil {
+			plan.Reasons = append(plan.Reasons, fmt.Sprintf("%s (%s) would need ~%s", better.tag, better.quantization, FormatBytes(better.ram)))
+		}
+	}
+
+	plan.Model = chosen.model
+	plan.Tag = chosen.tag
+	plan.Quantization = chosen.quantization
+	plan.RAM = chosen.ram
+	return plan, nil
+}
+
+// largerVariant returns the next more precise quantization of the chosen
+// model, which did not fit
+func largerVariant(options []planOption, chosen *planOption) *planOption {
+	var next *planOption
+	for i := range options {
+		o := &options[i]
+		if o.model.Name != chosen.model.Name || o.ram <= chosen.ram {
+			continue
+		}
+		if next == nil || o.ram < next.ram {
+			next = o
+		}
+	}
+	return next
+}
+
+// AssessModel estimates whether a model the user picked fits. Models the
+// catalog does not know are reported as FitUnknown.
+func AssessModel(c *catalog.Catalog, name string, req PlanRequest) *ModelFit {
+	if req.Context <= 0 {
+		req.Context = 4096
+	}
+	fit := &ModelFit{Name: name}
+
+	m := c.Lookup(name)
+	if m == nil {
+		fit.Risk = FitUnknown
+		fit.Message = fmt.Sprintf("%s is not in the model catalog; its memory use is unknown", name)
+		return fit
+	}
+
+	ram := int64(m.RAM)
+	for _, v := range m.Variants {
+		if strings.EqualFold(v.Tag, name) {
+			ram = int64(v.RAM)
+		}
+	}
+	if !m.IsEmbedding() {
+		ram = EstimateRAM(*m, ram, req.Context)
+	}
+	fit.RAM = ram
+
+	freeBudget := req.AvailableRAM - req.ReservedRAM
+	totalBudget := req.TotalRAM - osHeadroom - req.ReservedRAM
+	fit.Risk = riskFor(ram, freeBudget, totalBudget)
+
+	switch fit.Risk {
+	case FitOK:
+		fit.Message = fmt.Sprintf("%s needs ~%s; %s is free for it", name, FormatBytes(ram), FormatBytes(freeBudget))
+	case FitSwap:
+		fit.Message = fmt.Sprintf("%s needs ~%s but only %s is free after other components; the system will likely swap and run slowly",
+			name, FormatBytes(ram), FormatBytes(max64(freeBudget, 0)))
+	case FitOOM:
+		fit.Message = fmt.Sprintf("%s needs ~%s, more than this machine can give it (%s total); it will likely be killed for running out of memory",
+			name, FormatBytes(ram), FormatBytes(req.TotalRAM))
+	}
+	return fit
+}
+
+func riskFor(ram, freeBudget, totalBudget int64) FitRisk {
+	switch {
+	case ram <= freeBudget:
+		return FitOK
+	case ram <= totalBudget:
+		return FitSwap
+	default:
+		return FitOOM
+	}
+}
+
+// EstimateRAM adds the KV cache for contexts longer than the catalog's
+// figure. The cache is estimated from the parameter count; real models with
+// grouped-query attention usually need less.
+func EstimateRAM(m catalog.Model, ram int64, context int) int64 {
+	if context <= catalogContext {
+		return ram
+	}
+	params := ParseParameters(m.Parameters)
+	perToken := int64(12*1024*params/1e9) + 32*1024
+	return ram + int64(context-catalogContext)*perToken
+}
+
+// ParseParameters parses a parameter count such as "3.2B" or "137M"
+func ParseParameters(s string) float64 {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	factor := 1.0
+	switch {
+	case strings.HasSuffix(s, "B"):
+		factor = 1e9
+	case strings.HasSuffix(s, "M"):
+		factor = 1e6
+	}
+	n, err := strconv.ParseFloat(strings.TrimRight(s, "BM"), 64)
+	if err != nil {
+		return 0
+	}
+	return n * factor
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}